@@ -0,0 +1,198 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// subcommand is a CLI subcommand with its own flag set.
+type subcommand struct {
+	flags       *flag.FlagSet
+	description string
+	args        []string // Completions for positional arguments
+}
+
+// completionShells are the shells the completion subcommand can generate scripts for.
+var completionShells = []string{"bash", "zsh", "fish"}
+
+// completionFlag is a flag as offered by a completion script.
+type completionFlag struct {
+	name        string
+	description string
+	takesValue  bool
+}
+
+// completionFlags lists the flags of fs sorted by name.
+func completionFlags(fs *flag.FlagSet) []completionFlag {
+	var flags []completionFlag
+	fs.VisitAll(func(f *flag.Flag) {
+		boolFlag, ok := f.Value.(interface{ IsBoolFlag() bool })
+		flags = append(flags, completionFlag{
+			name:        f.Name,
+			description: f.Usage,
+			takesValue:  !ok || !boolFlag.IsBoolFlag(),
+		})
+	})
+	sort.Slice(flags, func(i, j int) bool { return flags[i].name < flags[j].name })
+	return flags
+}
+
+// writeCompletion writes the completion script for shell, built from the flags
+// of root and each subcommand.
+func writeCompletion(w io.Writer, shell string, root *flag.FlagSet, subcommands []subcommand) error {
+	switch shell {
+	case "bash":
+		writeBashCompletion(w, root, subcommands)
+	case "zsh":
+		writeZshCompletion(w, root, subcommands)
+	case "fish":
+		writeFishCompletion(w, root, subcommands)
+	default:
+		return fmt.Errorf("unsupported shell %q (use %s)", shell, strings.Join(completionShells, ", "))
+	}
+	return nil
+}
+
+// flagWords returns the flags as they are typed on the command line, e.g. "-pr".
+func flagWords(flags []completionFlag) []string {
+	words := make([]string, len(flags))
+	for i, f := range flags {
+		words[i] = "-" + f.name
+	}
+	return words
+}
+
+// valueFlagWords returns the flags that take a value.
+func valueFlagWords(flags []completionFlag) []string {
+	var words []string
+	for _, f := range flags {
+		if f.takesValue {
+			words = append(words, "-"+f.name)
+		}
+	}
+	return words
+}
+
+func writeBashCompletion(w io.Writer, root *flag.FlagSet, subcommands []subcommand) {
+	rootFlags := completionFlags(root)
+	names := make([]string, len(subcommands))
+	for i, cmd := range subcommands {
+		names[i] = cmd.flags.Name()
+	}
+
+	fmt.Fprintf(w, "# bash completion for pr-bot\n")
+	fmt.Fprintf(w, "# Load with: source <(pr-bot completion bash)\n")
+	fmt.Fprintf(w, "_pr_bot() {\n")
+	fmt.Fprintf(w, "    local cur=\"${COMP_WORDS[COMP_CWORD]}\" prev=\"${COMP_WORDS[COMP_CWORD-1]}\"\n")
+	fmt.Fprintf(w, "    local subcommand=\"\" word\n")
+	fmt.Fprintf(w, "    for word in \"${COMP_WORDS[@]:1:COMP_CWORD-1}\"; do\n")
+	fmt.Fprintf(w, "        case \"$word\" in\n")
+	fmt.Fprintf(w, "            %s) subcommand=\"$word\"; break ;;\n", strings.Join(names, "|"))
+	fmt.Fprintf(w, "        esac\n")
+	fmt.Fprintf(w, "    done\n\n")
+	fmt.Fprintf(w, "    case \"$subcommand\" in\n")
+	for _, cmd := range subcommands {
+		flags := completionFlags(cmd.flags)
+		fmt.Fprintf(w, "        %s)\n", cmd.flags.Name())
+		if valueFlags := valueFlagWords(flags); len(valueFlags) > 0 {
+			fmt.Fprintf(w, "            case \"$prev\" in %s) return ;; esac\n", strings.Join(valueFlags, "|"))
+		}
+		words := append(flagWords(flags), cmd.args...)
+		fmt.Fprintf(w, "            COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(words, " "))
+		fmt.Fprintf(w, "            return ;;\n")
+	}
+	fmt.Fprintf(w, "    esac\n\n")
+	if valueFlags := valueFlagWords(rootFlags); len(valueFlags) > 0 {
+		fmt.Fprintf(w, "    case \"$prev\" in %s) return ;; esac\n", strings.Join(valueFlags, "|"))
+	}
+	words := append(flagWords(rootFlags), names...)
+	fmt.Fprintf(w, "    COMPREPLY=($(compgen -W \"%s\" -- \"$cur\"))\n", strings.Join(words, " "))
+	fmt.Fprintf(w, "}\n")
+	fmt.Fprintf(w, "complete -o default -F _pr_bot pr-bot\n")
+}
+
+// zshQuote quotes s for use inside single quotes in a zsh script.
+func zshQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// zshDescribeEntries returns _describe entries ("name:description") for flags.
+func zshDescribeEntries(flags []completionFlag) []string {
+	entries := make([]string, len(flags))
+	for i, f := range flags {
+		entries[i] = zshQuote("-" + f.name + ":" + f.description)
+	}
+	return entries
+}
+
+func writeZshCompletion(w io.Writer, root *flag.FlagSet, subcommands []subcommand) {
+	fmt.Fprintf(w, "#compdef pr-bot\n")
+	fmt.Fprintf(w, "# zsh completion for pr-bot\n")
+	fmt.Fprintf(w, "# Load with: source <(pr-bot completion zsh)\n")
+	fmt.Fprintf(w, "_pr_bot() {\n")
+	fmt.Fprintf(w, "    local i\n")
+	fmt.Fprintf(w, "    local -a options arguments\n")
+	fmt.Fprintf(w, "    for (( i = 2; i < CURRENT; i++ )); do\n")
+	fmt.Fprintf(w, "        case ${words[i]} in\n")
+	for _, cmd := range subcommands {
+		fmt.Fprintf(w, "            %s)\n", cmd.flags.Name())
+		fmt.Fprintf(w, "                options=(%s)\n", strings.Join(zshDescribeEntries(completionFlags(cmd.flags)), " "))
+		if len(cmd.args) > 0 {
+			fmt.Fprintf(w, "                arguments=(%s)\n", strings.Join(cmd.args, " "))
+			fmt.Fprintf(w, "                _describe -t arguments 'argument' arguments\n")
+		}
+		fmt.Fprintf(w, "                _describe -t options 'option' options\n")
+		fmt.Fprintf(w, "                return ;;\n")
+	}
+	fmt.Fprintf(w, "        esac\n")
+	fmt.Fprintf(w, "    done\n\n")
+	fmt.Fprintf(w, "    options=(%s)\n", strings.Join(zshDescribeEntries(completionFlags(root)), " "))
+	var commands []string
+	for _, cmd := range subcommands {
+		commands = append(commands, zshQuote(cmd.flags.Name()+":"+cmd.description))
+	}
+	fmt.Fprintf(w, "    arguments=(%s)\n", strings.Join(commands, " "))
+	fmt.Fprintf(w, "    _describe -t options 'option' options\n")
+	fmt.Fprintf(w, "    _describe -t commands 'command' arguments\n")
+	fmt.Fprintf(w, "}\n")
+	fmt.Fprintf(w, "compdef _pr_bot pr-bot\n")
+}
+
+// fishQuote quotes s for use inside single quotes in a fish script.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, "'", `\'`).Replace(s) + "'"
+}
+
+// writeFishFlags writes a complete command for each flag, offered when condition holds.
+func writeFishFlags(w io.Writer, condition string, flags []completionFlag) {
+	for _, f := range flags {
+		fmt.Fprintf(w, "complete -c pr-bot -n %s -o %s", fishQuote(condition), f.name)
+		if f.description != "" {
+			fmt.Fprintf(w, " -d %s", fishQuote(f.description))
+		}
+		if f.takesValue {
+			fmt.Fprintf(w, " -r")
+		}
+		fmt.Fprintf(w, "\n")
+	}
+}
+
+func writeFishCompletion(w io.Writer, root *flag.FlagSet, subcommands []subcommand) {
+	fmt.Fprintf(w, "# fish completion for pr-bot\n")
+	fmt.Fprintf(w, "# Load with: pr-bot completion fish | source\n")
+	fmt.Fprintf(w, "complete -c pr-bot -f\n")
+	writeFishFlags(w, "__fish_use_subcommand", completionFlags(root))
+	for _, cmd := range subcommands {
+		fmt.Fprintf(w, "complete -c pr-bot -n '__fish_use_subcommand' -a %s -d %s\n", cmd.flags.Name(), fishQuote(cmd.description))
+	}
+	for _, cmd := range subcommands {
+		condition := "__fish_seen_subcommand_from " + cmd.flags.Name()
+		writeFishFlags(w, condition, completionFlags(cmd.flags))
+		if len(cmd.args) > 0 {
+			fmt.Fprintf(w, "complete -c pr-bot -n %s -a %s\n", fishQuote(condition), fishQuote(strings.Join(cmd.args, " ")))
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+
+	"github.com/shay23bra/pr-bot/internal/ga"
+	"github.com/shay23bra/pr-bot/internal/github"
+	"github.com/shay23bra/pr-bot/internal/gitlab"
+	"github.com/shay23bra/pr-bot/internal/jira"
+	"github.com/shay23bra/pr-bot/internal/slack"
+)
+
+// configInitTimeout bounds each token check of -config-init.
+const configInitTimeout = 15 * time.Second
+
+// errInputEnded is returned when stdin closes before -config-init has all answers.
+var errInputEnded = errors.New("input ended before setup finished")
+
+// configInitSetting is one environment variable prompted for by -config-init.
+type configInitSetting struct {
+	envVar       string
+	label        string
+	defaultValue string
+	readFile     bool // The answer is a file path; the file's content is stored
+}
+
+// configInitSection is a group of settings that are checked together, such as the
+// JIRA email and token.
+type configInitSection struct {
+	name     string
+	usedFor  string
+	optional bool
+	settings []configInitSetting
+	validate func(ctx context.Context, values map[string]string) error
+}
+
+// configInitSections returns the settings -config-init prompts for, in order.
+func configInitSections() []configInitSection {
+	defaultCacheDir := ""
+	if dir, err := os.UserCacheDir(); err == nil {
+		defaultCacheDir = filepath.Join(dir, "pr-bot", "repos")
+	}
+
+	return []configInitSection{
+		{
+			name:    "GitHub",
+			usedFor: "reading PRs, branches and tags (https://github.com/settings/tokens)",
+			settings: []configInitSetting{
+				{envVar: "PR_BOT_GITHUB_TOKEN", label: "GitHub token"},
+			},
+			validate: func(ctx context.Context, values map[string]string) error {
+				return github.NewClient(ctx, values["PR_BOT_GITHUB_TOKEN"]).Ping(ctx)
+			},
+		},
+		{
+			name:    "Repository cache",
+			usedFor: "local clones of the analyzed repositories (about 500MB)",
+			settings: []configInitSetting{
+				{envVar: "PR_BOT_REPO_CACHE_DIR", label: "Directory", defaultValue: defaultCacheDir},
+			},
+			validate: func(ctx context.Context, values map[string]string) error {
+				return os.MkdirAll(values["PR_BOT_REPO_CACHE_DIR"], 0o755)
+			},
+		},
+		{
+			name:    "Google Sheets",
+			usedFor: "the ACM/MCE release schedule with GA dates",
+			settings: []configInitSetting{
+				{envVar: "PR_BOT_GOOGLE_SHEET_ID", label: "Sheet ID"},
+				{envVar: "PR_BOT_GOOGLE_SERVICE_ACCOUNT_JSON", label: "Path to the service account JSON key file", readFile: true},
+			},
+			validate: func(ctx context.Context, values map[string]string) error {
+				client, err := ga.NewSheetsClient(values["PR_BOT_GOOGLE_SERVICE_ACCOUNT_JSON"], values["PR_BOT_GOOGLE_SHEET_ID"])
+				if err != nil {
+					return err
+				}
+				return client.Ping(ctx)
+			},
+		},
+		{
+			name:     "GitLab",
+			usedFor:  "MCE snapshot validation, -v mce and SaaS badges; skip it if you don't work with MCE",
+			optional: true,
+			settings: []configInitSetting{
+				{envVar: "PR_BOT_GITLAB_TOKEN", label: "GitLab token"},
+			},
+			validate: func(ctx context.Context, values map[string]string) error {
+				return gitlab.NewClient(ctx, values["PR_BOT_GITLAB_TOKEN"], nil).Ping(ctx)
+			},
+		},
+		{
+			name:     "JIRA",
+			usedFor:  "-jt and JIRA details in PR analyses (Atlassian Cloud API token)",
+			optional: true,
+			settings: []configInitSetting{
+				{envVar: "PR_BOT_JIRA_EMAIL", label: "JIRA email"},
+				{envVar: "PR_BOT_JIRA_TOKEN", label: "JIRA API token"},
+			},
+			validate: func(ctx context.Context, values map[string]string) error {
+				return jira.NewClient(ctx, values["PR_BOT_JIRA_EMAIL"], values["PR_BOT_JIRA_TOKEN"]).Ping(ctx)
+			},
+		},
+		{
+			name:     "Slack",
+			usedFor:  "-server mode only",
+			optional: true,
+			settings: []configInitSetting{
+				{envVar: "PR_BOT_SLACK_BOT_TOKEN", label: "Bot User OAuth token (xoxb-...)"},
+			},
+			validate: func(ctx context.Context, values map[string]string) error {
+				return slack.NewBotClient(values["PR_BOT_SLACK_BOT_TOKEN"]).TestAuth(ctx)
+			},
+		},
+	}
+}
+
+// configInitPrompter reads answers to -config-init questions line by line.
+type configInitPrompter struct {
+	scanner *bufio.Scanner
+	out     io.Writer
+}
+
+// ask prints label and returns the trimmed answer, or defaultValue for an empty one.
+func (p *configInitPrompter) ask(label, defaultValue string) (string, error) {
+	if defaultValue != "" {
+		fmt.Fprintf(p.out, "%s [%s]: ", label, defaultValue)
+	} else {
+		fmt.Fprintf(p.out, "%s: ", label)
+	}
+	if !p.scanner.Scan() {
+		if err := p.scanner.Err(); err != nil {
+			return "", fmt.Errorf("failed to read input: %w", err)
+		}
+		return "", errInputEnded
+	}
+	if answer := strings.TrimSpace(p.scanner.Text()); answer != "" {
+		return answer, nil
+	}
+	return defaultValue, nil
+}
+
+// confirm asks a yes/no question that defaults to no.
+func (p *configInitPrompter) confirm(question string) (bool, error) {
+	answer, err := p.ask(question+" [y/N]", "")
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+// handleConfigInit prompts on in for the tokens pr-bot needs, checks each with a
+// lightweight API call and writes them to the .env file at path with mode 0600.
+// An existing file is only replaced after confirmation.
+func handleConfigInit(in io.Reader, out io.Writer, path string) error {
+	p := &configInitPrompter{scanner: bufio.NewScanner(in), out: out}
+
+	if _, err := os.Stat(path); err == nil {
+		overwrite, err := p.confirm(fmt.Sprintf("%s already exists. Overwrite it?", path))
+		if err != nil {
+			return err
+		}
+		if !overwrite {
+			fmt.Fprintf(out, "Left %s unchanged\n", path)
+			return nil
+		}
+	}
+
+	fmt.Fprintf(out, "=== pr-bot setup ===\n")
+	fmt.Fprintf(out, "Each token is checked before it is saved. Press Enter on the first question of an optional section to skip it.\n")
+
+	var env bytes.Buffer
+	fmt.Fprintf(&env, "# Written by pr-bot -config-init on %s\n", time.Now().Format("2006-01-02"))
+
+	for _, section := range configInitSections() {
+		values, err := promptConfigInitSection(p, section)
+		if err != nil {
+			return err
+		}
+		if values == nil {
+			continue
+		}
+
+		fmt.Fprintf(&env, "\n# %s\n", section.name)
+		for _, setting := range section.settings {
+			line, err := godotenv.Marshal(map[string]string{setting.envVar: values[setting.envVar]})
+			if err != nil {
+				return fmt.Errorf("failed to encode %s: %w", setting.envVar, err)
+			}
+			env.WriteString(line + "\n")
+		}
+	}
+
+	if err := os.WriteFile(path, env.Bytes(), 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	// WriteFile keeps the mode of an existing file
+	if err := os.Chmod(path, 0o600); err != nil {
+		return fmt.Errorf("failed to restrict permissions of %s: %w", path, err)
+	}
+
+	fmt.Fprintf(out, "\n✅ Wrote %s (readable only by you). Other settings are described in env.example.\n", path)
+	return nil
+}
+
+// promptConfigInitSection asks for the settings of section until they pass its
+// check or the user keeps them anyway. It returns nil when an optional section is skipped.
+func promptConfigInitSection(p *configInitPrompter, section configInitSection) (map[string]string, error) {
+	status := "required"
+	if section.optional {
+		status = "optional"
+	}
+	fmt.Fprintf(p.out, "\n%s (%s) - %s\n", section.name, status, section.usedFor)
+
+	for {
+		values, err := askConfigInitSettings(p, section)
+		if err != nil {
+			return nil, err
+		}
+		if values == nil {
+			if section.optional {
+				fmt.Fprintf(p.out, "Skipped %s\n", section.name)
+				return nil, nil
+			}
+			fmt.Fprintf(p.out, "⚠️  %s is required\n", section.name)
+			continue
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), configInitTimeout)
+		err = section.validate(ctx, values)
+		cancel()
+		if err == nil {
+			fmt.Fprintf(p.out, "✅ %s OK\n", section.name)
+			return values, nil
+		}
+
+		fmt.Fprintf(p.out, "❌ %s check failed: %v\n", section.name, err)
+		keep, err := p.confirm("Save it anyway?")
+		if err != nil {
+			return nil, err
+		}
+		if keep {
+			return values, nil
+		}
+	}
+}
+
+// askConfigInitSettings reads one answer per setting of section. It returns nil
+// when the first answer is empty.
+func askConfigInitSettings(p *configInitPrompter, section configInitSection) (map[string]string, error) {
+	values := make(map[string]string, len(section.settings))
+	for i, setting := range section.settings {
+		for {
+			answer, err := p.ask(setting.label, setting.defaultValue)
+			if err != nil {
+				return nil, err
+			}
+			if answer == "" {
+				if i == 0 {
+					return nil, nil
+				}
+				fmt.Fprintf(p.out, "⚠️  %s is required\n", setting.label)
+				continue
+			}
+
+			if setting.readFile {
+				content, err := readCompactJSON(answer)
+				if err != nil {
+					fmt.Fprintf(p.out, "❌ %v\n", err)
+					continue
+				}
+				answer = content
+			}
+			values[setting.envVar] = answer
+			break
+		}
+	}
+	return values, nil
+}
+
+// readCompactJSON reads the JSON file at path and returns it on a single line.
+func readCompactJSON(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	var compact bytes.Buffer
+	if err := json.Compact(&compact, content); err != nil {
+		return "", fmt.Errorf("%s is not valid JSON: %w", path, err)
+	}
+	return compact.String(), nil
+}
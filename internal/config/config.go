@@ -2,15 +2,41 @@
 package config
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
 	"strings"
 
 	"github.com/joho/godotenv"
+	"github.com/shay23bra/pr-bot/internal/ga"
+	"github.com/shay23bra/pr-bot/internal/github"
+	"github.com/shay23bra/pr-bot/internal/logger"
 	"github.com/shay23bra/pr-bot/internal/models"
+	"github.com/shay23bra/pr-bot/internal/profile"
+	"github.com/shay23bra/pr-bot/internal/proxy"
 	"github.com/spf13/viper"
 )
 
+// selectedProfile is the profile whose values Load applies, set by the -profile flag.
+var selectedProfile string
+
+// ownerOverride and repositoryOverride replace the configured owner and repository,
+// set by the -owner and -repo flags.
+var ownerOverride, repositoryOverride string
+
+// SetProfile selects a profile from ~/.pr-bot/profiles.yaml for later Load calls.
+func SetProfile(name string) {
+	selectedProfile = name
+}
+
+// SetRepositoryOverride makes later Load calls use owner and repo instead of the
+// configured ones, whatever their source. Empty values keep the configured ones.
+func SetRepositoryOverride(owner, repo string) {
+	ownerOverride = owner
+	repositoryOverride = repo
+}
+
 // Load loads configuration from environment variables and config files.
 func Load() (*models.Config, error) {
 	// Load .env file if it exists
@@ -32,6 +58,13 @@ func Load() (*models.Config, error) {
 	// Set default values
 	setDefaults()
 
+	// Profile values replace the defaults, so config files and environment variables still win
+	if selectedProfile != "" {
+		if err := applyProfile(selectedProfile); err != nil {
+			return nil, err
+		}
+	}
+
 	// Read config file if it exists
 	if err := viper.ReadInConfig(); err != nil {
 		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
@@ -68,20 +101,143 @@ func Load() (*models.Config, error) {
 		googleServiceAccountJSON = os.Getenv("PR_BOT_GOOGLE_SERVICE_ACCOUNT_JSON")
 	}
 
+	// JIRA projects are given as a comma-separated list of keys, e.g. MGMT,ACM,OCPBUGS
+	var jiraProjects []string
+	for _, project := range strings.Split(viper.GetString("jira_projects"), ",") {
+		if project = strings.TrimSpace(project); project != "" {
+			jiraProjects = append(jiraProjects, strings.ToUpper(project))
+		}
+	}
+
+	// Snapshot projects are given as a JSON map keyed by product, e.g. {"MCE":"acm-cicd/mce-bb2"}
+	var snapshotProjects map[string]string
+	if raw := viper.GetString("snapshot_projects"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &snapshotProjects); err != nil {
+			return nil, fmt.Errorf("failed to parse PR_BOT_SNAPSHOT_PROJECTS: %w", err)
+		}
+	}
+
+	// Command ACLs are given as a JSON map of command name to Slack user group IDs, e.g. {"version":["S0123ABCD"]}
+	var commandACL map[string][]string
+	if raw := viper.GetString("command_acl"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &commandACL); err != nil {
+			return nil, fmt.Errorf("failed to parse PR_BOT_COMMAND_ACL: %w", err)
+		}
+	}
+
+	// Workspace tokens are given as a JSON map of Slack team ID to bot token, e.g. {"T0123ABCD":"xoxb-..."}
+	var workspaceTokens map[string]string
+	if raw := viper.GetString("slack.workspace_tokens"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &workspaceTokens); err != nil {
+			return nil, fmt.Errorf("failed to parse PR_BOT_SLACK_WORKSPACE_TOKENS: %w", err)
+		}
+	}
+
+	// Author Slack users are given as a JSON map of GitHub login to Slack user ID, e.g. {"octocat":"U0123ABCD"}
+	var authorSlackUsers map[string]string
+	if raw := viper.GetString("slack.author_users"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &authorSlackUsers); err != nil {
+			return nil, fmt.Errorf("failed to parse PR_BOT_SLACK_AUTHOR_USERS: %w", err)
+		}
+	}
+
+	// Pattern descriptions are given as a JSON map of branch prefix to display name, e.g. {"release-partner-":"Partner"}
+	var patternDescriptions map[string]string
+	if raw := viper.GetString("pattern_descriptions"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &patternDescriptions); err != nil {
+			return nil, fmt.Errorf("failed to parse PR_BOT_PATTERN_DESCRIPTIONS: %w", err)
+		}
+	}
+
+	// Branch patterns are given as a JSON array, e.g. [{"prefix":"mce-","pattern_key":"release-ocm-","version_extract_regex":"^(\\d+\\.\\d+)"}]
+	var branchPatterns []models.BranchPattern
+	if raw := viper.GetString("branch_patterns"); raw != "" {
+		if err := json.Unmarshal([]byte(raw), &branchPatterns); err != nil {
+			return nil, fmt.Errorf("failed to parse PR_BOT_BRANCH_PATTERNS: %w", err)
+		}
+	}
+
+	// Version mappings are given as a JSON array, e.g. [{"acm_minor":17,"mce_minor":12}],
+	// or as a version_mappings list in the config file
+	var versionMappings []models.VersionMapping
+	if raw, ok := viper.Get("version_mappings").(string); ok {
+		if raw != "" {
+			if err := json.Unmarshal([]byte(raw), &versionMappings); err != nil {
+				return nil, fmt.Errorf("failed to parse PR_BOT_VERSION_MAPPINGS: %w", err)
+			}
+		}
+	} else if err := viper.UnmarshalKey("version_mappings", &versionMappings); err != nil {
+		return nil, fmt.Errorf("failed to parse version_mappings: %w", err)
+	}
+
 	config := &models.Config{
 		GitHubToken:              viper.GetString("github.token"),
 		Repository:               viper.GetString("github.repository"),
 		Owner:                    viper.GetString("github.owner"),
 		BranchPrefix:             viper.GetString("github.branch_prefix"),
 		DefaultBranch:            viper.GetString("github.default_branch"),
+		GitHubMaxRetries:         viper.GetInt("github.max_retries"),
+		GitHubBaseURL:            viper.GetString("github.base_url"),
+		GitHubWebhookSecret:      viper.GetString("github.webhook_secret"),
 		SlackBotToken:            viper.GetString("slack.bot_token"),
 		SlackSigningSecret:       viper.GetString("slack.signing_secret"),
 		GitLabToken:              gitlabToken,
+		GitLabBaseURL:            viper.GetString("gitlab_base_url"),
+		GitLabProjectID:          viper.GetString("gitlab_project_id"),
 		JiraToken:                jiraToken,
 		JiraEmail:                jiraEmail,
+		JiraLinkSummaries:        viper.GetBool("jira_link_summaries"),
+		JiraFollowEpics:          viper.GetBool("jira_follow_epics"),
+		JiraFollowSubtasks:       viper.GetBool("jira_follow_subtasks"),
+		JiraProjects:             jiraProjects,
 		GoogleSheetID:            googleSheetID,
 		GoogleServiceAccountJSON: googleServiceAccountJSON,
 		RepoCacheDir:             viper.GetString("repo_cache_dir"),
+		GitLabMaxIdleConns:       viper.GetInt("gitlab_max_idle_conns"),
+		GitLabIdleConnTimeout:    viper.GetDuration("gitlab_idle_conn_timeout"),
+		ResultStorePath:          viper.GetString("result_store"),
+		IncludePreRelease:        viper.GetBool("include_prerelease"),
+		UrgentChannel:            viper.GetString("urgent_channel"),
+		SnapshotProjects:         snapshotProjects,
+		APIToken:                 viper.GetString("api_token"),
+		SheetLayout:              viper.GetString("sheet_layout"),
+		CommandACL:               commandACL,
+		ProxyURL:                 viper.GetString("proxy_url"),
+		ProxySkipTLSVerify:       viper.GetBool("proxy_skip_tls_verify"),
+		PatternDescriptions:      patternDescriptions,
+		SHASkewThreshold:         viper.GetInt("sha_skew_threshold"),
+		ServerReadTimeout:        viper.GetDuration("server_read_timeout"),
+		ServerWriteTimeout:       viper.GetDuration("server_write_timeout"),
+		ServerIdleTimeout:        viper.GetDuration("server_idle_timeout"),
+		BranchCacheTTL:           viper.GetDuration("branch_cache_ttl"),
+		MetricsEnabled:           viper.GetBool("metrics_enabled"),
+		WebhookChannel:           viper.GetString("webhook_channel"),
+		LogFormat:                viper.GetString("log_format"),
+		BranchPatterns:           branchPatterns,
+		ResultCacheTTL:           viper.GetDuration("result_cache_ttl"),
+		TLSCertFile:              viper.GetString("tls_cert_file"),
+		TLSKeyFile:               viper.GetString("tls_key_file"),
+		VersionMappings:          versionMappings,
+		JiraConcurrentIssueLimit: viper.GetInt("jira_concurrent_issue_limit"),
+		Concurrency: models.ConcurrencyConfig{
+			BranchCheckWorkers:   viper.GetInt("branch_workers"),
+			PRAnalysisWorkers:    viper.GetInt("pr_workers"),
+			MCEValidationWorkers: viper.GetInt("mce_workers"),
+		},
+		GARefreshInterval: viper.GetDuration("ga_refresh_interval"),
+		WorkspaceTokens:   workspaceTokens,
+		AuthorSlackUsers:  authorSlackUsers,
+		ShutdownTimeout:   viper.GetDuration("shutdown_timeout"),
+
+		SubscriptionPollInterval: viper.GetDuration("subscription_poll_interval"),
+		GitHubMaxPages:           viper.GetInt("max_pages"),
+	}
+
+	if ownerOverride != "" {
+		config.Owner = ownerOverride
+	}
+	if repositoryOverride != "" {
+		config.Repository = repositoryOverride
 	}
 
 	// Validate required fields
@@ -89,6 +245,10 @@ func Load() (*models.Config, error) {
 		return nil, err
 	}
 
+	if err := logger.SetFormat(config.LogFormat); err != nil {
+		return nil, fmt.Errorf("invalid PR_BOT_LOG_FORMAT: %w", err)
+	}
+
 	return config, nil
 }
 
@@ -110,6 +270,26 @@ func PrintConfig(config *models.Config) {
 	}
 }
 
+// applyProfile uses the owner, repository and branch prefix of profile name as defaults.
+func applyProfile(name string) error {
+	path, err := profile.DefaultPath()
+	if err != nil {
+		return err
+	}
+	p, err := profile.Find(path, name)
+	if err != nil {
+		return err
+	}
+
+	logger.Debug("Using profile %s: %s/%s", p.Name, p.Owner, p.Repo)
+	viper.SetDefault("github.owner", p.Owner)
+	viper.SetDefault("github.repository", p.Repo)
+	if p.BranchPrefix != "" {
+		viper.SetDefault("github.branch_prefix", p.BranchPrefix)
+	}
+	return nil
+}
+
 // setDefaults sets default configuration values.
 func setDefaults() {
 	viper.SetDefault("github.token", "")
@@ -117,13 +297,57 @@ func setDefaults() {
 	viper.SetDefault("github.owner", "openshift")
 	viper.SetDefault("github.branch_prefix", "release-ocm-")
 	viper.SetDefault("github.default_branch", "master")
+	viper.SetDefault("github.max_retries", 3)
+	viper.SetDefault("github.base_url", "")
+	viper.SetDefault("github.webhook_secret", "")
 	viper.SetDefault("slack.bot_token", "")
+	viper.SetDefault("slack.signing_secret", "")
+	viper.SetDefault("slack.workspace_tokens", "")
+	viper.SetDefault("slack.author_users", "")
 	viper.SetDefault("gitlab_token", "")
+	viper.SetDefault("gitlab_base_url", "")
+	viper.SetDefault("gitlab_project_id", "")
 	viper.SetDefault("jira_token", "")
 	viper.SetDefault("jira_email", "")
+	viper.SetDefault("jira_link_summaries", false)
+	viper.SetDefault("jira_follow_epics", false)
+	viper.SetDefault("jira_follow_subtasks", false)
+	viper.SetDefault("jira_concurrent_issue_limit", 5)
+	viper.SetDefault("branch_workers", models.DefaultBranchCheckWorkers)
+	viper.SetDefault("pr_workers", models.DefaultPRAnalysisWorkers)
+	viper.SetDefault("mce_workers", models.DefaultMCEValidationWorkers)
+	viper.SetDefault("jira_projects", "")
 	viper.SetDefault("google_sheet_id", "")
 	viper.SetDefault("google_service_account_json", "")
 	viper.SetDefault("repo_cache_dir", "")
+	viper.SetDefault("gitlab_max_idle_conns", 10)
+	viper.SetDefault("gitlab_idle_conn_timeout", "90s")
+	viper.SetDefault("result_store", "")
+	viper.SetDefault("include_prerelease", false)
+	viper.SetDefault("urgent_channel", "")
+	viper.SetDefault("snapshot_projects", "")
+	viper.SetDefault("api_token", "")
+	viper.SetDefault("sheet_layout", "auto")
+	viper.SetDefault("command_acl", "")
+	viper.SetDefault("proxy_url", "")
+	viper.SetDefault("proxy_skip_tls_verify", false)
+	viper.SetDefault("pattern_descriptions", "")
+	viper.SetDefault("sha_skew_threshold", 0)
+	viper.SetDefault("server_read_timeout", "15s")
+	viper.SetDefault("server_write_timeout", "30s")
+	viper.SetDefault("server_idle_timeout", "60s")
+	viper.SetDefault("branch_cache_ttl", "15m")
+	viper.SetDefault("metrics_enabled", false)
+	viper.SetDefault("webhook_channel", "")
+	viper.SetDefault("log_format", "text")
+	viper.SetDefault("branch_patterns", "")
+	viper.SetDefault("result_cache_ttl", "1h")
+	viper.SetDefault("ga_refresh_interval", "30m")
+	viper.SetDefault("shutdown_timeout", "30s")
+	viper.SetDefault("subscription_poll_interval", "15m")
+	viper.SetDefault("max_pages", github.DefaultMaxPages)
+	viper.SetDefault("tls_cert_file", "")
+	viper.SetDefault("tls_key_file", "")
 }
 
 // validateConfig validates the configuration.
@@ -140,6 +364,77 @@ func validateConfig(config *models.Config) error {
 		return fmt.Errorf("branch prefix is required")
 	}
 
+	if _, err := ga.ParseSheetLayout(config.SheetLayout); err != nil {
+		return fmt.Errorf("invalid PR_BOT_SHEET_LAYOUT: %w", err)
+	}
+
+	if err := proxy.FromConfig(config).Validate(); err != nil {
+		return fmt.Errorf("invalid PR_BOT_PROXY_URL: %w", err)
+	}
+
+	if config.GitHubBaseURL != "" && github.EnterpriseHost(config.GitHubBaseURL) == "" {
+		return fmt.Errorf("invalid PR_BOT_GITHUB_BASE_URL %q: must be an absolute URL such as https://github.mycompany.com/api/v3", config.GitHubBaseURL)
+	}
+
+	if config.GitLabBaseURL != "" {
+		if u, err := url.Parse(config.GitLabBaseURL); err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("invalid PR_BOT_GITLAB_BASE_URL %q: must be an absolute URL such as https://gitlab.example.com", config.GitLabBaseURL)
+		}
+	}
+
+	if _, err := github.NewBranchMatcher(config.BranchPatterns); err != nil {
+		return fmt.Errorf("invalid PR_BOT_BRANCH_PATTERNS: %w", err)
+	}
+
+	seenACMMinors := make(map[int]bool)
+	for _, m := range config.VersionMappings {
+		if m.ACMMinor < 0 || m.MCEMinor < 0 {
+			return fmt.Errorf("invalid PR_BOT_VERSION_MAPPINGS: negative minor version in ACM 2.%d -> MCE 2.%d", m.ACMMinor, m.MCEMinor)
+		}
+		if seenACMMinors[m.ACMMinor] {
+			return fmt.Errorf("invalid PR_BOT_VERSION_MAPPINGS: ACM 2.%d is mapped more than once", m.ACMMinor)
+		}
+		seenACMMinors[m.ACMMinor] = true
+	}
+
+	for name, workers := range map[string]int{
+		"PR_BOT_BRANCH_WORKERS": config.Concurrency.BranchCheckWorkers,
+		"PR_BOT_PR_WORKERS":     config.Concurrency.PRAnalysisWorkers,
+		"PR_BOT_MCE_WORKERS":    config.Concurrency.MCEValidationWorkers,
+	} {
+		if workers < 1 {
+			return fmt.Errorf("invalid %s %d: must be at least 1", name, workers)
+		}
+	}
+
+	if config.GARefreshInterval < 0 {
+		return fmt.Errorf("invalid PR_BOT_GA_REFRESH_INTERVAL %s: must not be negative", config.GARefreshInterval)
+	}
+	if config.ShutdownTimeout < 0 {
+		return fmt.Errorf("invalid PR_BOT_SHUTDOWN_TIMEOUT %s: must not be negative", config.ShutdownTimeout)
+	}
+	if config.SubscriptionPollInterval <= 0 {
+		return fmt.Errorf("invalid PR_BOT_SUBSCRIPTION_POLL_INTERVAL %s: must be positive", config.SubscriptionPollInterval)
+	}
+	if config.SHASkewThreshold < 0 {
+		return fmt.Errorf("invalid PR_BOT_SHA_SKEW_THRESHOLD %d: must not be negative", config.SHASkewThreshold)
+	}
+	if config.GitHubMaxPages <= 0 {
+		return fmt.Errorf("invalid PR_BOT_MAX_PAGES %d: must be positive", config.GitHubMaxPages)
+	}
+
+	for teamID, token := range config.WorkspaceTokens {
+		if teamID == "" || token == "" {
+			return fmt.Errorf("invalid PR_BOT_SLACK_WORKSPACE_TOKENS: team IDs and bot tokens must not be empty")
+		}
+	}
+
+	for login, userID := range config.AuthorSlackUsers {
+		if login == "" || userID == "" {
+			return fmt.Errorf("invalid PR_BOT_SLACK_AUTHOR_USERS: GitHub logins and Slack user IDs must not be empty")
+		}
+	}
+
 	// GitHub token is optional for public repositories but recommended
 	if config.GitHubToken == "" {
 		fmt.Fprintf(os.Stderr, "Warning: No GitHub token provided. API rate limits will be lower.\n")
@@ -0,0 +1,171 @@
+package ga
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/models"
+)
+
+// SheetLayout describes how releases are arranged in the "In Progress" sheet.
+type SheetLayout string
+
+// Supported sheet layouts.
+const (
+	LayoutAuto     SheetLayout = "auto"     // Detect the layout from the sheet contents
+	LayoutTabular  SheetLayout = "tabular"  // One row per release, versions and dates in any column
+	LayoutVertical SheetLayout = "vertical" // Dates as column headers, versions as row headers
+)
+
+// layoutDetectRows is how many rows detectLayout inspects for version row headers.
+const layoutDetectRows = 10
+
+// ParseSheetLayout converts a config value to a SheetLayout.
+// An empty value selects LayoutAuto.
+func ParseSheetLayout(value string) (SheetLayout, error) {
+	switch layout := SheetLayout(strings.ToLower(strings.TrimSpace(value))); layout {
+	case "":
+		return LayoutAuto, nil
+	case LayoutAuto, LayoutTabular, LayoutVertical:
+		return layout, nil
+	}
+	return "", fmt.Errorf("unknown sheet layout %q (expected auto, tabular or vertical)", value)
+}
+
+// detectLayout inspects the first rows and columns of a sheet to decide its layout.
+// A sheet is vertical when its header row holds at least two dates and the first
+// column of the following rows holds ACM/MCE versions; anything else is tabular.
+func (c *SheetsClient) detectLayout(values [][]interface{}) SheetLayout {
+	if len(values) < 2 {
+		return LayoutTabular
+	}
+
+	header := toStringRow(values[0])
+	dateHeaders := 0
+	for _, cell := range header[min(1, len(header)):] {
+		if c.parseDateFromText(cell) != nil {
+			dateHeaders++
+		}
+	}
+
+	versionRows := 0
+	for _, row := range values[1:min(len(values), layoutDetectRows+1)] {
+		stringRow := toStringRow(row)
+		if len(stringRow) == 0 {
+			continue
+		}
+		if c.extractVersionFromText(stringRow[0], ProductACM) != "" || c.extractVersionFromText(stringRow[0], ProductMCE) != "" {
+			versionRows++
+		}
+	}
+
+	if dateHeaders >= 2 && versionRows > 0 {
+		return LayoutVertical
+	}
+	return LayoutTabular
+}
+
+// readTabularSheet parses a sheet with one row per release. The version may be in
+// any column and the latest date in the row is taken as the GA date.
+func (c *SheetsClient) readTabularSheet(values [][]interface{}) []ReleaseInfo {
+	var releases []ReleaseInfo
+
+	for i, row := range values {
+		if len(row) < 2 {
+			continue
+		}
+
+		stringRow := toStringRow(row)
+
+		// Extract version information from this row
+		acmVersion := c.extractVersion(stringRow, ProductACM)
+		mceVersion := c.extractVersion(stringRow, ProductMCE)
+
+		// If we found version information, look for dates in the same row
+		if acmVersion != "" || mceVersion != "" {
+			logger.Debug("Found version row %d: ACM=%s, MCE=%s", i+1, acmVersion, mceVersion)
+
+			// Find all dates in this row and take the latest one
+			latestDate := c.findLatestDateInRow(stringRow)
+
+			if latestDate != nil {
+				logger.Debug("Found GA date in row %d: %s", i+1, models.FormatDateWithNil(latestDate))
+			} else {
+				logger.Debug("No dates found in row %d", i+1)
+			}
+
+			release := ReleaseInfo{
+				ACMVersion: acmVersion,
+				MCEVersion: mceVersion,
+				GADate:     latestDate,
+				IsGA:       latestDate != nil && latestDate.Before(time.Now()),
+			}
+			releases = append(releases, release)
+			logger.Debug("Added release: ACM %s, MCE %s, GA: %s", acmVersion, mceVersion, models.FormatDateWithNil(latestDate))
+		}
+	}
+
+	return releases
+}
+
+// readVerticalSheet parses a sheet with dates as column headers and versions as
+// row headers. A non-empty cell marks the release as scheduled on that column's
+// date; the latest marked date is taken as the GA date.
+func (c *SheetsClient) readVerticalSheet(values [][]interface{}) []ReleaseInfo {
+	if len(values) == 0 {
+		return nil
+	}
+
+	header := toStringRow(values[0])
+	columnDates := make([]*time.Time, len(header))
+	for j := 1; j < len(header); j++ {
+		columnDates[j] = c.parseDateFromText(header[j])
+	}
+
+	var releases []ReleaseInfo
+	for i, row := range values[1:] {
+		stringRow := toStringRow(row)
+		if len(stringRow) == 0 {
+			continue
+		}
+
+		acmVersion := c.extractVersionFromText(stringRow[0], ProductACM)
+		mceVersion := c.extractVersionFromText(stringRow[0], ProductMCE)
+		if acmVersion == "" && mceVersion == "" {
+			continue
+		}
+
+		var latestDate *time.Time
+		for j := 1; j < len(stringRow) && j < len(columnDates); j++ {
+			if columnDates[j] == nil || strings.TrimSpace(stringRow[j]) == "" {
+				continue
+			}
+			if latestDate == nil || columnDates[j].After(*latestDate) {
+				latestDate = columnDates[j]
+			}
+		}
+
+		releases = append(releases, ReleaseInfo{
+			ACMVersion: acmVersion,
+			MCEVersion: mceVersion,
+			GADate:     latestDate,
+			IsGA:       latestDate != nil && latestDate.Before(time.Now()),
+		})
+		logger.Debug("Added release from row %d: ACM %s, MCE %s, GA: %s", i+2, acmVersion, mceVersion, models.FormatDateWithNil(latestDate))
+	}
+
+	return releases
+}
+
+// toStringRow converts a row of sheet cells to strings.
+func toStringRow(row []interface{}) []string {
+	stringRow := make([]string, len(row))
+	for j, cell := range row {
+		if cell != nil {
+			stringRow[j] = fmt.Sprintf("%v", cell)
+		}
+	}
+	return stringRow
+}
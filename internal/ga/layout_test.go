@@ -0,0 +1,163 @@
+package ga
+
+import "testing"
+
+// sheetValues builds sheet values as returned by the Sheets API.
+func sheetValues(rows ...[]string) [][]interface{} {
+	values := make([][]interface{}, len(rows))
+	for i, row := range rows {
+		values[i] = make([]interface{}, len(row))
+		for j, cell := range row {
+			values[i][j] = cell
+		}
+	}
+	return values
+}
+
+// wantRelease is an expected ReleaseInfo with the GA date as YYYY-MM-DD ("" for none).
+type wantRelease struct {
+	acm, mce, gaDate string
+}
+
+var (
+	tabularSheet = sheetValues(
+		[]string{"Release", "Code freeze", "GA"},
+		[]string{"ACM 2.13.3 / MCE 2.8.2", "2025-03-01", "2025-03-25"},
+		[]string{"MCE 2.9.0", "4/1/2025", "4/20/2025"},
+		[]string{"Notes", "Dates are tentative"},
+	)
+	verticalSheet = sheetValues(
+		[]string{"Release", "3/25/2025", "4/20/2025", "5/14/2025"},
+		[]string{"ACM 2.13.3", "x", "GA", ""},
+		[]string{"MCE 2.9.0", "", "", "GA"},
+		[]string{"Owner", "alice", "bob", "carol"},
+	)
+	unrecognizedSheet = sheetValues(
+		[]string{"Team", "Owner"},
+		[]string{"Installer", "alice"},
+	)
+)
+
+func TestDetectLayout(t *testing.T) {
+	tests := []struct {
+		name   string
+		values [][]interface{}
+		want   SheetLayout
+	}{
+		{name: "tabular", values: tabularSheet, want: LayoutTabular},
+		{name: "vertical", values: verticalSheet, want: LayoutVertical},
+		{name: "unrecognized falls back to tabular", values: unrecognizedSheet, want: LayoutTabular},
+		{name: "header only", values: sheetValues([]string{"Release", "3/25/2025", "4/20/2025"}), want: LayoutTabular},
+		{name: "empty", values: nil, want: LayoutTabular},
+	}
+
+	c := &SheetsClient{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := c.detectLayout(tt.values); got != tt.want {
+				t.Errorf("detectLayout() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseInProgressSheet(t *testing.T) {
+	tests := []struct {
+		name   string
+		layout SheetLayout
+		values [][]interface{}
+		want   []wantRelease
+	}{
+		{
+			name:   "tabular detected",
+			layout: LayoutAuto,
+			values: tabularSheet,
+			want: []wantRelease{
+				{acm: "2.13.3", mce: "2.8.2", gaDate: "2025-03-25"},
+				{mce: "2.9.0", gaDate: "2025-04-20"},
+			},
+		},
+		{
+			name:   "vertical detected",
+			layout: LayoutAuto,
+			values: verticalSheet,
+			want: []wantRelease{
+				{acm: "2.13.3", gaDate: "2025-04-20"},
+				{mce: "2.9.0", gaDate: "2025-05-14"},
+			},
+		},
+		{
+			name:   "vertical configured",
+			layout: LayoutVertical,
+			values: verticalSheet,
+			want: []wantRelease{
+				{acm: "2.13.3", gaDate: "2025-04-20"},
+				{mce: "2.9.0", gaDate: "2025-05-14"},
+			},
+		},
+		{
+			// Read as tabular, the version rows of a vertical sheet carry no dates
+			name:   "tabular configured for a vertical sheet",
+			layout: LayoutTabular,
+			values: verticalSheet,
+			want: []wantRelease{
+				{acm: "2.13.3"},
+				{mce: "2.9.0"},
+			},
+		},
+		{
+			name:   "unrecognized layout",
+			layout: LayoutAuto,
+			values: unrecognizedSheet,
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &SheetsClient{layout: tt.layout}
+			releases := c.parseInProgressSheet(tt.values)
+
+			if len(releases) != len(tt.want) {
+				t.Fatalf("got %d releases %+v, want %d", len(releases), releases, len(tt.want))
+			}
+			for i, want := range tt.want {
+				got := releases[i]
+				gaDate := ""
+				if got.GADate != nil {
+					gaDate = got.GADate.Format("2006-01-02")
+				}
+				if got.ACMVersion != want.acm || got.MCEVersion != want.mce || gaDate != want.gaDate {
+					t.Errorf("release %d = ACM %q, MCE %q, GA %q; want ACM %q, MCE %q, GA %q",
+						i, got.ACMVersion, got.MCEVersion, gaDate, want.acm, want.mce, want.gaDate)
+				}
+			}
+		})
+	}
+}
+
+func TestParseSheetLayout(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    SheetLayout
+		wantErr bool
+	}{
+		{value: "", want: LayoutAuto},
+		{value: "auto", want: LayoutAuto},
+		{value: " Tabular ", want: LayoutTabular},
+		{value: "VERTICAL", want: LayoutVertical},
+		{value: "grid", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			got, err := ParseSheetLayout(tt.value)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSheetLayout(%q) error = %v, wantErr %v", tt.value, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSheetLayout(%q) = %q, want %q", tt.value, got, tt.want)
+			}
+		})
+	}
+}
@@ -26,23 +26,54 @@ const (
 	StatusNextVersion = "Next Version"
 	StatusNotFound    = "Not Found"
 	StatusMergedNotGA = "Merged but not GA"
-
-	// Version mapping constants
-	MCEVersionOffset = 5 // MCE version is 5 versions behind ACM
 )
 
+// cacheTTL is how old the cache may be when read before it is refreshed in the background.
 const cacheTTL = 1 * time.Hour
 
+// The initial read of the sheets is attempted sheetsReadAttempts times,
+// sheetsRetryBackoff apart, before the parser reports the release schedule unavailable.
+const (
+	sheetsReadAttempts = 3
+	sheetsRetryBackoff = 5 * time.Second
+)
+
+// Data sources reported by Parser.DataSource.
+const (
+	DataSourceGoogleSheets = "google_sheets" // Release schedule read through the Google Sheets API
+	DataSourceUnavailable  = "unavailable"   // Loading the release schedule failed
+)
+
 const ReleaseScheduleURL = "https://docs.google.com/spreadsheets/d/1kf0rj7J3DkP-Ddd-Ixy1F79b9U69_Wniob-ewH_H4l4/edit?gid=2089594794#gid=2089594794"
 
 // IsAvailable returns true if Google Sheets data was successfully loaded.
 func (p *Parser) IsAvailable() bool {
 	select {
 	case <-p.parseChannel:
-		return p.parseError == nil && p.cache != nil
 	default:
 		return false
 	}
+
+	p.cacheMutex.RLock()
+	defer p.cacheMutex.RUnlock()
+	return p.cache != nil
+}
+
+// DataSource reports where the cached release schedule came from. It returns an
+// empty string while the initial parse is still running.
+func (p *Parser) DataSource() string {
+	select {
+	case <-p.parseChannel:
+	default:
+		return ""
+	}
+
+	p.cacheMutex.RLock()
+	defer p.cacheMutex.RUnlock()
+	if p.cache == nil {
+		return DataSourceUnavailable
+	}
+	return p.cache.dataSource
 }
 
 // SheetsUnavailableMessage returns a user-friendly message when Google Sheets data is unavailable.
@@ -58,10 +89,15 @@ type Parser struct {
 	cacheMutex   sync.RWMutex
 	parseOnce    sync.Once
 	parseChannel chan struct{}
-	parseError   error
+	parseError   error // Error of the initial parse; a later scheduled refresh may still fill the cache
+
+	refreshInterval time.Duration // How often the cache is refreshed; 0 only refreshes stale data on read
+	stopRefresh     chan struct{}
+	stopOnce        sync.Once
 
 	serviceAccountJSON string
 	sheetID            string
+	versionMappings    []models.VersionMapping // ACM-to-MCE minor versions
 }
 
 // parsedData holds the cached Google Sheets data
@@ -70,10 +106,33 @@ type parsedData struct {
 	completedReleases  []ReleaseInfo
 	allReleases        []ReleaseInfo
 	lastParsed         time.Time
+	dataSource         string
+}
+
+// ParserOptions configures optional Parser behavior.
+type ParserOptions struct {
+	// Layout selects how the "In Progress" sheet is parsed (default LayoutAuto).
+	Layout SheetLayout
+	// VersionMappings maps ACM to MCE minor versions (default models.DefaultVersionMappings).
+	VersionMappings []models.VersionMapping
+	// RefreshInterval re-reads the sheets on this schedule until Close is called.
+	// Zero only refreshes the cache when it is read more than an hour after it was parsed.
+	RefreshInterval time.Duration
+}
+
+// OptionsFromConfig builds parser options from the application configuration.
+// An invalid layout falls back to LayoutAuto.
+func OptionsFromConfig(cfg *models.Config) ParserOptions {
+	layout, err := ParseSheetLayout(cfg.SheetLayout)
+	if err != nil {
+		logger.Debug("Ignoring sheet layout: %v", err)
+		layout = LayoutAuto
+	}
+	return ParserOptions{Layout: layout, VersionMappings: cfg.VersionMappingTable()}
 }
 
 // NewParser creates a new GA parser that uses Google Sheets API with service account authentication.
-func NewParser(serviceAccountJSON, sheetID string) (*Parser, error) {
+func NewParser(serviceAccountJSON, sheetID string, opts ...ParserOptions) (*Parser, error) {
 	if serviceAccountJSON == "" || sheetID == "" {
 		return nil, fmt.Errorf("Google service account JSON and Sheet ID are required")
 	}
@@ -83,54 +142,97 @@ func NewParser(serviceAccountJSON, sheetID string) (*Parser, error) {
 	if err != nil {
 		return nil, fmt.Errorf("failed to create sheets client: %w", err)
 	}
+	var versionMappings []models.VersionMapping
+	var refreshInterval time.Duration
+	for _, opt := range opts {
+		if opt.RefreshInterval > 0 {
+			refreshInterval = opt.RefreshInterval
+		}
+		if opt.Layout != "" {
+			sheetsClient.layout = opt.Layout
+		}
+		if len(opt.VersionMappings) > 0 {
+			versionMappings = opt.VersionMappings
+		}
+	}
 
 	p := &Parser{
 		sheetsClient:       sheetsClient,
 		parseChannel:       make(chan struct{}),
 		serviceAccountJSON: serviceAccountJSON,
 		sheetID:            sheetID,
+		versionMappings:    versionMappings,
+		refreshInterval:    refreshInterval,
+		stopRefresh:        make(chan struct{}),
 	}
 
 	// Start background parsing
 	go p.backgroundParse()
+	if refreshInterval > 0 {
+		go p.refreshLoop()
+	}
 
 	return p, nil
 }
 
+// Close stops the scheduled refreshes started for ParserOptions.RefreshInterval.
+// The cached data remains usable.
+func (p *Parser) Close() {
+	p.stopOnce.Do(func() { close(p.stopRefresh) })
+}
+
+// refreshLoop refreshes the cache every refreshInterval until Close is called.
+func (p *Parser) refreshLoop() {
+	ticker := time.NewTicker(p.refreshInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-p.stopRefresh:
+			return
+		case <-ticker.C:
+			p.refreshCache(0)
+		}
+	}
+}
+
 // backgroundParse parses Google Sheets data in the background and caches the results.
 func (p *Parser) backgroundParse() {
 	p.parseOnce.Do(func() {
 		start := time.Now()
 		logger.Debug("Starting background Google Sheets parsing")
 
-		// Read data from Google Sheets
-		inProgressReleases, err := p.sheetsClient.ReadInProgressSheet()
-		if err != nil {
-			p.parseError = fmt.Errorf("failed to read 'In Progress' sheet: %w", err)
-			close(p.parseChannel)
-			return
+		// Read data from Google Sheets, retrying transient failures such as network
+		// errors or an exceeded quota
+		var data *parsedData
+		var err error
+		for attempt := 1; attempt <= sheetsReadAttempts; attempt++ {
+			data, err = p.readSheets()
+			if err == nil || attempt == sheetsReadAttempts {
+				break
+			}
+			logger.Debug("Reading Google Sheets failed (attempt %d/%d), retrying in %v: %v",
+				attempt, sheetsReadAttempts, sheetsRetryBackoff, err)
+			select {
+			case <-time.After(sheetsRetryBackoff):
+			case <-p.stopRefresh:
+				attempt = sheetsReadAttempts // Closed, stop retrying
+			}
 		}
-
-		completedReleases, err := p.sheetsClient.ReadCompletedSheet()
 		if err != nil {
-			p.parseError = fmt.Errorf("failed to read 'Completed Releases' sheet: %w", err)
+			p.parseError = err
 			close(p.parseChannel)
 			return
 		}
 
 		// Store in cache
 		p.cacheMutex.Lock()
-		p.cache = &parsedData{
-			inProgressReleases: inProgressReleases,
-			completedReleases:  completedReleases,
-			allReleases:        append(inProgressReleases, completedReleases...),
-			lastParsed:         time.Now(),
-		}
+		p.cache = data
 		p.cacheMutex.Unlock()
 
 		duration := time.Since(start)
 		logger.Debug("Background Google Sheets parsing completed in %v (found %d total releases)",
-			duration, len(p.cache.allReleases))
+			duration, len(data.allReleases))
 
 		// Signal that parsing is complete
 		close(p.parseChannel)
@@ -141,58 +243,77 @@ func (p *Parser) backgroundParse() {
 func (p *Parser) waitForData() (*parsedData, error) {
 	<-p.parseChannel
 
-	if p.parseError != nil {
-		return nil, p.parseError
-	}
-
 	p.cacheMutex.RLock()
 	cache := p.cache
 	p.cacheMutex.RUnlock()
 
 	if cache == nil {
+		if p.parseError != nil {
+			return nil, p.parseError
+		}
 		return nil, fmt.Errorf("parsed data not available")
 	}
 
 	if time.Since(cache.lastParsed) > cacheTTL {
-		go p.refreshCache()
+		go p.refreshCache(cacheTTL)
 	}
 
 	return cache, nil
 }
 
-// refreshCache re-parses Google Sheets data and updates the cache.
-func (p *Parser) refreshCache() {
-	p.cacheMutex.Lock()
-	if p.cache != nil && time.Since(p.cache.lastParsed) <= cacheTTL {
-		p.cacheMutex.Unlock()
+// refreshCache re-parses Google Sheets data and replaces the cache, unless the cache
+// was parsed less than maxAge ago. A failed refresh keeps serving the previous
+// (stale) cache with a warning rather than failing reads.
+func (p *Parser) refreshCache(maxAge time.Duration) {
+	p.cacheMutex.RLock()
+	cache := p.cache
+	p.cacheMutex.RUnlock()
+	if cache != nil {
+		if maxAge > 0 && time.Since(cache.lastParsed) <= maxAge {
+			return
+		}
+		logger.Debug("Refreshing Google Sheets cache (parsed %v ago)", time.Since(cache.lastParsed).Round(time.Second))
+	} else {
+		logger.Debug("Refreshing Google Sheets cache (initial parse failed)")
+	}
+
+	refreshed, err := p.readSheets()
+	if err != nil {
+		if cache != nil {
+			logger.Info("⚠️  Failed to refresh the release schedule, still using the data from %s: %v",
+				cache.lastParsed.Format("2006-01-02 15:04"), err)
+		} else {
+			logger.Debug("Failed to refresh Google Sheets cache: %v", err)
+		}
 		return
 	}
+
+	p.cacheMutex.Lock()
+	p.cache = refreshed
 	p.cacheMutex.Unlock()
 
-	logger.Debug("Refreshing Google Sheets cache (stale for %v)", time.Since(p.cache.lastParsed))
+	logger.Debug("Google Sheets cache refreshed (%d releases)", len(refreshed.allReleases))
+}
 
+// readSheets reads both release sheets from Google Sheets.
+func (p *Parser) readSheets() (*parsedData, error) {
 	inProgressReleases, err := p.sheetsClient.ReadInProgressSheet()
 	if err != nil {
-		logger.Debug("Failed to refresh 'In Progress' sheet: %v", err)
-		return
+		return nil, fmt.Errorf("failed to read 'In Progress' sheet: %w", err)
 	}
 
 	completedReleases, err := p.sheetsClient.ReadCompletedSheet()
 	if err != nil {
-		logger.Debug("Failed to refresh 'Completed Releases' sheet: %v", err)
-		return
+		return nil, fmt.Errorf("failed to read 'Completed Releases' sheet: %w", err)
 	}
 
-	p.cacheMutex.Lock()
-	p.cache = &parsedData{
+	return &parsedData{
 		inProgressReleases: inProgressReleases,
 		completedReleases:  completedReleases,
 		allReleases:        append(inProgressReleases, completedReleases...),
 		lastParsed:         time.Now(),
-	}
-	p.cacheMutex.Unlock()
-
-	logger.Debug("Google Sheets cache refreshed (%d releases)", len(p.cache.allReleases))
+		dataSource:         DataSourceGoogleSheets,
+	}, nil
 }
 
 // ReleaseInfo represents release information from Google Sheets.
@@ -314,36 +435,59 @@ func (p *Parser) GetUpcomingGAVersions(version string, mergedAt *time.Time) ([]m
 	return result, nil
 }
 
+// ReleaseListOptions controls how GetAllMCEReleases returns releases.
+type ReleaseListOptions struct {
+	// Sorted orders releases by MCE version, newest first.
+	Sorted bool
+}
+
 // GetAllMCEReleases returns all releases from the cached data.
-func (p *Parser) GetAllMCEReleases() ([]ReleaseInfo, error) {
+// Releases are sorted by MCE version descending unless options disable it.
+func (p *Parser) GetAllMCEReleases(opts ...ReleaseListOptions) ([]ReleaseInfo, error) {
+	options := ReleaseListOptions{Sorted: true}
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
 	// Wait for cached data
 	data, err := p.waitForData()
 	if err != nil {
 		return nil, fmt.Errorf("failed to get cached data: %w", err)
 	}
 
-	return data.allReleases, nil
+	if !options.Sorted {
+		return data.allReleases, nil
+	}
+
+	// Copy before sorting so the shared cache keeps its sheet order
+	releases := make([]ReleaseInfo, len(data.allReleases))
+	copy(releases, data.allReleases)
+	sort.SliceStable(releases, func(i, j int) bool {
+		return p.compareVersions(releases[i].MCEVersion, releases[j].MCEVersion) > 0
+	})
+
+	return releases, nil
+}
+
+// GetAllMCEReleasesSorted returns all releases sorted by MCE version, newest first.
+func (p *Parser) GetAllMCEReleasesSorted() ([]ReleaseInfo, error) {
+	return p.GetAllMCEReleases(ReleaseListOptions{Sorted: true})
 }
 
 // mapReleaseToProductVersion maps a release version to product version
 func (p *Parser) mapReleaseToProductVersion(releaseVersion, product string) string {
 	if product == ProductMCE {
-		// MCE minor version is ACM minor version - MCEVersionOffset
-		// e.g., release-ocm-2.15 -> ACM 2.15.x -> MCE 2.10.x (15-5=10)
+		// The MCE minor version comes from the ACM-to-MCE version mappings,
+		// e.g. release-ocm-2.15 -> ACM 2.15.x -> MCE 2.10.x
 		parts := strings.Split(releaseVersion, ".")
 		if len(parts) >= 2 {
 			if minor, err := strconv.Atoi(parts[1]); err == nil {
-				mceMinor := minor - MCEVersionOffset
+				mceMinor := models.MCEMinorForACM(p.versionMappings, minor)
 				if mceMinor >= 0 {
 					return fmt.Sprintf("2.%d", mceMinor)
 				}
 			}
 		}
-		// Fallback to original logic if parsing fails
-		if version, err := strconv.ParseFloat(releaseVersion, 64); err == nil {
-			mceVersion := version - float64(MCEVersionOffset)/10.0
-			return fmt.Sprintf("%.1f", mceVersion)
-		}
 	}
 	return releaseVersion
 }
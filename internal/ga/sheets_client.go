@@ -19,6 +19,7 @@ import (
 type SheetsClient struct {
 	service *sheets.Service
 	sheetID string
+	layout  SheetLayout
 }
 
 // NewSheetsClient creates a new Google Sheets client using service account authentication
@@ -33,9 +34,19 @@ func NewSheetsClient(serviceAccountJSON, sheetID string) (*SheetsClient, error)
 	return &SheetsClient{
 		service: service,
 		sheetID: sheetID,
+		layout:  LayoutAuto,
 	}, nil
 }
 
+// Ping checks that the spreadsheet can be read with the service account by
+// fetching only its ID.
+func (c *SheetsClient) Ping(ctx context.Context) error {
+	if _, err := c.service.Spreadsheets.Get(c.sheetID).Fields("spreadsheetId").Context(ctx).Do(); err != nil {
+		return fmt.Errorf("failed to read spreadsheet %s: %w", c.sheetID, err)
+	}
+	return nil
+}
+
 // ReadInProgressSheet reads data from the "In Progress" sheet
 func (c *SheetsClient) ReadInProgressSheet() ([]ReleaseInfo, error) {
 	logger.Debug("Reading 'In Progress' sheet from Google Sheets")
@@ -47,53 +58,28 @@ func (c *SheetsClient) ReadInProgressSheet() ([]ReleaseInfo, error) {
 		return nil, fmt.Errorf("failed to read In Progress sheet: %w", err)
 	}
 
-	var releases []ReleaseInfo
-
 	logger.Debug("Processing %d rows from 'In Progress' sheet", len(resp.Values))
 
-	for i, row := range resp.Values {
-		if len(row) < 2 {
-			continue
-		}
-
-		// Convert interface{} slice to string slice
-		stringRow := make([]string, len(row))
-		for j, cell := range row {
-			if cell != nil {
-				stringRow[j] = fmt.Sprintf("%v", cell)
-			}
-		}
-
-		// Extract version information from this row
-		acmVersion := c.extractVersion(stringRow, ProductACM)
-		mceVersion := c.extractVersion(stringRow, ProductMCE)
-
-		// If we found version information, look for dates in the same row
-		if acmVersion != "" || mceVersion != "" {
-			logger.Debug("Found version row %d: ACM=%s, MCE=%s", i+1, acmVersion, mceVersion)
-
-			// Find all dates in this row and take the latest one
-			latestDate := c.findLatestDateInRow(stringRow)
-
-			if latestDate != nil {
-				logger.Debug("Found GA date in row %d: %s", i+1, models.FormatDateWithNil(latestDate))
-			} else {
-				logger.Debug("No dates found in row %d", i+1)
-			}
+	releases := c.parseInProgressSheet(resp.Values)
+	logger.Debug("Parsed %d releases from 'In Progress' sheet", len(releases))
+	return releases, nil
+}
 
-			release := ReleaseInfo{
-				ACMVersion: acmVersion,
-				MCEVersion: mceVersion,
-				GADate:     latestDate,
-				IsGA:       latestDate != nil && latestDate.Before(time.Now()),
-			}
-			releases = append(releases, release)
-			logger.Debug("Added release: ACM %s, MCE %s, GA: %s", acmVersion, mceVersion, models.FormatDateWithNil(latestDate))
-		}
+// parseInProgressSheet parses the "In Progress" sheet values with the configured
+// layout, detecting it for LayoutAuto.
+func (c *SheetsClient) parseInProgressSheet(values [][]interface{}) []ReleaseInfo {
+	layout := c.layout
+	if layout == "" || layout == LayoutAuto {
+		layout = c.detectLayout(values)
+		logger.Debug("Detected '%s' layout for 'In Progress' sheet", layout)
+	} else {
+		logger.Debug("Using configured '%s' layout for 'In Progress' sheet", layout)
 	}
 
-	logger.Debug("Parsed %d releases from 'In Progress' sheet", len(releases))
-	return releases, nil
+	if layout == LayoutVertical {
+		return c.readVerticalSheet(values)
+	}
+	return c.readTabularSheet(values)
 }
 
 // ReadCompletedSheet reads data from the "Completed Releases" sheet
@@ -0,0 +1,66 @@
+package ga
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// TimelineEntry is one upcoming GA of a product version.
+type TimelineEntry struct {
+	Product string // ProductACM or ProductMCE
+	Version string
+	GADate  time.Time
+	Branch  string // Release branch shipped in the version, e.g. release-ocm-2.15; empty when unknown
+}
+
+// GetReleaseTimeline returns the releases with a GA date after now, one entry per
+// product, sorted by GA date. product (ProductACM or ProductMCE, any case) limits
+// the entries to one product; empty includes both.
+func (p *Parser) GetReleaseTimeline(product string, now time.Time) ([]TimelineEntry, error) {
+	product = strings.ToUpper(product)
+	if product != "" && product != ProductACM && product != ProductMCE {
+		return nil, fmt.Errorf("unknown product %q (use %s or %s)", product, ProductACM, ProductMCE)
+	}
+
+	releases, err := p.GetAllMCEReleases(ReleaseListOptions{Sorted: false})
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []TimelineEntry
+	for _, release := range releases {
+		if release.GADate == nil || !release.GADate.After(now) {
+			continue
+		}
+		// Both products ship the ACM release's release-ocm branch
+		branch := ""
+		if major, minor, ok := majorMinor(release.ACMVersion); ok {
+			branch = fmt.Sprintf("release-ocm-%s.%s", major, minor)
+		}
+		if release.ACMVersion != "" && (product == "" || product == ProductACM) {
+			entries = append(entries, TimelineEntry{Product: ProductACM, Version: release.ACMVersion, GADate: *release.GADate, Branch: branch})
+		}
+		if release.MCEVersion != "" && (product == "" || product == ProductMCE) {
+			entries = append(entries, TimelineEntry{Product: ProductMCE, Version: release.MCEVersion, GADate: *release.GADate, Branch: branch})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if !entries[i].GADate.Equal(entries[j].GADate) {
+			return entries[i].GADate.Before(entries[j].GADate)
+		}
+		return entries[i].Product < entries[j].Product
+	})
+	return entries, nil
+}
+
+// majorMinor splits the major and minor parts off a version such as 2.15.1.
+func majorMinor(version string) (major, minor string, ok bool) {
+	parts := strings.Split(strings.TrimPrefix(version, "v"), ".")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
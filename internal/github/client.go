@@ -4,76 +4,258 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"net/url"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"strconv"
 
 	"github.com/google/go-github/v57/github"
+	"github.com/shay23bra/pr-bot/internal/logger"
 	"github.com/shay23bra/pr-bot/internal/models"
+	"github.com/shay23bra/pr-bot/internal/proxy"
 	"golang.org/x/oauth2"
 )
 
 // Constants for GitHub API operations.
 const (
 	DefaultPageSize     = 100
+	DefaultMaxPages     = 50 // Pages read from a paginated listing when ClientOptions.MaxPages is not set
 	ExpectedMatchGroups = 4
 	GitHubHost          = "github.com"
 )
 
+// coAuthorPattern matches "Co-authored-by: Name <email>" commit trailers.
+var coAuthorPattern = regexp.MustCompile(`(?im)^\s*Co-authored-by:\s*(.+?)\s*<([^>]*)>\s*$`)
+
+// ParseCoAuthors returns the co-author names from the Co-authored-by trailers of a commit message.
+func ParseCoAuthors(message string) []string {
+	var coAuthors []string
+	seen := make(map[string]bool)
+	for _, match := range coAuthorPattern.FindAllStringSubmatch(message, -1) {
+		name := match[1]
+		if name == "" {
+			name = match[2]
+		}
+		if !seen[name] {
+			seen[name] = true
+			coAuthors = append(coAuthors, name)
+		}
+	}
+	return coAuthors
+}
+
+// hotfixSkipPattern matches "[skip-N.N]" markers in PR titles.
+var hotfixSkipPattern = regexp.MustCompile(`(?i)\[skip-(\d+\.\d+)\]`)
+
+// ParseHotfixIndicators detects hotfix PRs from the title and labels.
+// A PR is a hotfix if its title contains "[hotfix]" or "[skip-N.N]", or it has a "hotfix" label.
+// The versions from "[skip-N.N]" markers are returned as the branches to skip.
+func ParseHotfixIndicators(title string, labels []string) (bool, []string) {
+	isHotfix := strings.Contains(strings.ToLower(title), "[hotfix]")
+	for _, label := range labels {
+		if strings.EqualFold(label, "hotfix") {
+			isHotfix = true
+		}
+	}
+
+	var skipBranches []string
+	for _, match := range hotfixSkipPattern.FindAllStringSubmatch(title, -1) {
+		skipBranches = append(skipBranches, match[1])
+	}
+	if len(skipBranches) > 0 {
+		isHotfix = true
+	}
+
+	return isHotfix, skipBranches
+}
+
+// applyLabelInfo fills the labels and hotfix fields of prInfo from the PR title and labels.
+func applyLabelInfo(prInfo *models.PRInfo, pr *github.PullRequest) {
+	var labels []string
+	for _, label := range pr.Labels {
+		labels = append(labels, label.GetName())
+	}
+	prInfo.Labels = labels
+	prInfo.IsHotfix, prInfo.SkipBranches = ParseHotfixIndicators(pr.GetTitle(), labels)
+}
+
 // Client wraps the GitHub API client.
 type Client struct {
-	client *github.Client
-	ctx    context.Context
+	client         *github.Client
+	ctx            context.Context
+	prInfoCache    *prInfoCache
+	branchPatterns []models.BranchPattern
+	maxPages       int
+}
+
+// prInfoCacheTTL is how long a PR lookup is reused. Open PRs change, and long-lived
+// clients such as the server's would otherwise report them as they first were.
+const prInfoCacheTTL = 10 * time.Minute
+
+// prInfoCache memoizes PR lookups for prInfoCacheTTL, so repeated lookups within
+// the same analysis run hit the API only once.
+type prInfoCache struct {
+	mu       sync.Mutex
+	byNumber map[string]prInfoCacheEntry // keyed by "owner/repo#number"
+	bySHA    map[string]prsCacheEntry    // keyed by "owner/repo@sha"
+}
+
+// prInfoCacheEntry is a cached GetBasicPRInfo result and when it was fetched.
+type prInfoCacheEntry struct {
+	prInfo    *models.PRInfo
+	fetchedAt time.Time
+}
+
+// prsCacheEntry is a cached ListPRsForCommit result and when it was fetched.
+type prsCacheEntry struct {
+	prs       []*github.PullRequest
+	fetchedAt time.Time
+}
+
+func newPRInfoCache() *prInfoCache {
+	return &prInfoCache{
+		byNumber: make(map[string]prInfoCacheEntry),
+		bySHA:    make(map[string]prsCacheEntry),
+	}
+}
+
+// ClientOptions configures the HTTP transport used by the GitHub client.
+// Zero retry settings use DefaultMaxRetries, DefaultRetryBaseDelay, DefaultRateLimitBuffer
+// and DefaultMaxRetryWait.
+type ClientOptions struct {
+	Proxy   proxy.Settings
+	BaseURL string // GitHub Enterprise API endpoint, e.g. https://github.mycompany.com/api/v3; empty uses api.github.com
+
+	MaxRetries      int           // Retries for rate-limited and 5xx responses; negative disables retries
+	RetryBaseDelay  time.Duration // First 5xx backoff delay, doubled on each retry
+	RateLimitBuffer time.Duration // Extra wait after X-RateLimit-Reset to absorb clock skew
+	MaxRetryWait    time.Duration // Longest wait before a retry or for a rate limit reset; longer waits fail fast
+
+	BranchPatterns []models.BranchPattern // Release branch patterns; empty uses models.DefaultBranchPatterns
+	MaxPages       int                    // Most pages read from a paginated listing; zero uses DefaultMaxPages
+}
+
+// OptionsFromConfig builds ClientOptions from the application configuration.
+func OptionsFromConfig(cfg *models.Config) ClientOptions {
+	return ClientOptions{
+		Proxy:          proxy.FromConfig(cfg),
+		BaseURL:        cfg.GitHubBaseURL,
+		MaxRetries:     cfg.GitHubMaxRetries,
+		BranchPatterns: cfg.BranchPatterns,
+		MaxPages:       cfg.GitHubMaxPages,
+	}
 }
 
 // NewClient creates a new GitHub client with authentication.
-func NewClient(ctx context.Context, token string) *Client {
+func NewClient(ctx context.Context, token string, opts ...ClientOptions) *Client {
+	var options ClientOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	var transport http.RoundTripper
+	if proxyTransport := options.Proxy.NewTransport(); proxyTransport != nil {
+		transport = proxyTransport
+	}
+	baseClient := &http.Client{Transport: newRetryTransport(transport, options)}
+
 	var client *github.Client
 
 	if token != "" {
 		ts := oauth2.StaticTokenSource(
 			&oauth2.Token{AccessToken: token},
 		)
-		tc := oauth2.NewClient(ctx, ts)
+		tc := oauth2.NewClient(context.WithValue(ctx, oauth2.HTTPClient, baseClient), ts)
 		client = github.NewClient(tc)
 	} else {
-		client = github.NewClient(nil)
+		client = github.NewClient(baseClient)
+	}
+
+	if options.BaseURL != "" {
+		enterpriseClient, err := client.WithEnterpriseURLs(options.BaseURL, options.BaseURL)
+		if err != nil {
+			logger.Debug("Ignoring GitHub base URL %q: %v", options.BaseURL, err)
+		} else {
+			client = enterpriseClient
+		}
+	}
+
+	branchPatterns := options.BranchPatterns
+	if len(branchPatterns) == 0 {
+		branchPatterns = models.DefaultBranchPatterns
+	}
+	maxPages := options.MaxPages
+	if maxPages <= 0 {
+		maxPages = DefaultMaxPages
 	}
 
 	return &Client{
-		client: client,
-		ctx:    ctx,
+		client:         client,
+		ctx:            ctx,
+		prInfoCache:    newPRInfoCache(),
+		branchPatterns: branchPatterns,
+		maxPages:       maxPages,
 	}
 }
 
-// GetPRInfo fetches detailed information about a pull request.
+// nextPage returns the page to request after resp, or 0 when resp is the last page
+// or the next one is past the page cap, in which case the listing described by
+// format and args is incomplete and a warning is logged.
+func (c *Client) nextPage(resp *github.Response, format string, args ...interface{}) int {
+	if resp.NextPage == 0 {
+		return 0
+	}
+	if resp.NextPage > c.maxPages {
+		logger.Info("⚠️  Stopped listing %s after %d pages (PR_BOT_MAX_PAGES), results may be incomplete",
+			fmt.Sprintf(format, args...), c.maxPages)
+		return 0
+	}
+	return resp.NextPage
+}
+
+// GetPRInfo fetches detailed information about a merged pull request. It returns
+// an error for PRs that are not merged; use GetBasicPRInfo for those.
 func (c *Client) GetPRInfo(owner, repo string, prNumber int) (*models.PRInfo, error) {
-	pr, _, err := c.client.PullRequests.Get(c.ctx, owner, repo, prNumber)
+	basic, err := c.GetBasicPRInfo(owner, repo, prNumber)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get PR %d: %w", prNumber, err)
+		return nil, err
 	}
 
-	if pr.MergedAt == nil {
+	if basic.MergedAt == nil {
 		return nil, fmt.Errorf("PR %d is not merged", prNumber)
 	}
 
-	prInfo := &models.PRInfo{
-		Number:     pr.GetNumber(),
-		Title:      pr.GetTitle(),
-		Hash:       pr.GetMergeCommitSHA(),
-		MergedAt:   pr.MergedAt.GetTime(),
-		MergedInto: pr.GetBase().GetRef(),
-		URL:        pr.GetHTMLURL(),
+	// Copy, as the basic info is shared through the PR cache
+	prInfo := *basic
+
+	// Squash and merge commits keep Co-authored-by trailers in the commit message
+	commit, _, err := c.client.Repositories.GetCommit(c.ctx, owner, repo, prInfo.Hash, nil)
+	if err != nil {
+		logger.Debug("Failed to get merge commit %s for co-authors: %v", prInfo.Hash, err)
+	} else {
+		prInfo.CoAuthors = ParseCoAuthors(commit.GetCommit().GetMessage())
 	}
 
-	return prInfo, nil
+	return &prInfo, nil
 }
 
-// GetBasicPRInfo gets basic PR information regardless of merge status
+// GetBasicPRInfo gets basic PR information regardless of merge status. MergedAt and
+// Hash are only set for merged PRs. Results are cached for prInfoCacheTTL.
 func (c *Client) GetBasicPRInfo(owner, repo string, prNumber int) (*models.PRInfo, error) {
+	cacheKey := fmt.Sprintf("%s/%s#%d", owner, repo, prNumber)
+	c.prInfoCache.mu.Lock()
+	cached, ok := c.prInfoCache.byNumber[cacheKey]
+	c.prInfoCache.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < prInfoCacheTTL {
+		return cached.prInfo, nil
+	}
+
 	pr, _, err := c.client.PullRequests.Get(c.ctx, owner, repo, prNumber)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get PR %d: %w", prNumber, err)
@@ -84,7 +266,10 @@ func (c *Client) GetBasicPRInfo(owner, repo string, prNumber int) (*models.PRInf
 		Title:      pr.GetTitle(),
 		URL:        pr.GetHTMLURL(),
 		MergedInto: pr.GetBase().GetRef(),
+		Author:     pr.GetUser().GetLogin(),
+		IsDraft:    pr.GetDraft(),
 	}
+	applyLabelInfo(prInfo, pr)
 
 	// Only set merge-related fields if the PR is actually merged
 	if pr.MergedAt != nil {
@@ -92,9 +277,101 @@ func (c *Client) GetBasicPRInfo(owner, repo string, prNumber int) (*models.PRInf
 		prInfo.MergedAt = pr.MergedAt.GetTime()
 	}
 
+	c.prInfoCache.mu.Lock()
+	c.prInfoCache.byNumber[cacheKey] = prInfoCacheEntry{prInfo: prInfo, fetchedAt: time.Now()}
+	c.prInfoCache.mu.Unlock()
+
 	return prInfo, nil
 }
 
+// ListPRsForCommit returns the pull requests associated with a commit SHA. Results
+// are cached for prInfoCacheTTL.
+func (c *Client) ListPRsForCommit(owner, repo, sha string) ([]*github.PullRequest, error) {
+	cacheKey := fmt.Sprintf("%s/%s@%s", owner, repo, sha)
+	c.prInfoCache.mu.Lock()
+	cached, ok := c.prInfoCache.bySHA[cacheKey]
+	c.prInfoCache.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < prInfoCacheTTL {
+		return cached.prs, nil
+	}
+
+	var allPRs []*github.PullRequest
+	opts := &github.ListOptions{PerPage: DefaultPageSize}
+
+	for {
+		prs, resp, err := c.client.PullRequests.ListPullRequestsWithCommit(c.ctx, owner, repo, sha, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list PRs for commit %s: %w", sha, err)
+		}
+
+		allPRs = append(allPRs, prs...)
+
+		if opts.Page = c.nextPage(resp, "PRs of commit %s in %s/%s", sha, owner, repo); opts.Page == 0 {
+			break
+		}
+	}
+
+	c.prInfoCache.mu.Lock()
+	c.prInfoCache.bySHA[cacheKey] = prsCacheEntry{prs: allPRs, fetchedAt: time.Now()}
+	c.prInfoCache.mu.Unlock()
+
+	return allPRs, nil
+}
+
+// GetPRDiff returns the paths of the files a pull request changes.
+func (c *Client) GetPRDiff(owner, repo string, prNumber int) ([]string, error) {
+	var paths []string
+	opts := &github.ListOptions{PerPage: DefaultPageSize}
+
+	for {
+		files, resp, err := c.client.PullRequests.ListFiles(c.ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files of PR #%d: %w", prNumber, err)
+		}
+
+		for _, file := range files {
+			paths = append(paths, file.GetFilename())
+		}
+
+		if opts.Page = c.nextPage(resp, "files of PR #%d in %s/%s", prNumber, owner, repo); opts.Page == 0 {
+			break
+		}
+	}
+
+	return paths, nil
+}
+
+// SearchPRsByFile returns the numbers of merged PRs whose search index matches the given file path.
+// If branch is empty, PRs merged into any base branch are returned.
+func (c *Client) SearchPRsByFile(owner, repo, filePath, branch string) ([]int, error) {
+	query := fmt.Sprintf("repo:%s/%s is:pr is:merged %s", owner, repo, filePath)
+	if branch != "" {
+		query += " base:" + branch
+	}
+
+	var prNumbers []int
+	opts := &github.SearchOptions{
+		ListOptions: github.ListOptions{PerPage: DefaultPageSize},
+	}
+
+	for {
+		result, resp, err := c.client.Search.Issues(c.ctx, query, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search PRs by file %s: %w", filePath, err)
+		}
+
+		for _, issue := range result.Issues {
+			prNumbers = append(prNumbers, issue.GetNumber())
+		}
+
+		if opts.Page = c.nextPage(resp, "PRs changing %s in %s/%s", filePath, owner, repo); opts.Page == 0 {
+			break
+		}
+	}
+
+	return prNumbers, nil
+}
+
 // GetReleaseBranches fetches all branches matching the release pattern.
 func (c *Client) GetReleaseBranches(owner, repo, branchPrefix string) ([]string, error) {
 	var allBranches []string
@@ -116,10 +393,9 @@ func (c *Client) GetReleaseBranches(owner, repo, branchPrefix string) ([]string,
 			}
 		}
 
-		if resp.NextPage == 0 {
+		if opts.Page = c.nextPage(resp, "branches of %s/%s", owner, repo); opts.Page == 0 {
 			break
 		}
-		opts.Page = resp.NextPage
 	}
 
 	return allBranches, nil
@@ -151,6 +427,65 @@ func (c *Client) CheckCommitInBranch(owner, repo, commitSHA, branchName string)
 	return found, mergedAt, nil
 }
 
+// cherryPickSearchCommits is how many of a branch's most recent commits
+// CheckCherryPickInBranch looks through.
+const cherryPickSearchCommits = 300
+
+// cherryPickTrailerRegex matches the trailer added by "git cherry-pick -x".
+var cherryPickTrailerRegex = regexp.MustCompile(`\(cherry picked from commit ([0-9a-f]{7,40})\)`)
+
+// CommitTitle returns the first line of a commit message.
+func CommitTitle(message string) string {
+	title, _, _ := strings.Cut(message, "\n")
+	return strings.TrimSpace(title)
+}
+
+// IsCherryPickOf reports whether the commit with candidateMessage is a cherry-pick
+// of the commit with commitMessage: either its "cherry picked from commit" trailer
+// names commitSHA (when commitSHA is not empty), or both have the same title.
+func IsCherryPickOf(candidateMessage, commitMessage, commitSHA string) bool {
+	if commitSHA != "" {
+		for _, match := range cherryPickTrailerRegex.FindAllStringSubmatch(candidateMessage, -1) {
+			if strings.HasPrefix(commitSHA, match[1]) {
+				return true
+			}
+		}
+	}
+	title := CommitTitle(commitMessage)
+	return title != "" && CommitTitle(candidateMessage) == title
+}
+
+// CheckCherryPickInBranch checks whether a commit with the same title as commitMessage
+// is among the recent commits of a branch, as happens when the original commit was
+// cherry-picked there. It returns the commit date of the cherry-pick.
+func (c *Client) CheckCherryPickInBranch(owner, repo, commitMessage, branchName string) (bool, *time.Time, error) {
+	opts := &github.CommitsListOptions{
+		SHA:         branchName,
+		ListOptions: github.ListOptions{PerPage: DefaultPageSize},
+	}
+
+	for searched := 0; searched < cherryPickSearchCommits; {
+		commits, resp, err := c.client.Repositories.ListCommits(c.ctx, owner, repo, opts)
+		if err != nil {
+			return false, nil, fmt.Errorf("failed to list commits of %s: %w", branchName, err)
+		}
+
+		for _, commit := range commits {
+			if IsCherryPickOf(commit.GetCommit().GetMessage(), commitMessage, "") {
+				date := commit.GetCommit().GetCommitter().GetDate()
+				return true, date.GetTime(), nil
+			}
+		}
+		searched += len(commits)
+
+		if opts.Page = c.nextPage(resp, "commits of %s in %s/%s", branchName, owner, repo); opts.Page == 0 {
+			break
+		}
+	}
+
+	return false, nil, nil
+}
+
 // GetVersionTags gets all tags that match a version prefix (e.g., v2.40 -> v2.40.0, v2.40.1, etc.)
 func (c *Client) GetVersionTags(owner, repo, versionPrefix string) ([]string, error) {
 	var matchingTags []string
@@ -171,10 +506,9 @@ func (c *Client) GetVersionTags(owner, repo, versionPrefix string) ([]string, er
 			}
 		}
 
-		if resp.NextPage == 0 {
+		if opts.Page = c.nextPage(resp, "tags of %s/%s", owner, repo); opts.Page == 0 {
 			break
 		}
-		opts.Page = resp.NextPage
 	}
 
 	return matchingTags, nil
@@ -225,10 +559,9 @@ func (c *Client) CheckCommitInTag(owner, repo, commitSHA, tagName string) (bool,
 			}
 		}
 
-		if resp.NextPage == 0 {
+		if opts.Page = c.nextPage(resp, "commits of %s in %s/%s", tagName, owner, repo); opts.Page == 0 {
 			break
 		}
-		opts.Page = resp.NextPage
 	}
 
 	// Commit not found in this tag's history
@@ -297,10 +630,9 @@ func (c *Client) GetAllTags(owner, repo string) ([]string, error) {
 			allTags = append(allTags, tag.GetName())
 		}
 
-		if resp.NextPage == 0 {
+		if opts.Page = c.nextPage(resp, "tags of %s/%s", owner, repo); opts.Page == 0 {
 			break
 		}
-		opts.Page = resp.NextPage
 	}
 
 	return allTags, nil
@@ -320,10 +652,76 @@ func (c *Client) TagExists(owner, repo, tag string) (bool, error) {
 	return true, nil
 }
 
+// GetTagCommitSHA returns the SHA of the commit a tag points to,
+// resolving annotated tags to their target commit.
+func (c *Client) GetTagCommitSHA(owner, repo, tag string) (string, error) {
+	tagRef, _, err := c.client.Git.GetRef(c.ctx, owner, repo, "tags/"+tag)
+	if err != nil {
+		return "", fmt.Errorf("failed to get tag %s: %w", tag, err)
+	}
+
+	object := tagRef.GetObject()
+	if object.GetType() != "tag" {
+		return object.GetSHA(), nil
+	}
+
+	annotated, _, err := c.client.Git.GetTag(c.ctx, owner, repo, object.GetSHA())
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve annotated tag %s: %w", tag, err)
+	}
+	return annotated.GetObject().GetSHA(), nil
+}
+
+// CommitsApart returns how many commits separate two SHAs in either direction.
+func (c *Client) CommitsApart(owner, repo, base, head string) (int, error) {
+	comparison, _, err := c.client.Repositories.CompareCommits(c.ctx, owner, repo, base, head, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to compare %s...%s: %w", base, head, err)
+	}
+	return comparison.GetAheadBy() + comparison.GetBehindBy(), nil
+}
+
+// GetReleaseForTag fetches the GitHub release published for a tag.
+// It returns nil without an error if the tag has no release.
+func (c *Client) GetReleaseForTag(owner, repo, tag string) (*github.RepositoryRelease, error) {
+	release, resp, err := c.client.Repositories.GetReleaseByTag(c.ctx, owner, repo, tag)
+	if err != nil {
+		if resp != nil && resp.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get release for tag %s: %w", tag, err)
+	}
+	return release, nil
+}
+
+// IsReleasedTag reports whether a tag can be treated as a published version.
+// Tags of draft releases are rejected, as are pre-releases unless includePreRelease is set.
+// Tags without a GitHub release are accepted.
+func (c *Client) IsReleasedTag(owner, repo, tag string, includePreRelease bool) bool {
+	release, err := c.GetReleaseForTag(owner, repo, tag)
+	if err != nil {
+		logger.Debug("Could not check release for tag %s, treating it as released: %v", tag, err)
+		return true
+	}
+	if release == nil {
+		return true
+	}
+	if release.GetDraft() {
+		logger.Debug("Skipping tag %s: release is a draft", tag)
+		return false
+	}
+	if release.GetPrerelease() && !includePreRelease {
+		logger.Debug("Skipping tag %s: release is a pre-release", tag)
+		return false
+	}
+	return true
+}
+
 // FindPreviousVersion finds the previous version for a given version tag
 // For v2.40.0 -> find v2.39.X (latest patch of previous minor)
 // For v2.40.1 -> find v2.40.0 (previous patch)
-func (c *Client) FindPreviousVersion(owner, repo, version string) (string, error) {
+// Tags of draft releases are skipped, and so are pre-releases unless includePreRelease is set.
+func (c *Client) FindPreviousVersion(owner, repo, version string, includePreRelease bool) (string, error) {
 	// Get all tags
 	allTags, err := c.GetAllTags(owner, repo)
 	if err != nil {
@@ -336,6 +734,11 @@ func (c *Client) FindPreviousVersion(owner, repo, version string) (string, error
 		return "", fmt.Errorf("invalid version format %s: %w", version, err)
 	}
 
+	// Release lookups cost an API call each, so only check tags we would return
+	isReleased := func(tag string) bool {
+		return c.IsReleasedTag(owner, repo, tag, includePreRelease)
+	}
+
 	var candidates []string
 
 	if patch > 0 {
@@ -344,38 +747,31 @@ func (c *Client) FindPreviousVersion(owner, repo, version string) (string, error
 		for p := patch - 1; p >= 0; p-- {
 			targetVersion := fmt.Sprintf("v%d.%d.%d", major, minor, p)
 			for _, tag := range allTags {
-				if tag == targetVersion {
+				if tag == targetVersion && isReleased(tag) {
 					return tag, nil
 				}
 			}
 		}
+	}
 
-		// If no patch versions found, fall back to looking for previous minor versions
-		targetPrefix := fmt.Sprintf("v%d.%d.", major, minor-1)
-		for _, tag := range allTags {
-			if strings.HasPrefix(tag, targetPrefix) {
-				candidates = append(candidates, tag)
-			}
-		}
-	} else {
-		// For minor versions (e.g., v2.40.0), find the latest patch of the previous minor (v2.39.X)
-		targetPrefix := fmt.Sprintf("v%d.%d.", major, minor-1)
-		for _, tag := range allTags {
-			if strings.HasPrefix(tag, targetPrefix) {
-				candidates = append(candidates, tag)
-			}
+	// For minor versions (e.g., v2.40.0), or when no previous patch exists,
+	// find the latest patch of the previous minor (v2.39.X)
+	targetPrefix := fmt.Sprintf("v%d.%d.", major, minor-1)
+	for _, tag := range allTags {
+		if strings.HasPrefix(tag, targetPrefix) {
+			candidates = append(candidates, tag)
 		}
 	}
 
-	// Find the latest patch version from candidates
-	if len(candidates) > 0 {
-		latest := candidates[0]
-		for _, candidate := range candidates[1:] {
-			if candidate > latest { // String comparison works for semantic versions
-				latest = candidate
-			}
+	// Return the latest released patch version from candidates
+	// Compare numerically: as strings, v2.39.10 would sort before v2.39.9
+	sort.Slice(candidates, func(i, j int) bool {
+		return models.CompareSemanticVersions(candidates[i], candidates[j]) > 0
+	})
+	for _, candidate := range candidates {
+		if isReleased(candidate) {
+			return candidate, nil
 		}
-		return latest, nil
 	}
 
 	return "", fmt.Errorf("no previous version found for %s", version)
@@ -403,6 +799,28 @@ func (c *Client) GetCommitsBetweenSHAs(owner, repo, baseSHA, headSHA string) ([]
 	return comparison.Commits, nil
 }
 
+// GetCommitsBetweenBranches gets the commits of headBranch that are not in baseBranch,
+// oldest first
+func (c *Client) GetCommitsBetweenBranches(owner, repo, baseBranch, headBranch string) ([]*github.RepositoryCommit, error) {
+	var commits []*github.RepositoryCommit
+	opts := &github.ListOptions{PerPage: DefaultPageSize}
+
+	for {
+		comparison, resp, err := c.client.Repositories.CompareCommits(c.ctx, owner, repo, baseBranch, headBranch, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare %s...%s: %w", baseBranch, headBranch, err)
+		}
+
+		commits = append(commits, comparison.Commits...)
+
+		if opts.Page = c.nextPage(resp, "commits between %s and %s in %s/%s", baseBranch, headBranch, owner, repo); opts.Page == 0 {
+			break
+		}
+	}
+
+	return commits, nil
+}
+
 // parseVersion parses a version string like "v2.40.1" into major, minor, patch
 func parseVersion(version string) (major, minor, patch int, err error) {
 	// Remove 'v' prefix if present
@@ -483,28 +901,89 @@ type BranchInfo struct {
 	Version string `json:"version"`
 }
 
-// GetAllReleaseBranches fetches all branches matching various release patterns.
-// It searches for branches that start with:
+// BranchMatcher assigns branch names to release branch patterns.
+type BranchMatcher struct {
+	patterns []compiledBranchPattern
+}
+
+// compiledBranchPattern is a BranchPattern with its version regex compiled.
+type compiledBranchPattern struct {
+	models.BranchPattern
+	versionRegex *regexp.Regexp // nil when the pattern has no regex
+}
+
+// NewBranchMatcher compiles patterns for matching branch names.
+func NewBranchMatcher(patterns []models.BranchPattern) (*BranchMatcher, error) {
+	m := &BranchMatcher{}
+	for _, pattern := range patterns {
+		if pattern.Prefix == "" {
+			return nil, fmt.Errorf("branch pattern %q has no prefix", pattern.Key())
+		}
+		compiled := compiledBranchPattern{BranchPattern: pattern}
+		if pattern.VersionExtractRegex != "" {
+			re, err := regexp.Compile(pattern.VersionExtractRegex)
+			if err != nil {
+				return nil, fmt.Errorf("invalid version regex for branch pattern %q: %w", pattern.Prefix, err)
+			}
+			compiled.versionRegex = re
+		}
+		m.patterns = append(m.patterns, compiled)
+	}
+	return m, nil
+}
+
+// Match returns the release branch info for name. When several prefixes match, the
+// longest wins, so "release-v1.0.9" is a "release-v" branch rather than a "release-"
+// one. A pattern with a version regex only matches names whose remainder it matches.
+func (m *BranchMatcher) Match(name string) (BranchInfo, bool) {
+	var best *compiledBranchPattern
+	var bestVersion string
+	for i := range m.patterns {
+		pattern := &m.patterns[i]
+		rest, ok := strings.CutPrefix(name, pattern.Prefix)
+		if !ok || (best != nil && len(pattern.Prefix) <= len(best.Prefix)) {
+			continue
+		}
+
+		version := rest
+		if pattern.versionRegex != nil {
+			match := pattern.versionRegex.FindStringSubmatch(rest)
+			if match == nil {
+				continue
+			}
+			version = match[0]
+			if len(match) > 1 {
+				version = match[1]
+			}
+		}
+		best, bestVersion = pattern, version
+	}
+
+	if best == nil {
+		return BranchInfo{}, false
+	}
+	return BranchInfo{Name: name, Pattern: best.Key(), Version: bestVersion}, true
+}
+
+// GetAllReleaseBranches fetches all branches matching the client's release branch
+// patterns (models.DefaultBranchPatterns unless configured), such as:
 // - release-ocm-<version> (for ACM and MCE repositories)
 // - release-<version> (like release-4.6, release-4.7, etc.)
 // - release-v<version> (like release-v1.0.9.6)
 // - v<version> (like v2.40)
+// When no branch matches these, the patterns found by DetectBranchPatterns are used instead.
 func (c *Client) GetAllReleaseBranches(owner, repo string) ([]BranchInfo, error) {
-	var allBranches []BranchInfo
+	matcher, err := NewBranchMatcher(c.branchPatterns)
+	if err != nil {
+		return nil, err
+	}
+
+	var branchNames []string
 
 	opts := &github.BranchListOptions{
 		ListOptions: github.ListOptions{PerPage: DefaultPageSize},
 	}
 
-	// Define the branch patterns to search for
-	branchPatterns := []string{
-		"release-ocm-",
-		"releases/v", // For assisted-installer-ui style branches
-		"release-v",
-		"release-",
-		"v",
-	}
-
 	for {
 		branches, resp, err := c.client.Repositories.ListBranches(c.ctx, owner, repo, opts)
 		if err != nil {
@@ -512,54 +991,177 @@ func (c *Client) GetAllReleaseBranches(owner, repo string) ([]BranchInfo, error)
 		}
 
 		for _, branch := range branches {
-			name := branch.GetName()
+			branchNames = append(branchNames, branch.GetName())
+		}
 
-			// Check each pattern and add to results
-			for _, pattern := range branchPatterns {
-				if strings.HasPrefix(name, pattern) {
-					// Special handling for patterns to avoid duplicates
-					if pattern == "release-" {
-						// Skip if it matches release-v, release-ocm-, or releases/v patterns
-						if strings.HasPrefix(name, "release-v") || strings.HasPrefix(name, "release-ocm-") || strings.HasPrefix(name, "releases/v") {
-							continue
-						}
-					}
-					if pattern == "release-v" {
-						// Skip if it matches releases/v pattern
-						if strings.HasPrefix(name, "releases/v") {
-							continue
-						}
-					}
-					if pattern == "v" {
-						// Skip if it matches release-v or releases/v patterns or if it's not a version pattern
-						if strings.HasPrefix(name, "release-v") || strings.HasPrefix(name, "releases/v") {
-							continue
-						}
-						// Only match if it's v followed by a digit (version pattern)
-						if len(name) > 1 && !regexp.MustCompile(`^v\d`).MatchString(name) {
-							continue
-						}
-					}
+		if opts.Page = c.nextPage(resp, "branches of %s/%s", owner, repo); opts.Page == 0 {
+			break
+		}
+	}
 
-					version := ExtractVersionFromBranchWithPattern(name, pattern)
-					branchInfo := BranchInfo{
-						Name:    name,
-						Pattern: pattern,
-						Version: version,
-					}
-					allBranches = append(allBranches, branchInfo)
-					break // Only match one pattern per branch
-				}
-			}
+	var allBranches []BranchInfo
+	for _, name := range branchNames {
+		if branchInfo, ok := matcher.Match(name); ok {
+			allBranches = append(allBranches, branchInfo)
 		}
+	}
+
+	// Repositories with their own naming scheme match none of the release patterns;
+	// fall back to the prefixes their branch names actually use.
+	if len(allBranches) == 0 && len(branchNames) > 0 {
+		detected := detectBranchPatterns(branchNames)
+		logger.Debug("No release branches matched the release branch patterns in %s/%s, using detected patterns %v", owner, repo, detected)
+		allBranches = matchDetectedPatterns(branchNames, detected)
+	}
+
+	return allBranches, nil
+}
+
+// Limits for DetectBranchPatterns.
+const (
+	detectPatternsMaxBranches = 200 // Branch names sampled from the repository
+	detectPatternsMinVersions = 3   // Distinct versions a prefix needs to count as a pattern
+	detectPatternsMaxPatterns = 5   // Most common prefixes returned
+)
 
-		if resp.NextPage == 0 {
+// branchPrefixRegex captures the non-digit prefix of a branch name that continues
+// with a dotted version number, such as "release-" in "release-4.15", and the
+// major.minor version that follows it.
+var branchPrefixRegex = regexp.MustCompile(`^(\D+?)(\d+\.\d+)`)
+
+// detectedVersionRegex extracts the version of branches matching a detected pattern.
+const detectedVersionRegex = `^(\d+\.\d+(?:\.\d+)*)`
+
+// DetectBranchPatterns suggests release branch patterns for a repository by looking
+// at the prefixes of its branch names that are followed by a version number, such as
+// "release-ocm-" in "release-ocm-2.13". Only the first 200 branches are sampled, and
+// a prefix must be followed by at least 3 different major.minor versions.
+// At most the 5 patterns used by the most branches are returned, most common first.
+func (c *Client) DetectBranchPatterns(owner, repo string) ([]string, error) {
+	branchNames, err := c.sampleBranchNames(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	return detectBranchPatterns(branchNames), nil
+}
+
+// DetectCustomBranchPatterns is DetectBranchPatterns for repositories with their own
+// release branch naming scheme. It returns no patterns when a sampled branch already
+// matches models.DefaultBranchPatterns.
+func (c *Client) DetectCustomBranchPatterns(owner, repo string) ([]string, error) {
+	branchNames, err := c.sampleBranchNames(owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	matcher, err := NewBranchMatcher(models.DefaultBranchPatterns)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range branchNames {
+		if _, ok := matcher.Match(name); ok {
+			logger.Debug("Branch %s of %s/%s matches the default branch patterns, not detecting others", name, owner, repo)
+			return nil, nil
+		}
+	}
+	return detectBranchPatterns(branchNames), nil
+}
+
+// sampleBranchNames returns the names of the first detectPatternsMaxBranches
+// branches of a repository.
+func (c *Client) sampleBranchNames(owner, repo string) ([]string, error) {
+	var branchNames []string
+
+	opts := &github.BranchListOptions{
+		ListOptions: github.ListOptions{PerPage: DefaultPageSize},
+	}
+
+	for len(branchNames) < detectPatternsMaxBranches {
+		branches, resp, err := c.client.Repositories.ListBranches(c.ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list branches: %w", err)
+		}
+
+		for _, branch := range branches {
+			branchNames = append(branchNames, branch.GetName())
+		}
+
+		if opts.Page = c.nextPage(resp, "branches of %s/%s", owner, repo); opts.Page == 0 {
 			break
 		}
-		opts.Page = resp.NextPage
 	}
 
-	return allBranches, nil
+	if len(branchNames) > detectPatternsMaxBranches {
+		branchNames = branchNames[:detectPatternsMaxBranches]
+	}
+	return branchNames, nil
+}
+
+// detectBranchPatterns returns the version prefixes followed by at least
+// detectPatternsMinVersions distinct versions in branchNames, most common first.
+func detectBranchPatterns(branchNames []string) []string {
+	counts := make(map[string]int)
+	versions := make(map[string]map[string]bool)
+	for _, name := range branchNames {
+		match := branchPrefixRegex.FindStringSubmatch(name)
+		if match == nil {
+			continue
+		}
+		prefix, version := match[1], match[2]
+		counts[prefix]++
+		if versions[prefix] == nil {
+			versions[prefix] = make(map[string]bool)
+		}
+		versions[prefix][version] = true
+	}
+
+	var patterns []string
+	for prefix := range counts {
+		if len(versions[prefix]) >= detectPatternsMinVersions {
+			patterns = append(patterns, prefix)
+		}
+	}
+
+	sort.Slice(patterns, func(i, j int) bool {
+		if counts[patterns[i]] != counts[patterns[j]] {
+			return counts[patterns[i]] > counts[patterns[j]]
+		}
+		return patterns[i] < patterns[j]
+	})
+
+	if len(patterns) > detectPatternsMaxPatterns {
+		patterns = patterns[:detectPatternsMaxPatterns]
+	}
+	return patterns
+}
+
+// DetectedBranchPattern returns the release branch pattern for a prefix returned by
+// DetectBranchPatterns.
+func DetectedBranchPattern(prefix string) models.BranchPattern {
+	return models.BranchPattern{Prefix: prefix, PatternKey: prefix, VersionExtractRegex: detectedVersionRegex}
+}
+
+// matchDetectedPatterns builds BranchInfo for the branch names whose version prefix
+// is one of patterns.
+func matchDetectedPatterns(branchNames []string, patterns []string) []BranchInfo {
+	known := make(map[string]bool, len(patterns))
+	for _, pattern := range patterns {
+		known[pattern] = true
+	}
+
+	var branches []BranchInfo
+	for _, name := range branchNames {
+		match := branchPrefixRegex.FindStringSubmatch(name)
+		if match == nil || !known[match[1]] {
+			continue
+		}
+		branches = append(branches, BranchInfo{
+			Name:    name,
+			Pattern: match[1],
+			Version: ExtractVersionFromBranchWithPattern(name, match[1]),
+		})
+	}
+	return branches
 }
 
 // GetCommit gets commit information by SHA.
@@ -567,9 +1169,23 @@ func (c *Client) GetCommit(owner, repo, sha string) (*github.RepositoryCommit, *
 	return c.client.Repositories.GetCommit(c.ctx, owner, repo, sha, nil)
 }
 
+// EnterpriseHost returns the web host of a GitHub Enterprise API base URL
+// (e.g. github.mycompany.com), or "" when baseURL is empty or invalid.
+func EnterpriseHost(baseURL string) string {
+	if baseURL == "" {
+		return ""
+	}
+	parsed, err := url.Parse(baseURL)
+	if err != nil {
+		return ""
+	}
+	return parsed.Host
+}
+
 // ParsePRInput parses PR input which can be either a number or a GitHub URL.
+// URLs must be from github.com or one of enterpriseHosts.
 // Returns: prNumber, owner, repo, error.
-func ParsePRInput(input string) (int, string, string, error) {
+func ParsePRInput(input string, enterpriseHosts ...string) (int, string, string, error) {
 	if prNumber, err := strconv.Atoi(input); err == nil {
 		return prNumber, "", "", nil
 	}
@@ -583,8 +1199,14 @@ func ParsePRInput(input string) (int, string, string, error) {
 		return 0, "", "", fmt.Errorf("invalid URL: %w", err)
 	}
 
-	if parsedURL.Host != GitHubHost {
-		return 0, "", "", fmt.Errorf("URL must be from github.com")
+	allowedHosts := []string{GitHubHost}
+	for _, host := range enterpriseHosts {
+		if host != "" {
+			allowedHosts = append(allowedHosts, host)
+		}
+	}
+	if !slices.Contains(allowedHosts, parsedURL.Host) {
+		return 0, "", "", fmt.Errorf("URL must be from %s", strings.Join(allowedHosts, " or "))
 	}
 
 	pathParts := strings.Split(strings.Trim(parsedURL.Path, "/"), "/")
@@ -622,3 +1244,12 @@ func (c *Client) GetFileContent(owner, repo, path, sha string) (string, error) {
 
 	return content, nil
 }
+
+// Ping checks that the GitHub API is reachable with the client's token by
+// reading the rate limit, which does not count against it.
+func (c *Client) Ping(ctx context.Context) error {
+	if _, _, err := c.client.RateLimits(ctx); err != nil {
+		return fmt.Errorf("failed to get rate limit: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,218 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a Client whose API requests are served by handler.
+func newTestClient(t *testing.T, handler http.Handler, opts ClientOptions) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	opts.BaseURL = server.URL + "/"
+	opts.MaxRetries = -1
+	return NewClient(context.Background(), "", opts)
+}
+
+func TestGetBasicPRInfo(t *testing.T) {
+	mergedAt := time.Date(2025, 3, 14, 18, 55, 26, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		body       string
+		wantDraft  bool
+		wantMerged bool
+	}{
+		{
+			name:       "merged",
+			body:       `{"number": 1, "title": "Merged PR", "state": "closed", "draft": false, "merged": true, "merged_at": "2025-03-14T18:55:26Z", "merge_commit_sha": "abc123"}`,
+			wantMerged: true,
+		},
+		{
+			name:      "open draft",
+			body:      `{"number": 1, "title": "Draft PR", "state": "open", "draft": true, "merged": false, "merged_at": null, "merge_commit_sha": "def456"}`,
+			wantDraft: true,
+		},
+		{
+			name: "open ready for review",
+			body: `{"number": 1, "title": "Open PR", "state": "open", "draft": false, "merged": false, "merged_at": null, "merge_commit_sha": "def456"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mux := http.NewServeMux()
+			mux.HandleFunc("/api/v3/repos/owner/repo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, tt.body)
+			})
+			client := newTestClient(t, mux, ClientOptions{})
+
+			prInfo, err := client.GetBasicPRInfo("owner", "repo", 1)
+			if err != nil {
+				t.Fatalf("GetBasicPRInfo() error = %v", err)
+			}
+
+			if prInfo.IsDraft != tt.wantDraft {
+				t.Errorf("IsDraft = %v, want %v", prInfo.IsDraft, tt.wantDraft)
+			}
+			if merged := prInfo.MergedAt != nil; merged != tt.wantMerged {
+				t.Fatalf("merged = %v, want %v", merged, tt.wantMerged)
+			}
+			if tt.wantMerged {
+				if !prInfo.MergedAt.Equal(mergedAt) {
+					t.Errorf("MergedAt = %v, want %v", prInfo.MergedAt, mergedAt)
+				}
+				if prInfo.Hash != "abc123" {
+					t.Errorf("Hash = %q, want %q", prInfo.Hash, "abc123")
+				}
+			} else if prInfo.Hash != "" {
+				t.Errorf("Hash = %q, want empty for an unmerged PR", prInfo.Hash)
+			}
+		})
+	}
+}
+
+func TestGetBasicPRInfoCacheExpires(t *testing.T) {
+	var calls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/owner/repo/pulls/1", func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		fmt.Fprint(w, `{"number": 1, "title": "Open PR", "state": "open", "draft": false}`)
+	})
+	client := newTestClient(t, mux, ClientOptions{})
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetBasicPRInfo("owner", "repo", 1); err != nil {
+			t.Fatalf("GetBasicPRInfo() error = %v", err)
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("PR fetched %d times, want 1", got)
+	}
+
+	// Age the cached entry past the TTL
+	client.prInfoCache.mu.Lock()
+	entry := client.prInfoCache.byNumber["owner/repo#1"]
+	entry.fetchedAt = time.Now().Add(-prInfoCacheTTL - time.Minute)
+	client.prInfoCache.byNumber["owner/repo#1"] = entry
+	client.prInfoCache.mu.Unlock()
+
+	if _, err := client.GetBasicPRInfo("owner", "repo", 1); err != nil {
+		t.Fatalf("GetBasicPRInfo() error = %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("PR fetched %d times, want 2 after the TTL", got)
+	}
+}
+
+// pagedBranchesHandler serves the branches of owner/repo over three pages linked
+// with Link headers, one branch per page, and records the pages requested.
+func pagedBranchesHandler(t *testing.T, requested *[]int) http.Handler {
+	const lastPage = 3
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/api/v3/repos/owner/repo/branches", func(w http.ResponseWriter, r *http.Request) {
+		page := 1
+		if p := r.URL.Query().Get("page"); p != "" {
+			var err error
+			if page, err = strconv.Atoi(p); err != nil {
+				t.Errorf("invalid page %q", p)
+			}
+		}
+		*requested = append(*requested, page)
+
+		if page < lastPage {
+			next := *r.URL
+			q := next.Query()
+			q.Set("page", strconv.Itoa(page+1))
+			next.RawQuery = q.Encode()
+			w.Header().Set("Link", fmt.Sprintf(`<http://%s%s>; rel="next"`, r.Host, next.RequestURI()))
+		}
+		fmt.Fprintf(w, `[{"name": "release-ocm-2.%d"}]`, page)
+	})
+	return mux
+}
+
+func TestPaginationCap(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxPages     int
+		wantPages    []int
+		wantBranches []string
+	}{
+		{
+			name:         "unset cap reads all pages",
+			wantPages:    []int{1, 2, 3},
+			wantBranches: []string{"release-ocm-2.1", "release-ocm-2.2", "release-ocm-2.3"},
+		},
+		{
+			name:         "cap stops after N pages",
+			maxPages:     2,
+			wantPages:    []int{1, 2},
+			wantBranches: []string{"release-ocm-2.1", "release-ocm-2.2"},
+		},
+		{
+			name:         "cap of one page",
+			maxPages:     1,
+			wantPages:    []int{1},
+			wantBranches: []string{"release-ocm-2.1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requested []int
+			client := newTestClient(t, pagedBranchesHandler(t, &requested), ClientOptions{MaxPages: tt.maxPages})
+
+			branches, err := client.GetReleaseBranches("owner", "repo", "release-ocm-")
+			if err != nil {
+				t.Fatalf("GetReleaseBranches() error = %v", err)
+			}
+			if !reflect.DeepEqual(requested, tt.wantPages) {
+				t.Errorf("requested pages %v, want %v", requested, tt.wantPages)
+			}
+			if !reflect.DeepEqual(branches, tt.wantBranches) {
+				t.Errorf("branches = %v, want %v", branches, tt.wantBranches)
+			}
+		})
+	}
+}
+
+func TestDetectBranchPatterns(t *testing.T) {
+	tests := []struct {
+		name     string
+		branches []string
+		want     []string
+	}{
+		{
+			name:     "release prefix with several versions",
+			branches: []string{"main", "stable-1.2", "stable-1.3", "stable-1.4", "stable-1.4.1"},
+			want:     []string{"stable-"},
+		},
+		{
+			name:     "two branches sharing a prefix",
+			branches: []string{"main", "feature-1.2", "feature-1.3"},
+		},
+		{
+			name:     "one version on several branches",
+			branches: []string{"fix-2.0-a", "fix-2.0-b", "fix-2.0-c"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := detectBranchPatterns(tt.branches); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("detectBranchPatterns() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/models"
+)
+
+// AnalysisCommentMarker is a hidden marker at the start of every analysis comment,
+// used to find and replace the comment pr-bot posted on an earlier run.
+const AnalysisCommentMarker = "<!-- pr-bot:release-analysis -->"
+
+// PostAnalysisComment posts body as a comment on a PR after deleting any earlier
+// comment carrying AnalysisCommentMarker, so a PR only ever has the latest analysis.
+// PR conversation comments are issue comments in the GitHub API. With dryRun, the
+// earlier comments are still looked up, but nothing is deleted or posted: the
+// comment is printed, labeled [DRY RUN], instead.
+func (c *Client) PostAnalysisComment(ctx context.Context, dryRun bool, owner, repo string, prNumber int, body string) error {
+	logger.Debug("Posting analysis comment to %s/%s#%d (dry run: %v)", owner, repo, prNumber, dryRun)
+
+	var previous []int64
+	opts := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{PerPage: DefaultPageSize}}
+	for {
+		comments, resp, err := c.client.Issues.ListComments(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list comments: %w", err)
+		}
+		for _, comment := range comments {
+			if strings.Contains(comment.GetBody(), AnalysisCommentMarker) {
+				previous = append(previous, comment.GetID())
+			}
+		}
+		if opts.Page = c.nextPage(resp, "comments of PR #%d in %s/%s", prNumber, owner, repo); opts.Page == 0 {
+			break
+		}
+	}
+
+	if !strings.Contains(body, AnalysisCommentMarker) {
+		body = AnalysisCommentMarker + "\n" + body
+	}
+
+	if dryRun {
+		fmt.Printf("\n[DRY RUN] Would delete %d previous pr-bot comment(s) and post this comment on %s/%s#%d:\n%s\n[DRY RUN] End of comment\n",
+			len(previous), owner, repo, prNumber, body)
+		return nil
+	}
+
+	for _, id := range previous {
+		logger.Debug("Deleting previous analysis comment %d on %s/%s#%d", id, owner, repo, prNumber)
+		if _, err := c.client.Issues.DeleteComment(ctx, owner, repo, id); err != nil {
+			return fmt.Errorf("failed to delete previous comment %d: %w", id, err)
+		}
+	}
+
+	if _, _, err := c.client.Issues.CreateComment(ctx, owner, repo, prNumber, &github.IssueComment{Body: github.String(body)}); err != nil {
+		return fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	return nil
+}
+
+// FormatAnalysisComment renders a PR analysis as a Markdown comment: a table of the
+// release branches containing the PR with their version and GA status, followed by
+// when and by which pr-bot version it was generated. version may be empty.
+func FormatAnalysisComment(result *models.PRAnalysisResult, version string) string {
+	var b strings.Builder
+	b.WriteString(AnalysisCommentMarker + "\n")
+	b.WriteString("### Release branch analysis\n\n")
+
+	var found []models.BranchPresence
+	for _, branch := range result.ReleaseBranches {
+		if branch.Found {
+			found = append(found, branch)
+		}
+	}
+	sort.Slice(found, func(i, j int) bool {
+		return models.CompareBranchVersions(found[i].Version, found[j].Version) < 0
+	})
+
+	if len(found) == 0 {
+		b.WriteString("This PR is not in any release branch yet.\n")
+	} else {
+		b.WriteString("| Branch | Version | GA status |\n")
+		b.WriteString("| --- | --- | --- |\n")
+		for _, branch := range found {
+			fmt.Fprintf(&b, "| `%s` | %s | %s |\n", branch.BranchName, escapeMarkdownTableCell(branch.Version), escapeMarkdownTableCell(gaStatusText(branch)))
+		}
+	}
+
+	generatedBy := "pr-bot"
+	if version != "" {
+		generatedBy += " " + version
+	}
+	fmt.Fprintf(&b, "\n<sub>Generated by %s on %s</sub>\n", generatedBy, time.Now().UTC().Format("2006-01-02 15:04 MST"))
+	return b.String()
+}
+
+// gaStatusText describes whether a branch has shipped: the released versions if any,
+// otherwise the next ACM/MCE GA planned for it.
+func gaStatusText(branch models.BranchPresence) string {
+	if len(branch.ReleasedVersions) > 0 {
+		return "Released in " + strings.Join(branch.ReleasedVersions, ", ")
+	}
+
+	now := time.Now()
+	var parts []string
+	for _, ga := range branch.UpcomingGAs {
+		if ga.GADate == nil {
+			continue
+		}
+		state := "GA planned"
+		if ga.GADate.Before(now) {
+			state = "GA"
+		}
+		parts = append(parts, fmt.Sprintf("%s %s %s %s", ga.Product, ga.Version, state, models.FormatDate(ga.GADate)))
+	}
+	if len(parts) == 0 {
+		return "Not GA yet"
+	}
+	return strings.Join(parts, ", ")
+}
+
+// escapeMarkdownTableCell keeps text from breaking a Markdown table row.
+func escapeMarkdownTableCell(text string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(text)
+}
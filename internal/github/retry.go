@@ -0,0 +1,209 @@
+package github
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/metrics"
+)
+
+// Retry defaults used when ClientOptions leaves the fields unset.
+const (
+	DefaultMaxRetries      = 3
+	DefaultRetryBaseDelay  = time.Second
+	DefaultRateLimitBuffer = 5 * time.Second
+	DefaultMaxRetryWait    = 15 * time.Minute
+)
+
+// retryTransport retries idempotent GitHub API requests that hit the rate limit or
+// fail with a transient 5xx error. When the rate limit is exhausted it waits until
+// the X-RateLimit-Reset time, so a long analysis slows down instead of failing,
+// unless the reset is further away than maxWait.
+type retryTransport struct {
+	base            http.RoundTripper
+	maxRetries      int
+	baseDelay       time.Duration
+	rateLimitBuffer time.Duration
+	maxWait         time.Duration
+}
+
+// newRetryTransport wraps base with the retry settings from options.
+// A nil base uses http.DefaultTransport.
+func newRetryTransport(base http.RoundTripper, options ClientOptions) *retryTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	t := &retryTransport{
+		base:            base,
+		maxRetries:      options.MaxRetries,
+		baseDelay:       options.RetryBaseDelay,
+		rateLimitBuffer: options.RateLimitBuffer,
+		maxWait:         options.MaxRetryWait,
+	}
+	if t.maxRetries == 0 {
+		t.maxRetries = DefaultMaxRetries
+	}
+	if t.baseDelay <= 0 {
+		t.baseDelay = DefaultRetryBaseDelay
+	}
+	if t.rateLimitBuffer <= 0 {
+		t.rateLimitBuffer = DefaultRateLimitBuffer
+	}
+	if t.maxWait <= 0 {
+		t.maxWait = DefaultMaxRetryWait
+	}
+	return t
+}
+
+// idempotentMethods are the HTTP methods whose requests can be sent again safely.
+var idempotentMethods = map[string]bool{
+	http.MethodGet:     true,
+	http.MethodHead:    true,
+	http.MethodOptions: true,
+	http.MethodPut:     true,
+	http.MethodDelete:  true,
+}
+
+// RoundTrip sends the request, retrying rate-limited and 5xx responses of
+// idempotent requests.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		if attempt > 0 && req.Body != nil {
+			// The previous attempt consumed the body
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req = req.Clone(req.Context())
+			req.Body = body
+		}
+
+		resp, err := t.base.RoundTrip(req)
+		recordRequest(resp, err)
+		if req.Context().Err() != nil {
+			return resp, err
+		}
+
+		wait, retry := t.retryDelay(resp, err, attempt)
+		if wait > t.maxWait {
+			// Fail fast rather than stall for a rate limit that resets much later
+			logger.Info("GitHub request %s %s would wait %s for the rate limit, more than %s; not waiting",
+				req.Method, req.URL.Path, wait.Round(time.Second), t.maxWait)
+			return resp, err
+		}
+		if !retry {
+			if wait > 0 {
+				return t.holdUntilReset(req, resp, wait)
+			}
+			return resp, err
+		}
+		// Non-idempotent requests, and requests with a body that cannot be replayed, are not retried
+		if attempt >= t.maxRetries || !idempotentMethods[req.Method] || (req.Body != nil && req.GetBody == nil) {
+			return resp, err
+		}
+
+		if err != nil {
+			logger.Debug("GitHub request %s %s failed, retrying in %s: %v", req.Method, req.URL.Path, wait, err)
+		} else {
+			logger.Info("GitHub request %s %s returned %d, retrying in %s (attempt %d/%d)",
+				req.Method, req.URL.Path, resp.StatusCode, wait.Round(time.Millisecond), attempt+1, t.maxRetries)
+			resp.Body.Close()
+		}
+
+		if err := sleepContext(req, wait); err != nil {
+			return nil, err
+		}
+	}
+}
+
+// holdUntilReset returns resp after waiting for the rate limit to reset. It is used
+// when the last request of the rate limit window succeeded, so the next call does
+// not fail before reaching the API. The body is read first so the connection is
+// not held open while waiting.
+func (t *retryTransport) holdUntilReset(req *http.Request, resp *http.Response, wait time.Duration) (*http.Response, error) {
+	body, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+
+	logger.Info("GitHub rate limit exhausted, waiting %s for it to reset", wait.Round(time.Second))
+	if err := sleepContext(req, wait); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+// retryDelay decides whether a response should be retried and how long to wait first.
+// For a successful response that used up the rate limit it returns the time until
+// the reset with retry false.
+func (t *retryTransport) retryDelay(resp *http.Response, err error, attempt int) (time.Duration, bool) {
+	backoff := t.baseDelay << attempt
+
+	if err != nil {
+		return backoff, true
+	}
+
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter, convErr := strconv.Atoi(resp.Header.Get("Retry-After")); convErr == nil {
+			// Secondary rate limit
+			return time.Duration(retryAfter)*time.Second + t.rateLimitBuffer, true
+		}
+		if wait, ok := t.untilReset(resp); ok {
+			return wait, true
+		}
+		return 0, false
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		return backoff, true
+	}
+
+	if wait, ok := t.untilReset(resp); ok {
+		return wait, false
+	}
+	return 0, false
+}
+
+// untilReset returns how long to wait for the rate limit to reset when the
+// response reports no remaining requests.
+func (t *retryTransport) untilReset(resp *http.Response) (time.Duration, bool) {
+	if resp.Header.Get("X-RateLimit-Remaining") != "0" {
+		return 0, false
+	}
+	reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	wait := time.Until(time.Unix(reset, 0)) + t.rateLimitBuffer
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, true
+}
+
+// recordRequest counts a GitHub API request attempt by status code.
+func recordRequest(resp *http.Response, err error) {
+	if err != nil || resp == nil {
+		metrics.GitHubRequests.WithLabelValues("error").Inc()
+		return
+	}
+	metrics.GitHubRequests.WithLabelValues(strconv.Itoa(resp.StatusCode)).Inc()
+}
+
+// sleepContext waits for d or until the request's context is done.
+func sleepContext(req *http.Request, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-req.Context().Done():
+		return req.Context().Err()
+	}
+}
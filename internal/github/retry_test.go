@@ -0,0 +1,85 @@
+package github
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+// roundTripFunc is an http.RoundTripper backed by a function.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// responder returns a base transport that answers every request with status and
+// header, and counts the requests in calls.
+func responder(calls *int, status int, header http.Header) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		*calls++
+		return &http.Response{
+			StatusCode: status,
+			Header:     header.Clone(),
+			Body:       io.NopCloser(strings.NewReader("{}")),
+			Request:    req,
+		}, nil
+	})
+}
+
+func TestRetryTransport(t *testing.T) {
+	resetLater := http.Header{
+		"X-Ratelimit-Remaining": []string{"0"},
+		"X-Ratelimit-Reset":     []string{strconv.FormatInt(time.Now().Add(time.Hour).Unix(), 10)},
+	}
+
+	tests := []struct {
+		name      string
+		method    string
+		status    int
+		header    http.Header
+		wantCalls int
+	}{
+		{name: "GET 5xx is retried", method: http.MethodGet, status: http.StatusBadGateway, wantCalls: 3},
+		{name: "POST 5xx is not retried", method: http.MethodPost, status: http.StatusBadGateway, wantCalls: 1},
+		{name: "PATCH 5xx is not retried", method: http.MethodPatch, status: http.StatusBadGateway, wantCalls: 1},
+		{name: "rate limit reset past the cap fails fast", method: http.MethodGet, status: http.StatusForbidden, header: resetLater, wantCalls: 1},
+		{name: "exhausted rate limit past the cap is not waited for", method: http.MethodGet, status: http.StatusOK, header: resetLater, wantCalls: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			transport := newRetryTransport(responder(&calls, tt.status, tt.header), ClientOptions{
+				MaxRetries:     2,
+				RetryBaseDelay: time.Millisecond,
+				MaxRetryWait:   time.Minute,
+			})
+
+			req, err := http.NewRequest(tt.method, "https://api.github.com/repos/owner/repo", nil)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			start := time.Now()
+			resp, err := transport.RoundTrip(req)
+			if err != nil {
+				t.Fatalf("RoundTrip() error = %v", err)
+			}
+			resp.Body.Close()
+
+			if resp.StatusCode != tt.status {
+				t.Errorf("status = %d, want %d", resp.StatusCode, tt.status)
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("sent %d requests, want %d", calls, tt.wantCalls)
+			}
+			if elapsed := time.Since(start); elapsed > 5*time.Second {
+				t.Errorf("RoundTrip() took %s, want it to fail fast", elapsed)
+			}
+		})
+	}
+}
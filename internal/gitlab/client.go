@@ -8,42 +8,162 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/shay23bra/pr-bot/internal/github"
 	"github.com/shay23bra/pr-bot/internal/logger"
 	"github.com/shay23bra/pr-bot/internal/models"
+	"github.com/shay23bra/pr-bot/internal/proxy"
 	gitlab "gitlab.com/gitlab-org/api/client-go"
 	"gopkg.in/yaml.v2"
 )
 
+// Default HTTP settings for the GitLab client.
+const (
+	DefaultRequestTimeout  = 30 * time.Second
+	DefaultMaxIdleConns    = 10
+	DefaultIdleConnTimeout = 90 * time.Second
+)
+
+// DefaultBaseURL is the GitLab server used when PR_BOT_GITLAB_BASE_URL is not set.
+const DefaultBaseURL = "https://gitlab.cee.redhat.com"
+
+// DefaultSnapshotProject is the GitLab project holding MCE snapshots when
+// PR_BOT_GITLAB_PROJECT_ID is not set.
+const DefaultSnapshotProject = "acm-cicd/mce-bb2"
+
+// saasBadgeTTL is how long a computed SaaS badge is reused before deployments.yaml is read again.
+const saasBadgeTTL = 1 * time.Hour
+
 // Client wraps the GitLab API client.
 type Client struct {
-	client       *gitlab.Client
-	githubClient *github.Client
-	ctx          context.Context
+	client           *gitlab.Client
+	githubClient     *github.Client
+	ctx              context.Context
+	snapshotProjects map[string]string
+	defaultProject   string // Snapshot project for products without an entry in snapshotProjects
+	versionMappings  []models.VersionMapping
+
+	saasBadgeMu    sync.Mutex
+	saasBadgeCache map[string]saasBadgeEntry // keyed by released version
+}
+
+// saasBadgeEntry is a cached GetSaaSVersionBadge result.
+type saasBadgeEntry struct {
+	badge     string
+	fetchedAt time.Time
+}
+
+// ClientOptions configures the HTTP transport used by the GitLab client.
+// Zero values fall back to the package defaults.
+type ClientOptions struct {
+	MaxIdleConns    int
+	IdleConnTimeout time.Duration
+	// SnapshotProjects maps a product name (e.g., "MCE") to the GitLab project holding its snapshots.
+	SnapshotProjects map[string]string
+	Proxy            proxy.Settings
+	// BaseURL is the GitLab server, e.g. https://gitlab.example.com; empty uses DefaultBaseURL.
+	BaseURL string
+	// ProjectID is the snapshot project of products missing from SnapshotProjects; empty uses DefaultSnapshotProject.
+	ProjectID string
+	// VersionMappings maps ACM to MCE minor versions; empty uses models.DefaultVersionMappings.
+	VersionMappings []models.VersionMapping
+}
+
+// OptionsFromConfig builds ClientOptions from the application configuration.
+func OptionsFromConfig(cfg *models.Config) ClientOptions {
+	return ClientOptions{
+		MaxIdleConns:     cfg.GitLabMaxIdleConns,
+		IdleConnTimeout:  cfg.GitLabIdleConnTimeout,
+		SnapshotProjects: cfg.SnapshotProjects,
+		Proxy:            proxy.FromConfig(cfg),
+		BaseURL:          cfg.GitLabBaseURL,
+		ProjectID:        cfg.GitLabProjectID,
+		VersionMappings:  cfg.VersionMappingTable(),
+	}
 }
 
 // NewClient creates a new GitLab client.
-func NewClient(ctx context.Context, token string, githubClient *github.Client) *Client {
-	// Create HTTP client with TLS skip verification for internal GitLab server
+func NewClient(ctx context.Context, token string, githubClient *github.Client, opts ...ClientOptions) *Client {
+	var options ClientOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+	if options.MaxIdleConns <= 0 {
+		options.MaxIdleConns = DefaultMaxIdleConns
+	}
+	if options.IdleConnTimeout <= 0 {
+		options.IdleConnTimeout = DefaultIdleConnTimeout
+	}
+	if options.BaseURL == "" {
+		options.BaseURL = DefaultBaseURL
+	}
+	if options.ProjectID == "" {
+		options.ProjectID = DefaultSnapshotProject
+	}
+
+	// Create HTTP client with TLS skip verification for internal GitLab server.
+	// Idle connections are pooled so concurrent MCE validations reuse them.
+	transport := &http.Transport{
+		TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+		MaxIdleConns:      options.MaxIdleConns,
+		IdleConnTimeout:   options.IdleConnTimeout,
+		DisableKeepAlives: false,
+	}
+	options.Proxy.Apply(transport)
+
 	httpClient := &http.Client{
-		Transport: &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		},
+		Timeout:   DefaultRequestTimeout,
+		Transport: transport,
 	}
 
 	client, _ := gitlab.NewClient(token,
-		gitlab.WithBaseURL("https://gitlab.cee.redhat.com"),
+		gitlab.WithBaseURL(options.BaseURL),
 		gitlab.WithHTTPClient(httpClient))
 
 	return &Client{
-		client:       client,
-		githubClient: githubClient,
-		ctx:          ctx,
+		client:           client,
+		githubClient:     githubClient,
+		ctx:              ctx,
+		snapshotProjects: options.SnapshotProjects,
+		defaultProject:   options.ProjectID,
+		versionMappings:  options.VersionMappings,
+		saasBadgeCache:   make(map[string]saasBadgeEntry),
+	}
+}
+
+// defaultProjectProducts are the products whose snapshots are in the default
+// snapshot project unless PR_BOT_SNAPSHOT_PROJECTS says otherwise.
+var defaultProjectProducts = map[string]bool{"ACM": true, "MCE": true}
+
+// ProjectForProduct returns the GitLab project holding snapshots for a product.
+// ACM and MCE without a configured project use PR_BOT_GITLAB_PROJECT_ID, or
+// DefaultSnapshotProject. Other products must be listed in PR_BOT_SNAPSHOT_PROJECTS.
+func (c *Client) ProjectForProduct(product string) (string, error) {
+	if projectID, ok := c.snapshotProjects[product]; ok && projectID != "" {
+		return projectID, nil
 	}
+	if projectID, ok := c.snapshotProjects[strings.ToUpper(product)]; ok && projectID != "" {
+		return projectID, nil
+	}
+	if !defaultProjectProducts[strings.ToUpper(product)] {
+		return "", fmt.Errorf("no GitLab snapshot project for product %q: add it to PR_BOT_SNAPSHOT_PROJECTS", product)
+	}
+	if c.defaultProject != "" {
+		return c.defaultProject, nil
+	}
+	return DefaultSnapshotProject, nil
+}
+
+// mceProject returns the GitLab project holding MCE snapshots, which always has one.
+func (c *Client) mceProject() string {
+	projectID, _ := c.ProjectForProduct("MCE")
+	return projectID
 }
 
 // BuildStatus represents the structure of build-status.yaml
@@ -53,16 +173,33 @@ type BuildStatus struct {
 	} `yaml:"announce"`
 }
 
+// SnapshotReader reads component SHAs and versions from the MCE snapshots project.
+// *Client implements it.
+type SnapshotReader interface {
+	ProjectForProduct(product string) (string, error)
+	FindLatestSnapshot(projectID, mceBranch string) (string, error)
+	FindSnapshotClosestToDate(projectID, mceBranch string, targetDate time.Time) (string, error)
+	GetVersionFromSnapshot(mceBranch, snapshotFolder string) (string, error)
+	ExtractComponentSHA(mceBranch, snapshotFolder, componentName string) (string, error)
+}
+
+var _ SnapshotReader = (*Client)(nil)
+
 // DownSHA represents the structure of down-sha.yaml
 // Using a flexible approach to handle different YAML structures
 type DownSHA map[string]interface{}
 
 // ValidateMCESnapshot performs the complete MCE snapshot validation process.
 func (c *Client) ValidateMCESnapshot(product, version string, gaDate *time.Time, prCommitSHA string) (*models.MCESnapshotValidation, error) {
-	return c.ValidateMCESnapshotForComponent(product, version, gaDate, prCommitSHA, "assisted-service")
+	projectID, err := c.ProjectForProduct(product)
+	if err != nil {
+		return nil, err
+	}
+	return c.ValidateMCESnapshotForComponent(projectID, product, version, gaDate, prCommitSHA, "assisted-service")
 }
 
-func (c *Client) ValidateMCESnapshotForComponent(product, version string, gaDate *time.Time, prCommitSHA, componentName string) (*models.MCESnapshotValidation, error) {
+// ValidateMCESnapshotForComponent validates a component against the snapshots stored in the GitLab project projectID.
+func (c *Client) ValidateMCESnapshotForComponent(projectID, product, version string, gaDate *time.Time, prCommitSHA, componentName string) (*models.MCESnapshotValidation, error) {
 	if gaDate == nil {
 		return nil, fmt.Errorf("GA date is required for validation")
 	}
@@ -85,7 +222,7 @@ func (c *Client) ValidateMCESnapshotForComponent(product, version string, gaDate
 	result.MCEBranch = mceBranch
 
 	// Find appropriate snapshot folder
-	snapshotFolder, err := c.findSnapshotFolder(mceBranch, *gaDate)
+	snapshotFolder, err := c.findSnapshotBefore(projectID, mceBranch, *gaDate)
 	if err != nil {
 		result.ErrorMessage = fmt.Sprintf("Failed to find snapshot folder: %v", err)
 		return result, nil
@@ -102,7 +239,7 @@ func (c *Client) ValidateMCESnapshotForComponent(product, version string, gaDate
 	}
 
 	// Validate version in build-status.yaml
-	valid, err := c.validateVersionInBuildStatus(mceBranch, snapshotFolder, versionToValidate)
+	valid, err := c.validateVersionInBuildStatus(projectID, mceBranch, snapshotFolder, versionToValidate)
 	if err != nil {
 		result.ErrorMessage = fmt.Sprintf("Failed to validate build status: %v", err)
 		return result, nil
@@ -113,7 +250,7 @@ func (c *Client) ValidateMCESnapshotForComponent(product, version string, gaDate
 	}
 
 	// Extract component SHA from down-sha.yaml
-	componentSHA, err := c.ExtractComponentSHA(mceBranch, snapshotFolder, componentName)
+	componentSHA, err := c.extractComponentSHA(projectID, mceBranch, snapshotFolder, componentName)
 	if err != nil {
 		result.ErrorMessage = fmt.Sprintf("Failed to extract %s SHA: %v", componentName, err)
 		return result, nil
@@ -138,7 +275,7 @@ func (c *Client) calculateMCEBranch(product, version string) (string, error) {
 		}
 		return fmt.Sprintf("mce-%s.%s", parts[0], parts[1]), nil
 	} else if product == "ACM" {
-		// For ACM versions, calculate MCE equivalent (minor - 5)
+		// For ACM versions, look up the MCE equivalent in the version mappings
 		parts := strings.Split(version, ".")
 		if len(parts) < 2 {
 			return "", fmt.Errorf("invalid ACM version format: %s", version)
@@ -154,7 +291,7 @@ func (c *Client) calculateMCEBranch(product, version string) (string, error) {
 			return "", fmt.Errorf("invalid minor version in ACM version %s: %v", version, err)
 		}
 
-		mceMinor := minor - 5
+		mceMinor := models.MCEMinorForACM(c.versionMappings, minor)
 		if mceMinor < 0 {
 			return "", fmt.Errorf("calculated MCE minor version is negative for ACM %s", version)
 		}
@@ -165,91 +302,13 @@ func (c *Client) calculateMCEBranch(product, version string) (string, error) {
 	return "", fmt.Errorf("unsupported product: %s", product)
 }
 
-// findSnapshotFolder finds the appropriate snapshot folder before the GA date.
-func (c *Client) findSnapshotFolder(mceBranch string, gaDate time.Time) (string, error) {
-	logger.Debug("Looking for snapshot folders in branch %s before %s", mceBranch, gaDate.Format("2006-01-02"))
-
-	// List files in the snapshots directory
-	projectID := "acm-cicd/mce-bb2"
-	path := "snapshots"
-
-	opts := &gitlab.ListTreeOptions{
-		Path:      &path,
-		Ref:       &mceBranch,
-		Recursive: gitlab.Ptr(false),
-	}
-
-	tree, resp, err := c.client.Repositories.ListTree(projectID, opts)
-	if err != nil {
-		return "", fmt.Errorf("failed to list snapshots directory: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to list snapshots directory, status: %d", resp.StatusCode)
-	}
-
-	// Find folders with date format YYYY-MM-DD-HH-MM-SS
-	var candidateFolders []string
-	for _, item := range tree {
-		if item.Type == "tree" && len(item.Name) >= 19 { // YYYY-MM-DD-HH-MM-SS is 19 chars
-			// Parse the date part (first 10 characters)
-			dateStr := item.Name[:10]
-			folderDate, err := time.Parse("2006-01-02", dateStr)
-			if err != nil {
-				continue // Skip folders that don't match date format
-			}
-
-			// Only consider folders before the GA date
-			if folderDate.Before(gaDate) {
-				candidateFolders = append(candidateFolders, item.Name)
-			}
-		}
-	}
-
-	if len(candidateFolders) == 0 {
-		return "", fmt.Errorf("no snapshot folders found before GA date %s", gaDate.Format("2006-01-02"))
-	}
-
-	// Find the latest folder (closest to GA date)
-	var latestFolder string
-	for _, folder := range candidateFolders {
-		if latestFolder == "" || folder > latestFolder {
-			latestFolder = folder
-		}
-	}
-
-	logger.Debug("Selected snapshot folder: %s", latestFolder)
-	return latestFolder, nil
-}
-
 // validateVersionInBuildStatus checks if the version matches in build-status.yaml.
-func (c *Client) validateVersionInBuildStatus(mceBranch, snapshotFolder, expectedVersion string) (bool, error) {
+func (c *Client) validateVersionInBuildStatus(projectID, mceBranch, snapshotFolder, expectedVersion string) (bool, error) {
 	logger.Debug("Validating version %s in build-status.yaml", expectedVersion)
 
-	projectID := "acm-cicd/mce-bb2"
-	filePath := fmt.Sprintf("snapshots/%s/build-status.yaml", snapshotFolder)
-
-	file, resp, err := c.client.RepositoryFiles.GetFile(projectID, filePath, &gitlab.GetFileOptions{
-		Ref: &mceBranch,
-	})
-	if err != nil {
-		return false, fmt.Errorf("failed to get build-status.yaml: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return false, fmt.Errorf("failed to get build-status.yaml, status: %d", resp.StatusCode)
-	}
-
-	// Decode the file content
-	content, err := base64.StdEncoding.DecodeString(file.Content)
+	buildStatus, err := c.readBuildStatus(projectID, mceBranch, snapshotFolder)
 	if err != nil {
-		return false, fmt.Errorf("failed to decode build-status.yaml: %w", err)
-	}
-
-	// Parse YAML
-	var buildStatus BuildStatus
-	if err := yaml.Unmarshal(content, &buildStatus); err != nil {
-		return false, fmt.Errorf("failed to parse build-status.yaml: %w", err)
+		return false, err
 	}
 
 	// Check if version matches
@@ -261,15 +320,20 @@ func (c *Client) validateVersionInBuildStatus(mceBranch, snapshotFolder, expecte
 
 // ExtractComponentSHA extracts the SHA for a specific component from down-sha.yaml.
 func (c *Client) ExtractComponentSHA(mceBranch, snapshotFolder, componentName string) (string, error) {
+	return c.extractComponentSHA(c.mceProject(), mceBranch, snapshotFolder, componentName)
+}
+
+// extractComponentSHA extracts a component SHA from a snapshot in the given GitLab project.
+func (c *Client) extractComponentSHA(projectID, mceBranch, snapshotFolder, componentName string) (string, error) {
 	logger.Debug("Extracting %s SHA from down-sha.yaml", componentName)
 
 	// Special handling for assisted-installer-ui
 	if componentName == "assisted-installer-ui" {
-		return c.extractAssistedInstallerUIVersion(mceBranch, snapshotFolder)
+		return c.extractAssistedInstallerUIVersion(projectID, mceBranch, snapshotFolder)
 	}
 
 	// Try to extract SHA from the specified snapshot folder first
-	sha, err := c.extractComponentSHAFromSnapshot(mceBranch, snapshotFolder, componentName)
+	sha, err := c.extractComponentSHAFromSnapshot(projectID, mceBranch, snapshotFolder, componentName)
 	if err == nil {
 		return sha, nil
 	}
@@ -278,12 +342,11 @@ func (c *Client) ExtractComponentSHA(mceBranch, snapshotFolder, componentName st
 	logger.Debug("Trying fallback to previous snapshots...")
 
 	// Fallback: try previous snapshot folders with the same version
-	return c.extractComponentSHAWithFallback(mceBranch, snapshotFolder, componentName)
+	return c.extractComponentSHAWithFallback(projectID, mceBranch, snapshotFolder, componentName)
 }
 
 // extractComponentSHAFromSnapshot extracts SHA from a specific snapshot folder.
-func (c *Client) extractComponentSHAFromSnapshot(mceBranch, snapshotFolder, componentName string) (string, error) {
-	projectID := "acm-cicd/mce-bb2"
+func (c *Client) extractComponentSHAFromSnapshot(projectID, mceBranch, snapshotFolder, componentName string) (string, error) {
 	filePath := fmt.Sprintf("snapshots/%s/down-sha.yaml", snapshotFolder)
 
 	file, resp, err := c.client.RepositoryFiles.GetFile(projectID, filePath, &gitlab.GetFileOptions{
@@ -442,41 +505,35 @@ func (c *Client) extractComponentSHAFromSnapshot(mceBranch, snapshotFolder, comp
 }
 
 // extractComponentSHAWithFallback tries to find the SHA from previous snapshots with the same version.
-func (c *Client) extractComponentSHAWithFallback(mceBranch, originalSnapshot, componentName string) (string, error) {
+func (c *Client) extractComponentSHAWithFallback(projectID, mceBranch, originalSnapshot, componentName string) (string, error) {
 	// First, get the expected version from the original snapshot's build-status.yaml
-	expectedVersion, err := c.GetVersionFromSnapshot(mceBranch, originalSnapshot)
+	expectedVersion, err := c.getVersionFromSnapshot(projectID, mceBranch, originalSnapshot)
 	if err != nil {
 		return "", fmt.Errorf("failed to get expected version from original snapshot: %v", err)
 	}
 
 	logger.Debug("Looking for snapshots with version %s", expectedVersion)
 
-	// Get all available snapshot folders
-	snapshots, err := c.getAllSnapshotFolders(mceBranch)
+	// Get all available snapshot folders, newest first
+	snapshots, err := c.listSnapshots(projectID, mceBranch)
 	if err != nil {
 		return "", fmt.Errorf("failed to get snapshot folders: %v", err)
 	}
 
-	// Sort snapshots in reverse chronological order (newest first, excluding the original)
+	// Only snapshots taken before the original one are candidates
 	var candidateSnapshots []string
 	for _, snapshot := range snapshots {
-		if snapshot != originalSnapshot && snapshot < originalSnapshot {
-			candidateSnapshots = append(candidateSnapshots, snapshot)
+		if snapshot.FolderName < originalSnapshot {
+			candidateSnapshots = append(candidateSnapshots, snapshot.FolderName)
 		}
 	}
 
-	// Sort in reverse order (newest first)
-	for i := 0; i < len(candidateSnapshots)/2; i++ {
-		j := len(candidateSnapshots) - 1 - i
-		candidateSnapshots[i], candidateSnapshots[j] = candidateSnapshots[j], candidateSnapshots[i]
-	}
-
 	// Try each candidate snapshot
 	for _, candidateSnapshot := range candidateSnapshots {
 		logger.Debug("Trying snapshot %s", candidateSnapshot)
 
 		// Check if this snapshot has the same version
-		version, err := c.GetVersionFromSnapshot(mceBranch, candidateSnapshot)
+		version, err := c.getVersionFromSnapshot(projectID, mceBranch, candidateSnapshot)
 		if err != nil {
 			logger.Debug("Failed to get version from snapshot %s: %v", candidateSnapshot, err)
 			continue
@@ -490,7 +547,7 @@ func (c *Client) extractComponentSHAWithFallback(mceBranch, originalSnapshot, co
 		logger.Debug("Snapshot %s has matching version %s", candidateSnapshot, version)
 
 		// Try to extract SHA from this snapshot
-		sha, err := c.extractComponentSHAFromSnapshot(mceBranch, candidateSnapshot, componentName)
+		sha, err := c.extractComponentSHAFromSnapshot(projectID, mceBranch, candidateSnapshot, componentName)
 		if err != nil {
 			logger.Debug("Failed to extract SHA from snapshot %s: %v", candidateSnapshot, err)
 			continue
@@ -505,70 +562,61 @@ func (c *Client) extractComponentSHAWithFallback(mceBranch, originalSnapshot, co
 
 // GetVersionFromSnapshot gets the version from build-status.yaml in a snapshot.
 func (c *Client) GetVersionFromSnapshot(mceBranch, snapshotFolder string) (string, error) {
-	projectID := "acm-cicd/mce-bb2"
+	return c.getVersionFromSnapshot(c.mceProject(), mceBranch, snapshotFolder)
+}
+
+// getVersionFromSnapshot reads the announced version from build-status.yaml of a
+// snapshot in the given GitLab project.
+func (c *Client) getVersionFromSnapshot(projectID, mceBranch, snapshotFolder string) (string, error) {
+	buildStatus, err := c.readBuildStatus(projectID, mceBranch, snapshotFolder)
+	if err != nil {
+		return "", err
+	}
+	if buildStatus.Announce.Version == "" {
+		return "", fmt.Errorf("build-status.yaml in snapshot %s has no announce.version", snapshotFolder)
+	}
+	return buildStatus.Announce.Version, nil
+}
+
+// readBuildStatus fetches and parses build-status.yaml of a snapshot.
+func (c *Client) readBuildStatus(projectID, mceBranch, snapshotFolder string) (*BuildStatus, error) {
 	filePath := fmt.Sprintf("snapshots/%s/build-status.yaml", snapshotFolder)
 
 	file, resp, err := c.client.RepositoryFiles.GetFile(projectID, filePath, &gitlab.GetFileOptions{
 		Ref: &mceBranch,
 	})
 	if err != nil {
-		return "", fmt.Errorf("failed to get build-status.yaml: %w", err)
+		return nil, fmt.Errorf("failed to get build-status.yaml: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to get build-status.yaml, status: %d", resp.StatusCode)
-	}
-
-	// Decode and parse
-	content, err := base64.StdEncoding.DecodeString(file.Content)
-	if err != nil {
-		return "", fmt.Errorf("failed to decode build-status.yaml: %w", err)
-	}
-
-	var buildStatus BuildStatus
-	if err := yaml.Unmarshal(content, &buildStatus); err != nil {
-		return "", fmt.Errorf("failed to parse build-status.yaml: %w", err)
+		return nil, fmt.Errorf("failed to get build-status.yaml, status: %d", resp.StatusCode)
 	}
 
-	return buildStatus.Announce.Version, nil
+	return parseBuildStatus(file.Content)
 }
 
-// getAllSnapshotFolders gets all snapshot folder names in a branch.
-func (c *Client) getAllSnapshotFolders(mceBranch string) ([]string, error) {
-	projectID := "acm-cicd/mce-bb2"
-	path := "snapshots"
-
-	opts := &gitlab.ListTreeOptions{
-		Path:      &path,
-		Ref:       &mceBranch,
-		Recursive: gitlab.Ptr(false),
-	}
-
-	tree, resp, err := c.client.Repositories.ListTree(projectID, opts)
+// parseBuildStatus decodes the base64 content of build-status.yaml, as returned by
+// the GitLab files API, and parses it.
+func parseBuildStatus(encoded string) (*BuildStatus, error) {
+	content, err := base64.StdEncoding.DecodeString(encoded)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list snapshots directory: %w", err)
-	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("failed to list snapshots directory, status: %d", resp.StatusCode)
+		return nil, fmt.Errorf("failed to decode build-status.yaml: %w", err)
 	}
 
-	var folders []string
-	for _, item := range tree {
-		if item.Type == "tree" {
-			folders = append(folders, item.Name)
-		}
+	var buildStatus BuildStatus
+	if err := yaml.Unmarshal(content, &buildStatus); err != nil {
+		return nil, fmt.Errorf("failed to parse build-status.yaml: %w", err)
 	}
-
-	return folders, nil
+	return &buildStatus, nil
 }
 
 // extractAssistedInstallerUIVersion extracts the assisted-installer-ui version through stolostron/console
-func (c *Client) extractAssistedInstallerUIVersion(mceBranch, snapshotFolder string) (string, error) {
+func (c *Client) extractAssistedInstallerUIVersion(projectID, mceBranch, snapshotFolder string) (string, error) {
 	logger.Debug("Extracting assisted-installer-ui version via stolostron/console")
 
 	// First, get the stolostron/console SHA from down-sha.yaml
-	consoleSHA, err := c.extractStolostronConsoleSHA(mceBranch, snapshotFolder)
+	consoleSHA, err := c.extractStolostronConsoleSHA(projectID, mceBranch, snapshotFolder)
 	if err != nil {
 		return "", fmt.Errorf("failed to extract stolostron/console SHA: %v", err)
 	}
@@ -591,9 +639,8 @@ func (c *Client) extractAssistedInstallerUIVersion(mceBranch, snapshotFolder str
 }
 
 // extractStolostronConsoleSHA extracts the SHA for stolostron/console from down-sha.yaml
-func (c *Client) extractStolostronConsoleSHA(mceBranch, snapshotFolder string) (string, error) {
+func (c *Client) extractStolostronConsoleSHA(projectID, mceBranch, snapshotFolder string) (string, error) {
 	// Get the down-sha.yaml content
-	projectID := "acm-cicd/mce-bb2"
 	filePath := fmt.Sprintf("snapshots/%s/down-sha.yaml", snapshotFolder)
 
 	file, resp, err := c.client.RepositoryFiles.GetFile(projectID, filePath, &gitlab.GetFileOptions{
@@ -691,7 +738,7 @@ func (c *Client) ExtractAssistedServiceSHA(mceBranch, snapshotFolder string) (st
 }
 
 // convertACMToMCEVersion converts an ACM version to its corresponding MCE version.
-// ACM minor version - 5 = MCE minor version (e.g., ACM 2.13.1 -> MCE 2.8.1)
+// The MCE minor version comes from the version mappings (e.g., ACM 2.13.1 -> MCE 2.8.1).
 func (c *Client) convertACMToMCEVersion(acmVersion string) (string, error) {
 	// Parse version format: X.Y.Z
 	parts := strings.Split(acmVersion, ".")
@@ -706,8 +753,8 @@ func (c *Client) convertACMToMCEVersion(acmVersion string) (string, error) {
 	}
 	patch := parts[2]
 
-	// Convert ACM minor to MCE minor (subtract 5)
-	mceMinor := minor - 5
+	// Convert ACM minor to MCE minor
+	mceMinor := models.MCEMinorForACM(c.versionMappings, minor)
 	if mceMinor < 0 {
 		return "", fmt.Errorf("invalid conversion: ACM minor %d would result in negative MCE minor", minor)
 	}
@@ -718,45 +765,40 @@ func (c *Client) convertACMToMCEVersion(acmVersion string) (string, error) {
 	return mceVersion, nil
 }
 
-// FindLatestSnapshot finds the latest snapshot folder in the given MCE branch.
-func (c *Client) FindLatestSnapshot(mceBranch string) (string, error) {
-	logger.Debug("Finding latest snapshot in branch %s", mceBranch)
-
-	projectID := "acm-cicd/mce-bb2"
-	path := "snapshots"
-
-	opts := &gitlab.ListTreeOptions{
-		Path:      &path,
-		Ref:       &mceBranch,
-		Recursive: gitlab.Ptr(false),
-	}
+// mceBranchPattern matches snapshot branches such as "mce-2.8".
+var mceBranchPattern = regexp.MustCompile(`^mce-\d+\.\d+$`)
 
-	tree, resp, err := c.client.Repositories.ListTree(projectID, opts)
-	if err != nil {
-		return "", fmt.Errorf("failed to list snapshots directory: %w", err)
+// ListMCEBranches returns the mce-X.Y branches of the snapshot project projectID, newest first.
+func (c *Client) ListMCEBranches(projectID string) ([]string, error) {
+	opts := &gitlab.ListBranchesOptions{
+		ListOptions: gitlab.ListOptions{PerPage: 100},
+		Search:      gitlab.Ptr("mce-"),
 	}
 
-	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("failed to list snapshots directory, status: %d", resp.StatusCode)
-	}
+	var branches []string
+	for {
+		page, resp, err := c.client.Branches.ListBranches(projectID, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list MCE branches: %w", err)
+		}
 
-	// Find all directory entries and get the latest one (by name sorting)
-	var latestFolder string
-	for _, item := range tree {
-		if item.Type == "tree" { // Directory
-			// Snapshot folders are typically named with timestamps like "2025-03-14-18-55-26"
-			if latestFolder == "" || item.Name > latestFolder {
-				latestFolder = item.Name
+		for _, branch := range page {
+			if mceBranchPattern.MatchString(branch.Name) {
+				branches = append(branches, branch.Name)
 			}
 		}
-	}
 
-	if latestFolder == "" {
-		return "", fmt.Errorf("no snapshot folders found in %s branch", mceBranch)
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
 	}
 
-	logger.Debug("Found latest snapshot folder: %s", latestFolder)
-	return latestFolder, nil
+	sort.Slice(branches, func(i, j int) bool {
+		return CompareVersions(strings.TrimPrefix(branches[i], "mce-"), strings.TrimPrefix(branches[j], "mce-")) > 0
+	})
+
+	return branches, nil
 }
 
 // Deployment represents a deployment entry in deployments.yaml
@@ -854,15 +896,33 @@ func CompareVersions(v1, v2 string) int {
 	return 0
 }
 
-// GetSaaSVersionBadge returns the badge text for a SaaS version based on deployments.yaml
-func (c *Client) GetSaaSVersionBadge(releasedVersion string) string {
+// GetSaaSVersionBadge returns the badge text for a SaaS version based on deployments.yaml.
+// Results are cached per version for saasBadgeTTL; failures are not cached, so the
+// next call retries.
+func (c *Client) GetSaaSVersionBadge(releasedVersion string) (string, error) {
+	c.saasBadgeMu.Lock()
+	entry, ok := c.saasBadgeCache[releasedVersion]
+	c.saasBadgeMu.Unlock()
+	if ok && time.Since(entry.fetchedAt) < saasBadgeTTL {
+		return entry.badge, nil
+	}
+
 	productionVersion, stageVersion, err := c.GetDeploymentsVersions()
 	if err != nil {
-		logger.Debug("Failed to get deployments versions: %v", err)
-		// Return empty string on error - badge won't be shown
-		return ""
+		return "", fmt.Errorf("failed to get SaaS deployment versions: %w", err)
 	}
 
+	badge := saasBadgeFor(releasedVersion, productionVersion, stageVersion)
+
+	c.saasBadgeMu.Lock()
+	c.saasBadgeCache[releasedVersion] = saasBadgeEntry{badge: badge, fetchedAt: time.Now()}
+	c.saasBadgeMu.Unlock()
+
+	return badge, nil
+}
+
+// saasBadgeFor picks the badge for a released version given the deployed production and stage versions.
+func saasBadgeFor(releasedVersion, productionVersion, stageVersion string) string {
 	logger.Debug("Deployments versions - Production: %s, Stage: %s, Released: %s", productionVersion, stageVersion, releasedVersion)
 
 	// Remove 'v' prefix from released version for comparison
@@ -885,3 +945,13 @@ func (c *Client) GetSaaSVersionBadge(releasedVersion string) string {
 	// If neither is bigger, it's not in production or staging yet
 	return " - not in production or staging yet"
 }
+
+// Ping checks that the MCE snapshot project is reachable with the client's token
+// by listing a single entry of its repository.
+func (c *Client) Ping(ctx context.Context) error {
+	opts := &gitlab.ListTreeOptions{ListOptions: gitlab.ListOptions{PerPage: 1}}
+	if _, _, err := c.client.Repositories.ListTree(c.mceProject(), opts, gitlab.WithContext(ctx)); err != nil {
+		return fmt.Errorf("failed to list snapshot project: %w", err)
+	}
+	return nil
+}
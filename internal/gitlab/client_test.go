@@ -0,0 +1,202 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a Client whose API requests are served by handler.
+func newTestClient(t *testing.T, handler http.Handler) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return NewClient(context.Background(), "token", nil, ClientOptions{BaseURL: server.URL})
+}
+
+// deploymentsHandler serves deployments.yaml with the given production and stage
+// versions and counts the requests in calls.
+func deploymentsHandler(calls *atomic.Int32, production, stage string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if !strings.HasSuffix(r.URL.Path, "/repository/files/deployments.yaml") {
+			http.NotFound(w, r)
+			return
+		}
+		content := fmt.Sprintf("deployments:\n- environment: production\n  version: %s\n- environment: stage\n  version: %s\n", production, stage)
+		fmt.Fprintf(w, `{"file_name": "deployments.yaml", "content": %q}`, encodeContent(content))
+	}
+}
+
+func TestGetSaaSVersionBadgeCachesPerVersion(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, deploymentsHandler(&calls, "2.40.0", "2.41.0"))
+
+	for i := 0; i < 2; i++ {
+		badge, err := client.GetSaaSVersionBadge("v2.41.0")
+		if err != nil {
+			t.Fatalf("GetSaaSVersionBadge() error = %v", err)
+		}
+		if badge != " - in staging" {
+			t.Errorf("badge = %q, want %q", badge, " - in staging")
+		}
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("deployments.yaml read %d times, want 1", got)
+	}
+
+	// Another version is not served from the first version's entry
+	if _, err := client.GetSaaSVersionBadge("v2.39.0"); err != nil {
+		t.Fatalf("GetSaaSVersionBadge() error = %v", err)
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("deployments.yaml read %d times, want 2", got)
+	}
+}
+
+func TestGetSaaSVersionBadgeExpires(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, deploymentsHandler(&calls, "2.41.0", "2.41.0"))
+
+	if _, err := client.GetSaaSVersionBadge("v2.41.0"); err != nil {
+		t.Fatalf("GetSaaSVersionBadge() error = %v", err)
+	}
+
+	// Age the cached entry past the TTL
+	client.saasBadgeMu.Lock()
+	entry := client.saasBadgeCache["v2.41.0"]
+	entry.fetchedAt = time.Now().Add(-saasBadgeTTL - time.Minute)
+	client.saasBadgeCache["v2.41.0"] = entry
+	client.saasBadgeMu.Unlock()
+
+	badge, err := client.GetSaaSVersionBadge("v2.41.0")
+	if err != nil {
+		t.Fatalf("GetSaaSVersionBadge() error = %v", err)
+	}
+	if badge != " - in production" {
+		t.Errorf("badge = %q, want %q", badge, " - in production")
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("deployments.yaml read %d times, want 2 after the TTL", got)
+	}
+}
+
+func TestGetSaaSVersionBadgeDoesNotCacheErrors(t *testing.T) {
+	var calls atomic.Int32
+	client := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		http.Error(w, `{"message": "404 File Not Found"}`, http.StatusNotFound)
+	}))
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetSaaSVersionBadge("v2.41.0"); err == nil {
+			t.Fatal("GetSaaSVersionBadge() error = nil, want an error")
+		}
+	}
+	if got := calls.Load(); got != 2 {
+		t.Errorf("deployments.yaml read %d times, want 2 since failures are not cached", got)
+	}
+	if _, ok := client.saasBadgeCache["v2.41.0"]; ok {
+		t.Error("failed lookup was cached")
+	}
+}
+
+// encodeContent encodes file content as the GitLab files API returns it.
+func encodeContent(content string) string {
+	return base64.StdEncoding.EncodeToString([]byte(content))
+}
+
+func TestParseBuildStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		encoded     string
+		wantVersion string
+		wantErr     bool
+	}{
+		{name: "valid", encoded: encodeContent("announce:\n  version: 2.8.2\n"), wantVersion: "2.8.2"},
+		{name: "missing announce.version", encoded: encodeContent("announce:\n  channel: stable\n")},
+		{name: "malformed YAML", encoded: encodeContent("announce: [version: 2.8.2\n"), wantErr: true},
+		{name: "invalid base64", encoded: "not base64!", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buildStatus, err := parseBuildStatus(tt.encoded)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseBuildStatus() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err == nil && buildStatus.Announce.Version != tt.wantVersion {
+				t.Errorf("announce.version = %q, want %q", buildStatus.Announce.Version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestSnapshotReaderGetVersionFromSnapshot(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		wantVersion string
+		wantErr     bool
+	}{
+		{name: "valid", content: encodeContent("announce:\n  version: 2.8.2\n"), wantVersion: "2.8.2"},
+		{name: "missing announce.version", content: encodeContent("announce:\n  channel: stable\n"), wantErr: true},
+		{name: "malformed YAML", content: encodeContent("announce: [version: 2.8.2\n"), wantErr: true},
+		{name: "invalid base64", content: "not base64!", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var reader SnapshotReader = newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if !strings.HasSuffix(r.URL.Path, "/repository/files/snapshots/2025-03-14-18-55-26/build-status.yaml") {
+					http.NotFound(w, r)
+					return
+				}
+				fmt.Fprintf(w, `{"file_name": "build-status.yaml", "content": %q}`, tt.content)
+			}))
+
+			version, err := reader.GetVersionFromSnapshot("mce-2.8", "2025-03-14-18-55-26")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetVersionFromSnapshot() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if version != tt.wantVersion {
+				t.Errorf("GetVersionFromSnapshot() = %q, want %q", version, tt.wantVersion)
+			}
+		})
+	}
+}
+
+func TestProjectForProduct(t *testing.T) {
+	client := NewClient(context.Background(), "token", nil, ClientOptions{
+		SnapshotProjects: map[string]string{"HyperShift": "hypershift/snapshots", "ACM": "acm/snapshots"},
+	})
+
+	tests := []struct {
+		product string
+		want    string
+		wantErr bool
+	}{
+		{product: "HyperShift", want: "hypershift/snapshots"},
+		{product: "acm", want: "acm/snapshots"},
+		{product: "MCE", want: DefaultSnapshotProject},
+		{product: "RHOAI", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.product, func(t *testing.T) {
+			got, err := client.ProjectForProduct(tt.product)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ProjectForProduct(%q) error = %v, wantErr %v", tt.product, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ProjectForProduct(%q) = %q, want %q", tt.product, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,194 @@
+package gitlab
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/shay23bra/pr-bot/internal/logger"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+	"gopkg.in/yaml.v2"
+)
+
+// ComponentDiffStatus classifies how a component changed between two snapshots.
+type ComponentDiffStatus string
+
+// Component diff statuses, in display order.
+const (
+	DiffChanged        ComponentDiffStatus = "Changed"
+	DiffAddedInNew     ComponentDiffStatus = "AddedInNew"
+	DiffRemovedFromNew ComponentDiffStatus = "RemovedFromNew"
+	DiffUnchanged      ComponentDiffStatus = "Unchanged"
+)
+
+var diffStatusOrder = map[ComponentDiffStatus]int{
+	DiffChanged:        0,
+	DiffAddedInNew:     1,
+	DiffRemovedFromNew: 2,
+	DiffUnchanged:      3,
+}
+
+// ComponentDiff describes one repository's SHA in two MCE snapshots.
+type ComponentDiff struct {
+	Component  string              // down-sha.yaml component key, e.g. multicluster-engine-assisted-service-9
+	Repository string              // owner/repo, e.g. openshift/assisted-service
+	OldSHA     string              // SHA in the first version (empty if added)
+	NewSHA     string              // SHA in the second version (empty if removed)
+	Status     ComponentDiffStatus // Changed, Unchanged, AddedInNew or RemovedFromNew
+}
+
+// FindSnapshotForVersion finds the newest snapshot folder in mceBranch whose
+// build-status.yaml announces the given version.
+func (c *Client) FindSnapshotForVersion(mceBranch, version string) (string, error) {
+	projectID := c.mceProject()
+	snapshots, err := c.listSnapshots(projectID, mceBranch)
+	if err != nil {
+		return "", fmt.Errorf("failed to get snapshot folders: %w", err)
+	}
+
+	for _, snapshot := range snapshots {
+		snapshotVersion, err := c.getVersionFromSnapshot(projectID, mceBranch, snapshot.FolderName)
+		if err != nil {
+			logger.Debug("Failed to get version from snapshot %s: %v", snapshot.FolderName, err)
+			continue
+		}
+		if snapshotVersion == version {
+			logger.Debug("Found snapshot %s for MCE %s", snapshot.FolderName, version)
+			return snapshot.FolderName, nil
+		}
+	}
+
+	return "", fmt.Errorf("no snapshot found for MCE %s in branch %s", version, mceBranch)
+}
+
+// CompareVersionSnapshots compares the component SHAs of two MCE versions.
+// Results are ordered Changed, AddedInNew, RemovedFromNew, then Unchanged.
+func (c *Client) CompareVersionSnapshots(mceBranch1, version1, mceBranch2, version2 string) ([]ComponentDiff, error) {
+	snapshot1, err := c.FindSnapshotForVersion(mceBranch1, version1)
+	if err != nil {
+		return nil, err
+	}
+	snapshot2, err := c.FindSnapshotForVersion(mceBranch2, version2)
+	if err != nil {
+		return nil, err
+	}
+
+	oldSHAs, err := c.getSnapshotComponentSHAs(mceBranch1, snapshot1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read MCE %s snapshot: %w", version1, err)
+	}
+	newSHAs, err := c.getSnapshotComponentSHAs(mceBranch2, snapshot2)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read MCE %s snapshot: %w", version2, err)
+	}
+
+	var diffs []ComponentDiff
+	for key, oldEntry := range oldSHAs {
+		diff := ComponentDiff{
+			Component:  oldEntry.component,
+			Repository: oldEntry.repository,
+			OldSHA:     oldEntry.sha,
+		}
+		if newEntry, ok := newSHAs[key]; ok {
+			diff.NewSHA = newEntry.sha
+			diff.Status = DiffUnchanged
+			if newEntry.sha != oldEntry.sha {
+				diff.Status = DiffChanged
+			}
+		} else {
+			diff.Status = DiffRemovedFromNew
+		}
+		diffs = append(diffs, diff)
+	}
+	for key, newEntry := range newSHAs {
+		if _, ok := oldSHAs[key]; !ok {
+			diffs = append(diffs, ComponentDiff{
+				Component:  newEntry.component,
+				Repository: newEntry.repository,
+				NewSHA:     newEntry.sha,
+				Status:     DiffAddedInNew,
+			})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		if diffs[i].Status != diffs[j].Status {
+			return diffStatusOrder[diffs[i].Status] < diffStatusOrder[diffs[j].Status]
+		}
+		return diffs[i].Repository < diffs[j].Repository
+	})
+
+	return diffs, nil
+}
+
+// snapshotSHA is a single repository entry from down-sha.yaml.
+type snapshotSHA struct {
+	component  string
+	repository string
+	sha        string
+}
+
+// getSnapshotComponentSHAs reads every repository SHA from a snapshot's down-sha.yaml,
+// keyed by repository so renamed component keys still line up across versions.
+func (c *Client) getSnapshotComponentSHAs(mceBranch, snapshotFolder string) (map[string]snapshotSHA, error) {
+	projectID := c.mceProject()
+	filePath := fmt.Sprintf("snapshots/%s/down-sha.yaml", snapshotFolder)
+
+	file, resp, err := c.client.RepositoryFiles.GetFile(projectID, filePath, &gitlab.GetFileOptions{
+		Ref: &mceBranch,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get down-sha.yaml: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get down-sha.yaml, status: %d", resp.StatusCode)
+	}
+
+	content, err := base64.StdEncoding.DecodeString(file.Content)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode down-sha.yaml: %w", err)
+	}
+
+	var downSHA DownSHA
+	if err := yaml.Unmarshal(content, &downSHA); err != nil {
+		return nil, fmt.Errorf("failed to parse down-sha.yaml: %w", err)
+	}
+
+	components := toStringMap(downSHA["component"])
+	if components == nil {
+		return nil, fmt.Errorf("component key not found in down-sha.yaml")
+	}
+
+	shas := make(map[string]snapshotSHA)
+	for componentName, repos := range components {
+		for repoName, repoValue := range toStringMap(repos) {
+			sha, _ := toStringMap(repoValue)["sha"].(string)
+			if sha == "" {
+				continue
+			}
+			shas[repoName] = snapshotSHA{component: componentName, repository: repoName, sha: sha}
+		}
+	}
+
+	return shas, nil
+}
+
+// toStringMap converts a decoded YAML mapping to map[string]interface{}.
+// It returns nil if value is not a mapping.
+func toStringMap(value interface{}) map[string]interface{} {
+	switch m := value.(type) {
+	case map[string]interface{}:
+		return m
+	case map[interface{}]interface{}:
+		result := make(map[string]interface{}, len(m))
+		for k, v := range m {
+			if key, ok := k.(string); ok {
+				result[key] = v
+			}
+		}
+		return result
+	}
+	return nil
+}
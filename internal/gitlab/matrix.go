@@ -0,0 +1,86 @@
+package gitlab
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/shay23bra/pr-bot/internal/logger"
+)
+
+// matrixWorkers caps how many snapshots BuildVersionMatrix reads at once.
+const matrixWorkers = 5
+
+// VersionMatrixRow is one snapshot of an MCE branch with the component SHAs it shipped.
+type VersionMatrixRow struct {
+	SnapshotFolder string            // Snapshot folder name, e.g. 2025-06-18-13-52-18
+	MCEVersion     string            // Version announced in build-status.yaml (empty if unreadable)
+	Components     map[string]string // Component repository (owner/repo) -> SHA from down-sha.yaml
+}
+
+// BuildVersionMatrix reads every snapshot of mceBranch and returns the MCE version and
+// component SHAs of each, oldest snapshot first. Snapshots whose down-sha.yaml cannot
+// be read are skipped. Components are keyed by repository because down-sha.yaml
+// component keys change between MCE versions.
+func (c *Client) BuildVersionMatrix(mceBranch string) ([]VersionMatrixRow, error) {
+	projectID := c.mceProject()
+	listed, err := c.listSnapshots(projectID, mceBranch)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get snapshot folders: %w", err)
+	}
+	if len(listed) == 0 {
+		return nil, fmt.Errorf("no snapshots found in branch %s", mceBranch)
+	}
+
+	// listSnapshots returns the newest first
+	snapshots := make([]string, len(listed))
+	for i, snapshot := range listed {
+		snapshots[len(listed)-1-i] = snapshot.FolderName
+	}
+
+	rows := make([]*VersionMatrixRow, len(snapshots))
+	sem := make(chan struct{}, matrixWorkers)
+	var wg sync.WaitGroup
+
+	for i, snapshot := range snapshots {
+		wg.Add(1)
+		go func(i int, snapshot string) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+
+			shas, err := c.getSnapshotComponentSHAs(mceBranch, snapshot)
+			if err != nil {
+				logger.Debug("Skipping snapshot %s in version matrix: %v", snapshot, err)
+				return
+			}
+
+			version, err := c.getVersionFromSnapshot(projectID, mceBranch, snapshot)
+			if err != nil {
+				logger.Debug("Failed to get version from snapshot %s: %v", snapshot, err)
+			}
+
+			row := &VersionMatrixRow{
+				SnapshotFolder: snapshot,
+				MCEVersion:     version,
+				Components:     make(map[string]string, len(shas)),
+			}
+			for repository, entry := range shas {
+				row.Components[repository] = entry.sha
+			}
+			rows[i] = row
+		}(i, snapshot)
+	}
+	wg.Wait()
+
+	var matrix []VersionMatrixRow
+	for _, row := range rows {
+		if row != nil {
+			matrix = append(matrix, *row)
+		}
+	}
+	if len(matrix) == 0 {
+		return nil, fmt.Errorf("no readable snapshots found in branch %s", mceBranch)
+	}
+
+	return matrix, nil
+}
@@ -0,0 +1,148 @@
+package gitlab
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/logger"
+	gitlab "gitlab.com/gitlab-org/api/client-go"
+)
+
+// Snapshot folders are named after the time the snapshot was taken, e.g.
+// 2025-03-14-18-55-26. Older folders may only carry the date.
+const (
+	snapshotFolderLayout = "2006-01-02-15-04-05"
+	snapshotDayLayout    = "2006-01-02"
+)
+
+// Snapshot is a snapshot folder of an MCE branch in the snapshot project.
+type Snapshot struct {
+	FolderName string    // e.g. 2025-03-14-18-55-26
+	Date       time.Time // When the snapshot was taken, parsed from FolderName (UTC)
+	MCEBranch  string    // e.g. mce-2.8
+}
+
+// Day returns the YYYY-MM-DD date the snapshot was taken.
+func (s Snapshot) Day() string {
+	return s.Date.Format(snapshotDayLayout)
+}
+
+// parseSnapshotDate parses the date a snapshot folder is named after.
+func parseSnapshotDate(folderName string) (time.Time, bool) {
+	if len(folderName) >= len(snapshotFolderLayout) {
+		if date, err := time.Parse(snapshotFolderLayout, folderName[:len(snapshotFolderLayout)]); err == nil {
+			return date, true
+		}
+	}
+	if len(folderName) >= len(snapshotDayLayout) {
+		if date, err := time.Parse(snapshotDayLayout, folderName[:len(snapshotDayLayout)]); err == nil {
+			return date, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// ListSnapshots returns the snapshots of mceBranch in the MCE snapshot project, newest first.
+func (c *Client) ListSnapshots(mceBranch string) ([]Snapshot, error) {
+	return c.listSnapshots(c.mceProject(), mceBranch)
+}
+
+// listSnapshots returns the snapshots of mceBranch in the GitLab project projectID,
+// newest first. Folders whose name does not start with a date are skipped.
+func (c *Client) listSnapshots(projectID, mceBranch string) ([]Snapshot, error) {
+	path := "snapshots"
+
+	opts := &gitlab.ListTreeOptions{
+		Path:      &path,
+		Ref:       &mceBranch,
+		Recursive: gitlab.Ptr(false),
+	}
+
+	tree, resp, err := c.client.Repositories.ListTree(projectID, opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list snapshots directory: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to list snapshots directory, status: %d", resp.StatusCode)
+	}
+
+	var snapshots []Snapshot
+	for _, item := range tree {
+		if item.Type != "tree" {
+			continue
+		}
+		date, ok := parseSnapshotDate(item.Name)
+		if !ok {
+			logger.Debug("Skipping snapshot folder %s in %s: name does not start with a date", item.Name, mceBranch)
+			continue
+		}
+		snapshots = append(snapshots, Snapshot{FolderName: item.Name, Date: date, MCEBranch: mceBranch})
+	}
+
+	sort.Slice(snapshots, func(i, j int) bool {
+		if !snapshots[i].Date.Equal(snapshots[j].Date) {
+			return snapshots[i].Date.After(snapshots[j].Date)
+		}
+		return snapshots[i].FolderName > snapshots[j].FolderName
+	})
+	return snapshots, nil
+}
+
+// FindLatestSnapshot finds the latest snapshot folder in the given MCE branch of the GitLab project projectID.
+func (c *Client) FindLatestSnapshot(projectID, mceBranch string) (string, error) {
+	logger.Debug("Finding latest snapshot in branch %s", mceBranch)
+
+	snapshots, err := c.listSnapshots(projectID, mceBranch)
+	if err != nil {
+		return "", err
+	}
+	if len(snapshots) == 0 {
+		return "", fmt.Errorf("no snapshot folders found in %s branch", mceBranch)
+	}
+
+	logger.Debug("Found latest snapshot folder: %s", snapshots[0].FolderName)
+	return snapshots[0].FolderName, nil
+}
+
+// FindSnapshotClosestToDate finds the newest snapshot folder in the given MCE branch of
+// the GitLab project projectID whose YYYY-MM-DD date prefix is not after targetDate.
+// Snapshots from the target day itself are included.
+func (c *Client) FindSnapshotClosestToDate(projectID, mceBranch string, targetDate time.Time) (string, error) {
+	logger.Debug("Finding snapshot in branch %s closest to %s", mceBranch, targetDate.Format(snapshotDayLayout))
+
+	snapshots, err := c.listSnapshots(projectID, mceBranch)
+	if err != nil {
+		return "", err
+	}
+
+	targetDay := targetDate.Format(snapshotDayLayout)
+	for _, snapshot := range snapshots {
+		if snapshot.Day() <= targetDay {
+			logger.Debug("Found snapshot folder closest to %s: %s", targetDay, snapshot.FolderName)
+			return snapshot.FolderName, nil
+		}
+	}
+	return "", fmt.Errorf("no snapshot folders found in %s branch on or before %s", mceBranch, targetDay)
+}
+
+// findSnapshotBefore finds the newest snapshot folder taken on a day before gaDate.
+func (c *Client) findSnapshotBefore(projectID, mceBranch string, gaDate time.Time) (string, error) {
+	gaDay := gaDate.Format(snapshotDayLayout)
+	logger.Debug("Looking for snapshot folders in branch %s before %s", mceBranch, gaDay)
+
+	snapshots, err := c.listSnapshots(projectID, mceBranch)
+	if err != nil {
+		return "", err
+	}
+
+	for _, snapshot := range snapshots {
+		if snapshot.Day() < gaDay {
+			logger.Debug("Selected snapshot folder: %s", snapshot.FolderName)
+			return snapshot.FolderName, nil
+		}
+	}
+	return "", fmt.Errorf("no snapshot folders found before GA date %s", gaDay)
+}
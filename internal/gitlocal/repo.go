@@ -5,7 +5,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -19,15 +20,25 @@ const defaultFetchTTL = 5 * time.Minute
 
 type RepoManager struct {
 	cacheDir string
+	host     string // Git host to clone from, github.com unless SetHost is called
 	fetchTTL time.Duration
 	repos    map[string]*Repo
 	mu       sync.Mutex
+
+	detectedPatterns map[string][]string // keyed by "owner/repo"
+	detectMu         sync.Mutex
 }
 
 type Repo struct {
 	path      string
 	lastFetch time.Time
 	fetchMu   sync.Mutex
+
+	// Release branches kept by CachedBranches for every analyzer sharing the manager
+	branchMu       sync.Mutex
+	branches       []github.BranchInfo
+	branchPatterns string // The patterns branches were listed with, see patternsKey
+	branchesAt     time.Time
 }
 
 func NewRepoManager(cacheDir string) (*RepoManager, error) {
@@ -39,11 +50,49 @@ func NewRepoManager(cacheDir string) (*RepoManager, error) {
 	}
 	return &RepoManager{
 		cacheDir: cacheDir,
+		host:     github.GitHubHost,
 		fetchTTL: defaultFetchTTL,
 		repos:    make(map[string]*Repo),
+
+		detectedPatterns: make(map[string][]string),
 	}, nil
 }
 
+// SetHost makes the manager clone and fetch from a GitHub Enterprise host instead of github.com.
+func (rm *RepoManager) SetHost(host string) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.host = host
+}
+
+// SetFetchTTL sets how long a repository is used without fetching it again
+// (5 minutes by default).
+func (rm *RepoManager) SetFetchTTL(ttl time.Duration) {
+	rm.mu.Lock()
+	defer rm.mu.Unlock()
+	rm.fetchTTL = ttl
+}
+
+// DetectedBranchPatterns returns the release branch patterns detected in owner/repo.
+// detect is only called the first time a repository is asked for; its result is
+// reused until the manager is discarded. Failed detections are not cached.
+func (rm *RepoManager) DetectedBranchPatterns(owner, repo string, detect func() ([]string, error)) ([]string, error) {
+	key := owner + "/" + repo
+
+	rm.detectMu.Lock()
+	defer rm.detectMu.Unlock()
+
+	if patterns, ok := rm.detectedPatterns[key]; ok {
+		return patterns, nil
+	}
+	patterns, err := detect()
+	if err != nil {
+		return nil, err
+	}
+	rm.detectedPatterns[key] = patterns
+	return patterns, nil
+}
+
 var supportedRepos = [][2]string{
 	{"openshift", "assisted-service"},
 	{"openshift", "assisted-installer"},
@@ -65,6 +114,8 @@ func (rm *RepoManager) EnsureRepo(owner, repo, token string) (*Repo, error) {
 	key := owner + "/" + repo
 
 	rm.mu.Lock()
+	host := rm.host
+	fetchTTL := rm.fetchTTL
 	r, exists := rm.repos[key]
 	if !exists {
 		repoPath := filepath.Join(rm.cacheDir, owner, repo+".git")
@@ -77,7 +128,7 @@ func (rm *RepoManager) EnsureRepo(owner, repo, token string) (*Repo, error) {
 	defer r.fetchMu.Unlock()
 
 	if _, err := os.Stat(r.path); os.IsNotExist(err) {
-		cloneURL := fmt.Sprintf("https://%s@github.com/%s/%s.git", token, owner, repo)
+		cloneURL := fmt.Sprintf("https://%s@%s/%s/%s.git", token, host, owner, repo)
 		logger.Debug("Cloning %s/%s (bare) to %s", owner, repo, r.path)
 		if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
 			return nil, fmt.Errorf("failed to create parent dir: %w", err)
@@ -91,9 +142,9 @@ func (rm *RepoManager) EnsureRepo(owner, repo, token string) (*Repo, error) {
 		return r, nil
 	}
 
-	if time.Since(r.lastFetch) > rm.fetchTTL {
+	if time.Since(r.lastFetch) > fetchTTL {
 		logger.Debug("Fetching %s/%s (stale for %v)", owner, repo, time.Since(r.lastFetch))
-		fetchURL := fmt.Sprintf("https://%s@github.com/%s/%s.git", token, owner, repo)
+		fetchURL := fmt.Sprintf("https://%s@%s/%s/%s.git", token, host, owner, repo)
 		cmd := exec.Command("git", "-C", r.path, "fetch", "--prune", fetchURL, "+refs/heads/*:refs/heads/*", "+refs/tags/*:refs/tags/*")
 		if out, err := cmd.CombinedOutput(); err != nil {
 			logger.Debug("Warning: git fetch failed for %s/%s: %v\n%s", owner, repo, err, string(out))
@@ -106,60 +157,109 @@ func (rm *RepoManager) EnsureRepo(owner, repo, token string) (*Repo, error) {
 	return r, nil
 }
 
-func (r *Repo) ListBranches() ([]github.BranchInfo, error) {
+// ListBranches returns the local branches that match one of patterns.
+func (r *Repo) ListBranches(patterns []models.BranchPattern) ([]github.BranchInfo, error) {
+	matcher, err := github.NewBranchMatcher(patterns)
+	if err != nil {
+		return nil, err
+	}
+
 	cmd := exec.Command("git", "-C", r.path, "branch", "--list")
 	out, err := cmd.Output()
 	if err != nil {
 		return nil, fmt.Errorf("git branch failed: %w", err)
 	}
 
-	branchPatterns := []string{"release-ocm-", "releases/v", "release-v", "release-", "v"}
 	var result []github.BranchInfo
-
 	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
 		name := strings.TrimSpace(strings.TrimPrefix(line, "* "))
 		if name == "" {
 			continue
 		}
+		if branchInfo, ok := matcher.Match(name); ok {
+			result = append(result, branchInfo)
+		}
+	}
 
-		for _, pattern := range branchPatterns {
-			if !strings.HasPrefix(name, pattern) {
-				continue
-			}
-			if pattern == "release-" && (strings.HasPrefix(name, "release-v") || strings.HasPrefix(name, "release-ocm-") || strings.HasPrefix(name, "releases/v")) {
-				continue
-			}
-			if pattern == "release-v" && strings.HasPrefix(name, "releases/v") {
-				continue
-			}
-			if pattern == "v" {
-				if strings.HasPrefix(name, "release-v") || strings.HasPrefix(name, "releases/v") {
-					continue
-				}
-				if len(name) > 1 && !regexp.MustCompile(`^v\d`).MatchString(name) {
-					continue
-				}
-			}
+	return result, nil
+}
+
+// CachedBranches returns the local branches that match one of patterns like
+// ListBranches, reusing the branches listed for the same patterns within ttl. The
+// bool reports whether the cached branches were returned.
+func (r *Repo) CachedBranches(patterns []models.BranchPattern, ttl time.Duration) ([]github.BranchInfo, bool, error) {
+	key := patternsKey(patterns)
+
+	r.branchMu.Lock()
+	defer r.branchMu.Unlock()
 
-			version := github.ExtractVersionFromBranchWithPattern(name, pattern)
-			result = append(result, github.BranchInfo{Name: name, Pattern: pattern, Version: version})
-			break
+	if len(r.branches) > 0 && r.branchPatterns == key {
+		age := time.Since(r.branchesAt)
+		if age <= ttl {
+			return slices.Clone(r.branches), true, nil
 		}
+		logger.Debug("Branch cache for %s is stale (cached %v ago)", r.path, age.Round(time.Second))
 	}
+	return r.listAndCacheBranches(patterns, key)
+}
 
-	return result, nil
+// RefreshBranches lists the local branches that match one of patterns and caches
+// them for CachedBranches.
+func (r *Repo) RefreshBranches(patterns []models.BranchPattern) ([]github.BranchInfo, error) {
+	r.branchMu.Lock()
+	defer r.branchMu.Unlock()
+
+	branches, _, err := r.listAndCacheBranches(patterns, patternsKey(patterns))
+	return branches, err
+}
+
+// listAndCacheBranches lists the branches matching patterns and caches them under
+// key. The caller must hold branchMu.
+func (r *Repo) listAndCacheBranches(patterns []models.BranchPattern, key string) ([]github.BranchInfo, bool, error) {
+	branches, err := r.ListBranches(patterns)
+	if err != nil {
+		return nil, false, err
+	}
+	r.branches = slices.Clone(branches)
+	r.branchPatterns = key
+	r.branchesAt = time.Now()
+	return branches, false, nil
 }
 
+// patternsKey identifies a list of branch patterns in the branch cache.
+func patternsKey(patterns []models.BranchPattern) string {
+	return fmt.Sprintf("%q", patterns)
+}
+
+// FlushBranchCache drops the release branches cached by CachedBranches for every
+// repository, so they are listed again.
+func (rm *RepoManager) FlushBranchCache() {
+	rm.mu.Lock()
+	repos := make([]*Repo, 0, len(rm.repos))
+	for _, r := range rm.repos {
+		repos = append(repos, r)
+	}
+	rm.mu.Unlock()
+
+	for _, r := range repos {
+		r.branchMu.Lock()
+		r.branches = nil
+		r.branchMu.Unlock()
+	}
+}
+
+// IsAncestor reports whether commitSHA is reachable from ref. It returns an error
+// when git cannot tell, e.g. because one of them is not in the repository.
 func (r *Repo) IsAncestor(commitSHA, ref string) (bool, error) {
 	cmd := exec.Command("git", "-C", r.path, "merge-base", "--is-ancestor", commitSHA, ref)
-	err := cmd.Run()
+	out, err := cmd.CombinedOutput()
 	if err == nil {
 		return true, nil
 	}
 	if exitErr, ok := err.(*exec.ExitError); ok && exitErr.ExitCode() == 1 {
 		return false, nil
 	}
-	return false, nil
+	return false, fmt.Errorf("git merge-base failed for %s and %s: %w\n%s", commitSHA, ref, err, strings.TrimSpace(string(out)))
 }
 
 func (r *Repo) GetCommitDate(sha string) (*time.Time, error) {
@@ -179,6 +279,55 @@ func (r *Repo) GetCommitDate(sha string) (*time.Time, error) {
 	return &t, nil
 }
 
+// GetCommitMessage returns the full message of a commit.
+func (r *Repo) GetCommitMessage(sha string) (string, error) {
+	cmd := exec.Command("git", "-C", r.path, "log", "-1", "--format=%B", sha)
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git log failed for %s: %w", sha, err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// cherryPickSearchCommits bounds the commits FindCherryPick reads when the original
+// commit has no date.
+const cherryPickSearchCommits = 500
+
+// FindCherryPick looks for a cherry-pick of commitSHA, whose message is commitMessage,
+// among the commits of branch made since the original commit (see github.IsCherryPickOf).
+// It returns the SHA and commit date of the cherry-pick, or "" if there is none.
+func (r *Repo) FindCherryPick(commitSHA, commitMessage, branch string, since *time.Time) (string, *time.Time, error) {
+	args := []string{"-C", r.path, "log", "--format=%H%x1f%cI%x1f%B%x1e"}
+	if since != nil {
+		// Allow for clock skew between the original and the cherry-picked commit
+		args = append(args, "--since="+since.Add(-24*time.Hour).Format(time.RFC3339))
+	} else {
+		args = append(args, fmt.Sprintf("--max-count=%d", cherryPickSearchCommits))
+	}
+	args = append(args, branch, "--")
+
+	out, err := exec.Command("git", args...).Output()
+	if err != nil {
+		return "", nil, fmt.Errorf("git log failed for %s: %w", branch, err)
+	}
+
+	for _, record := range strings.Split(string(out), "\x1e") {
+		parts := strings.SplitN(strings.TrimSpace(record), "\x1f", 3)
+		if len(parts) < 3 || parts[0] == commitSHA {
+			continue
+		}
+		if !github.IsCherryPickOf(parts[2], commitMessage, commitSHA) {
+			continue
+		}
+		date, err := time.Parse(time.RFC3339, parts[1])
+		if err != nil {
+			return parts[0], nil, nil
+		}
+		return parts[0], &date, nil
+	}
+	return "", nil, nil
+}
+
 func (r *Repo) ListTags(prefix string) ([]string, error) {
 	pattern := prefix + "*"
 	cmd := exec.Command("git", "-C", r.path, "tag", "-l", pattern)
@@ -271,7 +420,9 @@ func (r *Repo) ShowFile(sha, path string) (string, error) {
 	return string(out), nil
 }
 
-func (r *Repo) FindPreviousVersion(version string) (string, error) {
+// FindPreviousVersion returns the tag released before version. Tags rejected by
+// any accept function (e.g. draft releases) are skipped.
+func (r *Repo) FindPreviousVersion(version string, accept ...func(tag string) bool) (string, error) {
 	allTags, err := r.ListTags("v")
 	if err != nil {
 		return "", fmt.Errorf("failed to list tags: %w", err)
@@ -282,40 +433,42 @@ func (r *Repo) FindPreviousVersion(version string) (string, error) {
 		return "", fmt.Errorf("invalid version %s: %w", version, err)
 	}
 
+	accepted := func(tag string) bool {
+		for _, fn := range accept {
+			if !fn(tag) {
+				return false
+			}
+		}
+		return true
+	}
+
 	var candidates []string
 
 	if patch > 0 {
 		for p := patch - 1; p >= 0; p-- {
 			target := fmt.Sprintf("v%d.%d.%d", major, minor, p)
 			for _, tag := range allTags {
-				if tag == target {
+				if tag == target && accepted(tag) {
 					return tag, nil
 				}
 			}
 		}
-		prefix := fmt.Sprintf("v%d.%d.", major, minor-1)
-		for _, tag := range allTags {
-			if strings.HasPrefix(tag, prefix) {
-				candidates = append(candidates, tag)
-			}
-		}
-	} else {
-		prefix := fmt.Sprintf("v%d.%d.", major, minor-1)
-		for _, tag := range allTags {
-			if strings.HasPrefix(tag, prefix) {
-				candidates = append(candidates, tag)
-			}
+	}
+
+	prefix := fmt.Sprintf("v%d.%d.", major, minor-1)
+	for _, tag := range allTags {
+		if strings.HasPrefix(tag, prefix) {
+			candidates = append(candidates, tag)
 		}
 	}
 
-	if len(candidates) > 0 {
-		latest := candidates[0]
-		for _, c := range candidates[1:] {
-			if models.CompareSemanticVersions(c, latest) > 0 {
-				latest = c
-			}
+	sort.Slice(candidates, func(i, j int) bool {
+		return models.CompareSemanticVersions(candidates[i], candidates[j]) > 0
+	})
+	for _, c := range candidates {
+		if accepted(c) {
+			return c, nil
 		}
-		return latest, nil
 	}
 
 	return "", fmt.Errorf("no previous version found for %s", version)
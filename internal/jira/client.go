@@ -7,11 +7,37 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"regexp"
+	"sort"
+	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/models"
+	"github.com/shay23bra/pr-bot/internal/proxy"
+)
+
+// DefaultBaseURL is the Jira instance used for all API calls and browse links.
+const DefaultBaseURL = "https://redhat.atlassian.net"
+
+// BrowseURL returns the web URL of an issue.
+func BrowseURL(issueKey string) string {
+	return DefaultBaseURL + "/browse/" + issueKey
+}
+
+// Clone resolution settings for GetAllClonedIssues.
+const (
+	cloneQueueSize = 32 // Buffered issue keys waiting for a worker
+
+	// DefaultConcurrentIssueLimit is how many GetIssue calls GetAllClonedIssues makes at once by default.
+	DefaultConcurrentIssueLimit = 5
+
+	// DefaultEpicDepth bounds how many epic and "is part of" hops FollowEpics takes from the original issue.
+	DefaultEpicDepth = 3
 )
 
 // Client represents a Jira API client.
@@ -21,6 +47,15 @@ type Client struct {
 	token      string
 	email      string
 	ctx        context.Context
+
+	includeIssueLinkSummaries bool
+	tickets                   *TicketMatcher // Projects recognized in PR titles
+	followEpics               bool
+	epicDepth                 int
+	followSubtasks            bool
+	concurrentIssueLimit      int // GetIssue calls GetAllClonedIssues makes at once
+
+	epicSummaries sync.Map // epic key -> summary
 }
 
 // JiraIssue represents a Jira issue/ticket.
@@ -31,10 +66,43 @@ type JiraIssue struct {
 
 // JiraFields represents the fields of a Jira issue.
 type JiraFields struct {
-	Summary     string       `json:"summary"`
-	Description string       `json:"description"`
-	IssueLinks  []IssueLink  `json:"issuelinks"`
-	RemoteLinks []RemoteLink `json:"remotelinks"`
+	Summary     string          `json:"summary"`
+	IssueType   IssueType       `json:"issuetype"`
+	FixVersions VersionNames    `json:"fixVersions"` // Versions the issue is planned to be fixed in
+	Description RichText        `json:"description"` // Wiki markup or ADF; use PlainText to read it
+	Priority    JiraPriority    `json:"priority"`
+	Epic        string          `json:"customfield_10014"` // Epic link (issue key of the parent epic)
+	EpicSummary string          `json:"-"`                 // Summary of the epic, fetched separately
+	IssueLinks  []IssueLink     `json:"issuelinks"`
+	RemoteLinks []RemoteLink    `json:"remotelinks"`
+	Subtasks    []LinkedIssue   `json:"subtasks"` // Sub-tasks of the issue, which are not issue links
+	Components  []JiraComponent `json:"components"`
+	Assignee    *JiraUser       `json:"assignee"` // nil when unassigned
+}
+
+// IssueType is the type of a Jira issue, e.g. "Bug" or "Epic".
+type IssueType struct {
+	Name string `json:"name"`
+}
+
+// VersionNames holds the names of the versions in a version field such as fixVersions,
+// which Jira returns as a list of version objects.
+type VersionNames []string
+
+// UnmarshalJSON reads the name of each version object.
+func (v *VersionNames) UnmarshalJSON(data []byte) error {
+	var versions []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal(data, &versions); err != nil {
+		return err
+	}
+	names := make(VersionNames, 0, len(versions))
+	for _, version := range versions {
+		names = append(names, version.Name)
+	}
+	*v = names
+	return nil
 }
 
 // IssueLink represents a link between Jira issues.
@@ -75,20 +143,90 @@ type JiraSearchResponse struct {
 	Total  int         `json:"total"`
 }
 
+// ClientOptions configures the Jira client.
+type ClientOptions struct {
+	Proxy proxy.Settings
+
+	// IncludeIssueLinkSummaries also looks for PR URLs in the summaries of linked issues,
+	// which some Jira integrations use to record PRs. Off by default because a linked
+	// issue's PRs are usually not the PRs of this issue.
+	IncludeIssueLinkSummaries bool
+
+	// Projects are the project keys whose tickets are recognized in PR titles; empty means DefaultProjects.
+	Projects []string
+
+	// FollowEpics makes GetAllClonedIssues also walk epic links, the issues of an epic and
+	// "is part of" links, up to EpicDepth hops (DefaultEpicDepth when zero) from the original issue.
+	FollowEpics bool
+	EpicDepth   int
+
+	// FollowSubtasks makes GetAllClonedIssues also collect PRs from the issue's sub-tasks
+	// and from issues linked with a "Subtask" link type.
+	FollowSubtasks bool
+
+	// ConcurrentIssueLimit bounds the concurrent GetIssue calls of GetAllClonedIssues
+	// (DefaultConcurrentIssueLimit when zero).
+	ConcurrentIssueLimit int
+}
+
+// OptionsFromConfig builds ClientOptions from the application configuration.
+func OptionsFromConfig(cfg *models.Config) ClientOptions {
+	return ClientOptions{
+		Proxy:                     proxy.FromConfig(cfg),
+		IncludeIssueLinkSummaries: cfg.JiraLinkSummaries,
+		Projects:                  cfg.JiraProjects,
+		FollowEpics:               cfg.JiraFollowEpics,
+		FollowSubtasks:            cfg.JiraFollowSubtasks,
+		ConcurrentIssueLimit:      cfg.JiraConcurrentIssueLimit,
+	}
+}
+
 // NewClient creates a new Jira client.
-func NewClient(ctx context.Context, email, token string) *Client {
+func NewClient(ctx context.Context, email, token string, opts ...ClientOptions) *Client {
 	if token == "" || email == "" {
 		return nil
 	}
 
+	var options ClientOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
+	if transport := options.Proxy.NewTransport(); transport != nil {
+		httpClient.Transport = transport
+	}
+
+	projects := options.Projects
+	if len(projects) == 0 {
+		projects = DefaultProjects
+	}
+
+	epicDepth := options.EpicDepth
+	if epicDepth <= 0 {
+		epicDepth = DefaultEpicDepth
+	}
+
+	concurrentIssueLimit := options.ConcurrentIssueLimit
+	if concurrentIssueLimit <= 0 {
+		concurrentIssueLimit = DefaultConcurrentIssueLimit
+	}
+
 	return &Client{
-		baseURL: "https://redhat.atlassian.net",
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-		token: token,
-		email: email,
-		ctx:   ctx,
+		baseURL:    DefaultBaseURL,
+		httpClient: httpClient,
+		token:      token,
+		email:      email,
+		ctx:        ctx,
+
+		includeIssueLinkSummaries: options.IncludeIssueLinkSummaries,
+		tickets:                   NewTicketMatcher(projects),
+		followEpics:               options.FollowEpics,
+		epicDepth:                 epicDepth,
+		followSubtasks:            options.FollowSubtasks,
+		concurrentIssueLimit:      concurrentIssueLimit,
 	}
 }
 
@@ -96,7 +234,7 @@ func NewClient(ctx context.Context, email, token string) *Client {
 func (c *Client) GetIssue(issueKey string) (*JiraIssue, error) {
 	logger.Debug("Getting Jira issue: %s", issueKey)
 
-	url := fmt.Sprintf("%s/rest/api/2/issue/%s?expand=names&fields=summary,description,issuelinks,remotelinks", c.baseURL, issueKey)
+	url := fmt.Sprintf("%s/rest/api/2/issue/%s?expand=names&fields=summary,issuetype,description,priority,fixVersions,issuelinks,remotelinks,subtasks,components,assignee,customfield_10014", c.baseURL, issueKey)
 
 	req, err := http.NewRequestWithContext(c.ctx, "GET", url, nil)
 	if err != nil {
@@ -133,6 +271,14 @@ func (c *Client) GetIssue(issueKey string) (*JiraIssue, error) {
 
 	logger.Debug("Found issue: %s - %s", issue.Key, issue.Fields.Summary)
 
+	if issue.Fields.Epic != "" {
+		summary, err := c.getEpicSummary(issue.Fields.Epic)
+		if err != nil {
+			logger.Debug("Warning: failed to get epic %s for %s: %v", issue.Fields.Epic, issueKey, err)
+		}
+		issue.Fields.EpicSummary = summary
+	}
+
 	// Get remote links separately as they require a different API endpoint
 	remoteLinks, err := c.getRemoteLinks(issueKey)
 	if err != nil {
@@ -145,9 +291,57 @@ func (c *Client) GetIssue(issueKey string) (*JiraIssue, error) {
 	return &issue, nil
 }
 
-// getRemoteLinks retrieves remote links for a JIRA issue.
+// suspectedRemoteLinkLimits are response sizes that suggest the server truncated a
+// remote link list at a non-default maxResults.
+var suspectedRemoteLinkLimits = map[int]bool{50: true, 100: true}
+
+// getRemoteLinks retrieves remote links for a JIRA issue. The endpoint is not
+// paginated, but some servers cap it at their maxResults setting: when a response
+// has exactly 50 or 100 links, the links after them are requested with startAt.
 func (c *Client) getRemoteLinks(issueKey string) ([]RemoteLink, error) {
+	remoteLinks, err := c.getRemoteLinksPage(issueKey, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[int]bool, len(remoteLinks))
+	for _, link := range remoteLinks {
+		seen[link.ID] = true
+	}
+
+	pageSize := len(remoteLinks)
+	for suspectedRemoteLinkLimits[pageSize] {
+		logger.Info("⚠️  %s returned exactly %d remote links, which may be a server-side limit; requesting more", issueKey, pageSize)
+		page, err := c.getRemoteLinksPage(issueKey, len(remoteLinks))
+		if err != nil {
+			logger.Debug("Warning: failed to get more remote links for %s: %v", issueKey, err)
+			break
+		}
+
+		// Servers that ignore startAt return the same links again
+		added := 0
+		for _, link := range page {
+			if !seen[link.ID] {
+				seen[link.ID] = true
+				remoteLinks = append(remoteLinks, link)
+				added++
+			}
+		}
+		if added == 0 {
+			break
+		}
+		pageSize = len(page)
+	}
+
+	return remoteLinks, nil
+}
+
+// getRemoteLinksPage retrieves the remote links of a JIRA issue starting at startAt.
+func (c *Client) getRemoteLinksPage(issueKey string, startAt int) ([]RemoteLink, error) {
 	url := fmt.Sprintf("%s/rest/api/2/issue/%s/remotelink", c.baseURL, issueKey)
+	if startAt > 0 {
+		url += fmt.Sprintf("?startAt=%d", startAt)
+	}
 
 	req, err := http.NewRequestWithContext(c.ctx, "GET", url, nil)
 	if err != nil {
@@ -181,125 +375,336 @@ func (c *Client) getRemoteLinks(issueKey string) ([]RemoteLink, error) {
 	return remoteLinks, nil
 }
 
+// getEpicSummary fetches only the summary of an epic. Summaries are cached because
+// clones usually share the same epic.
+func (c *Client) getEpicSummary(epicKey string) (string, error) {
+	if summary, ok := c.epicSummaries.Load(epicKey); ok {
+		return summary.(string), nil
+	}
+
+	url := fmt.Sprintf("%s/rest/api/2/issue/%s?fields=summary", c.baseURL, epicKey)
+
+	req, err := http.NewRequestWithContext(c.ctx, "GET", url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.email+":"+c.token)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get epic %s, status: %d", epicKey, resp.StatusCode)
+	}
+
+	var epic JiraIssue
+	if err := json.NewDecoder(resp.Body).Decode(&epic); err != nil {
+		return "", fmt.Errorf("failed to unmarshal epic response: %w", err)
+	}
+
+	c.epicSummaries.Store(epicKey, epic.Fields.Summary)
+	return epic.Fields.Summary, nil
+}
+
 // GetAllClonedIssues finds all cloned issues related to the given issue.
 func (c *Client) GetAllClonedIssues(issueKey string) ([]JiraIssue, error) {
 	logger.Debug("Getting cloned issues for: %s", issueKey)
 
-	var allIssues []JiraIssue
-	visited := make(map[string]bool)
-	toProcess := []string{issueKey}
+	type cloneJob struct {
+		key   string
+		order int // discovery order, so results keep BFS order with the original issue first
+		depth int // epic and "is part of" hops from the original issue; clones keep their source's depth
+	}
+	type cloneResult struct {
+		issue JiraIssue
+		order int
+	}
 
-	for len(toProcess) > 0 {
-		currentKey := toProcess[0]
-		toProcess = toProcess[1:]
+	jobs := make(chan cloneJob, cloneQueueSize)
+	results := make(chan cloneResult, cloneQueueSize)
 
-		if visited[currentKey] {
-			continue
-		}
-		visited[currentKey] = true
+	// visited doubles as cycle detection: every key is fetched at most once,
+	// however the clone links loop back on each other.
+	var visited sync.Map
+	var discovered atomic.Int64
+	var pending sync.WaitGroup
 
-		issue, err := c.GetIssue(currentKey)
-		if err != nil {
-			logger.Debug("Failed to get issue %s: %v", currentKey, err)
-			continue
+	enqueue := func(key string, depth int) {
+		if _, seen := visited.LoadOrStore(key, true); seen {
+			return
+		}
+		job := cloneJob{key: key, order: int(discovered.Add(1) - 1), depth: depth}
+		pending.Add(1)
+		select {
+		case jobs <- job:
+		default:
+			// Queue is full and every worker may be blocked here; hand off the send
+			go func() { jobs <- job }()
 		}
+	}
 
-		allIssues = append(allIssues, *issue)
+	for w := 0; w < c.concurrentIssueLimit; w++ {
+		go func() {
+			for job := range jobs {
+				issue, err := c.GetIssue(job.key)
+				if err != nil {
+					logger.Debug("Failed to get issue %s: %v", job.key, err)
+					pending.Done()
+					continue
+				}
 
-		// Look for cloned issues in links
-		for _, link := range issue.Fields.IssueLinks {
-			if strings.Contains(strings.ToLower(link.Type.Name), "clone") {
-				if link.OutwardIssue != nil && !visited[link.OutwardIssue.Key] {
-					toProcess = append(toProcess, link.OutwardIssue.Key)
+				// Look for cloned issues in links
+				for _, link := range issue.Fields.IssueLinks {
+					if strings.Contains(strings.ToLower(link.Type.Name), "clone") {
+						if link.OutwardIssue != nil {
+							enqueue(link.OutwardIssue.Key, job.depth)
+						}
+						if link.InwardIssue != nil {
+							enqueue(link.InwardIssue.Key, job.depth)
+						}
+					}
 				}
-				if link.InwardIssue != nil && !visited[link.InwardIssue.Key] {
-					toProcess = append(toProcess, link.InwardIssue.Key)
+
+				if c.followSubtasks {
+					c.enqueueSubtasks(issue, func(key string) { enqueue(key, job.depth) })
 				}
+
+				if c.followEpics && job.depth < c.epicDepth {
+					c.enqueueEpicRelations(issue, func(key string) { enqueue(key, job.depth+1) })
+				}
+
+				results <- cloneResult{issue: *issue, order: job.order}
+				pending.Done()
 			}
-		}
+		}()
+	}
+
+	enqueue(issueKey, 0)
+	go func() {
+		pending.Wait()
+		close(jobs)
+		close(results)
+	}()
+
+	var collected []cloneResult
+	for result := range results {
+		collected = append(collected, result)
+	}
+	sort.Slice(collected, func(i, j int) bool {
+		return collected[i].order < collected[j].order
+	})
+
+	allIssues := make([]JiraIssue, 0, len(collected))
+	for _, result := range collected {
+		allIssues = append(allIssues, result.issue)
 	}
 
 	logger.Debug("Found %d total issues (including original and clones)", len(allIssues))
 	return allIssues, nil
 }
 
-// ExtractGitHubPRsFromIssue extracts GitHub PR URLs from a Jira issue.
-func (c *Client) ExtractGitHubPRsFromIssue(issue JiraIssue) []string {
-	var prURLs []string
+// enqueueSubtasks passes enqueue the issue's sub-tasks and the issues linked by
+// "Subtask" links.
+func (c *Client) enqueueSubtasks(issue *JiraIssue, enqueue func(key string)) {
+	for _, subtask := range issue.Fields.Subtasks {
+		enqueue(subtask.Key)
+	}
 
-	// GitHub PR URL pattern
-	prPattern := regexp.MustCompile(`https://github\.com/[^/]+/[^/]+/pull/\d+`)
+	for _, link := range issue.Fields.IssueLinks {
+		name := strings.ToLower(link.Type.Name)
+		if !strings.Contains(name, "subtask") && !strings.Contains(name, "sub-task") {
+			continue
+		}
+		if link.OutwardIssue != nil {
+			enqueue(link.OutwardIssue.Key)
+		}
+		if link.InwardIssue != nil {
+			enqueue(link.InwardIssue.Key)
+		}
+	}
+}
 
-	// Check summary
-	matches := prPattern.FindAllString(issue.Fields.Summary, -1)
-	prURLs = append(prURLs, matches...)
+// enqueueEpicRelations passes enqueue the issue's epic, the issues linked by
+// "is part of" links and, for an epic, the issues in it.
+func (c *Client) enqueueEpicRelations(issue *JiraIssue, enqueue func(key string)) {
+	if issue.Fields.Epic != "" {
+		enqueue(issue.Fields.Epic)
+	}
 
-	// Check description
-	matches = prPattern.FindAllString(issue.Fields.Description, -1)
-	prURLs = append(prURLs, matches...)
+	for _, link := range issue.Fields.IssueLinks {
+		if !isPartOfLink(link.Type) {
+			continue
+		}
+		if link.OutwardIssue != nil {
+			enqueue(link.OutwardIssue.Key)
+		}
+		if link.InwardIssue != nil {
+			enqueue(link.InwardIssue.Key)
+		}
+	}
 
-	// Check remote links - these are where "links to" URLs are typically stored
-	for _, remoteLink := range issue.Fields.RemoteLinks {
-		matches := prPattern.FindAllString(remoteLink.Object.URL, -1)
-		prURLs = append(prURLs, matches...)
-		logger.Debug("Checked remote link: %s (title: %s)", remoteLink.Object.URL, remoteLink.Object.Title)
+	if strings.EqualFold(issue.Fields.IssueType.Name, "Epic") {
+		children, err := c.searchIssueKeys(fmt.Sprintf(`"Epic Link" = %s OR parent = %s`, issue.Key, issue.Key))
+		if err != nil {
+			logger.Debug("Failed to list issues in epic %s: %v", issue.Key, err)
+			return
+		}
+		logger.Debug("Epic %s has %d issues", issue.Key, len(children))
+		for _, child := range children {
+			enqueue(child)
+		}
 	}
+}
 
-	// Remove duplicates
-	seen := make(map[string]bool)
-	var uniquePRs []string
-	for _, pr := range prURLs {
-		if !seen[pr] {
-			seen[pr] = true
-			uniquePRs = append(uniquePRs, pr)
+// isPartOfLink reports whether a link type expresses a containment ("is part of") relationship.
+func isPartOfLink(linkType LinkType) bool {
+	return strings.Contains(strings.ToLower(linkType.Inward), "part of") ||
+		strings.Contains(strings.ToLower(linkType.Outward), "part of")
+}
+
+// searchPageSize is the number of issues requested per search page.
+const searchPageSize = 100
+
+// searchIssueKeys returns the keys of all issues matching a JQL query.
+func (c *Client) searchIssueKeys(jql string) ([]string, error) {
+	var keys []string
+	for startAt := 0; ; {
+		query := url.Values{}
+		query.Set("jql", jql)
+		query.Set("fields", "key")
+		query.Set("startAt", strconv.Itoa(startAt))
+		query.Set("maxResults", strconv.Itoa(searchPageSize))
+
+		req, err := http.NewRequestWithContext(c.ctx, "GET", c.baseURL+"/rest/api/2/search?"+query.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create request: %w", err)
+		}
+
+		req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.email+":"+c.token)))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := c.httpClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("failed to make request: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, fmt.Errorf("failed to search issues, status: %d, body: %s", resp.StatusCode, string(body))
+		}
+
+		var page JiraSearchResponse
+		err = json.NewDecoder(resp.Body).Decode(&page)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal search response: %w", err)
+		}
+
+		for _, issue := range page.Issues {
+			keys = append(keys, issue.Key)
+		}
+
+		startAt += len(page.Issues)
+		if len(page.Issues) == 0 || startAt >= page.Total {
+			return keys, nil
 		}
 	}
+}
+
+// GitHub PR and GitLab MR URL patterns. GitLab MR URLs look like
+// https://gitlab.cee.redhat.com/group/project/-/merge_requests/123.
+var (
+	githubPRPattern = regexp.MustCompile(`https://github\.com/[^/]+/[^/]+/pull/\d+`)
+	gitlabMRPattern = regexp.MustCompile(`https://gitlab\.[^/\s]+/[^\s]+?/merge_requests/\d+`)
+)
 
-	logger.Debug("Found %d GitHub PRs in issue %s (checked summary, description, and %d remote links)", len(uniquePRs), issue.Key, len(issue.Fields.RemoteLinks))
-	return uniquePRs
+// ExtractGitHubPRsFromIssue extracts GitHub PR URLs from a Jira issue.
+func (c *Client) ExtractGitHubPRsFromIssue(issue JiraIssue) []string {
+	prURLs := c.extractURLsFromIssue(issue, githubPRPattern, "github.com")
+	logger.Debug("Found %d GitHub PRs in issue %s (checked summary, description, and %d remote links)", len(prURLs), issue.Key, len(issue.Fields.RemoteLinks))
+	return prURLs
 }
 
-// ExtractMGMTTicketFromTitle extracts MGMT ticket number from PR title.
-func ExtractMGMTTicketFromTitle(title string) string {
-	return ExtractJiraTicketWithPrefix(title, "MGMT")
+// ExtractGitLabMRsFromIssue extracts GitLab merge request URLs from a Jira issue,
+// looking in the same places as ExtractGitHubPRsFromIssue.
+func (c *Client) ExtractGitLabMRsFromIssue(issue JiraIssue) []string {
+	mrURLs := c.extractURLsFromIssue(issue, gitlabMRPattern, "gitlab.")
+	logger.Debug("Found %d GitLab MRs in issue %s", len(mrURLs), issue.Key)
+	return mrURLs
 }
 
-// ExtractJiraTicketFromText extracts JIRA ticket from text with any project prefix.
-// It supports URLs like https://issues.redhat.com/browse/ACM-22787 and direct ticket IDs like MGMT-20662
-func ExtractJiraTicketFromText(text string) string {
-	// First try to extract from JIRA URL format
-	urlRegex := regexp.MustCompile(`https://redhat\.atlassian\.net/browse/([A-Z]+-\d+)`)
-	matches := urlRegex.FindStringSubmatch(text)
-	if len(matches) >= 2 {
-		return matches[1]
+// extractURLsFromIssue returns the unique matches of pattern in the summary,
+// description, remote links and (if enabled) linked issue summaries of an issue.
+// Remote links whose URL does not contain host are skipped.
+func (c *Client) extractURLsFromIssue(issue JiraIssue, pattern *regexp.Regexp, host string) []string {
+	var urls []string
+
+	// Check summary
+	matches := pattern.FindAllString(issue.Fields.Summary, -1)
+	urls = append(urls, matches...)
+
+	// Check description, rendered so URLs inside ADF nodes and wiki links are found
+	matches = pattern.FindAllString(issue.Fields.Description.PlainText(), -1)
+	urls = append(urls, matches...)
+
+	// Check remote links - these are where "links to" URLs are typically stored.
+	// Confluence pages, CI builds and other unrelated links are skipped.
+	for _, remoteLink := range issue.Fields.RemoteLinks {
+		if !strings.Contains(remoteLink.Object.URL, host) {
+			continue
+		}
+		matches := pattern.FindAllString(remoteLink.Object.URL, -1)
+		urls = append(urls, matches...)
+		logger.Debug("Checked remote link: %s (title: %s)", remoteLink.Object.URL, remoteLink.Object.Title)
 	}
 
-	// Then try to extract direct ticket format (PROJECT-NUMBER)
-	ticketRegex := regexp.MustCompile(`([A-Z]+-\d+)`)
-	matches = ticketRegex.FindStringSubmatch(text)
-	if len(matches) >= 2 {
-		return matches[1]
+	// Check summaries of linked issues, where some integrations put PR URLs
+	if c.includeIssueLinkSummaries {
+		for _, link := range issue.Fields.IssueLinks {
+			if link.OutwardIssue == nil {
+				continue
+			}
+			matches := pattern.FindAllString(link.OutwardIssue.Fields.Summary, -1)
+			urls = append(urls, matches...)
+		}
 	}
 
-	return ""
+	// Remove duplicates
+	seen := make(map[string]bool)
+	var unique []string
+	for _, u := range urls {
+		if !seen[u] {
+			seen[u] = true
+			unique = append(unique, u)
+		}
+	}
+	return unique
 }
 
-// ExtractJiraTicketWithPrefix extracts JIRA ticket from text with specific project prefix.
-func ExtractJiraTicketWithPrefix(text, projectPrefix string) string {
-	urlPattern := fmt.Sprintf(`https://redhat\.atlassian\.net/browse/(%s-\d+)`, projectPrefix)
-	urlRegex := regexp.MustCompile(urlPattern)
-	matches := urlRegex.FindStringSubmatch(text)
-	if len(matches) >= 2 {
-		return matches[1]
+// Ping checks that the Jira server is reachable by reading its server info.
+func (c *Client) Ping(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, "GET", c.baseURL+"/rest/api/2/serverInfo", nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
 	}
 
-	// Then try to extract direct ticket format
-	ticketPattern := fmt.Sprintf(`(%s-\d+)`, projectPrefix)
-	ticketRegex := regexp.MustCompile(ticketPattern)
-	matches = ticketRegex.FindStringSubmatch(text)
-	if len(matches) >= 2 {
-		return matches[1]
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.email+":"+c.token)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
 	}
+	defer resp.Body.Close()
 
-	return ""
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get server info, status: %d", resp.StatusCode)
+	}
+	return nil
 }
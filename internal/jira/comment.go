@@ -0,0 +1,162 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/models"
+)
+
+// PostComment adds a comment to an issue. body is Jira wiki markup. With dryRun,
+// the comment is printed, labeled [DRY RUN], instead of being posted.
+func (c *Client) PostComment(ctx context.Context, dryRun bool, issueKey, body string) error {
+	if dryRun {
+		fmt.Printf("\n[DRY RUN] Would post this comment on %s:\n%s\n[DRY RUN] End of comment\n", issueKey, body)
+		return nil
+	}
+
+	logger.Debug("Posting comment to Jira issue: %s", issueKey)
+
+	payload, err := json.Marshal(map[string]string{"body": body})
+	if err != nil {
+		return fmt.Errorf("failed to marshal comment: %w", err)
+	}
+
+	url := fmt.Sprintf("%s/rest/api/2/issue/%s/comment", c.baseURL, issueKey)
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(c.email+":"+c.token)))
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("failed to post comment to %s, status: %d, body: %s", issueKey, resp.StatusCode, string(respBody))
+	}
+
+	return nil
+}
+
+// FormatAnalysisComment renders PR analysis results as a short wiki markup comment:
+// a table of the PRs with the release branches containing each, followed by the
+// release status of those branches. GitLab merge requests are listed after them,
+// without branch information.
+func FormatAnalysisComment(results []*models.PRAnalysisResult, mergeRequests []models.MRInfo) string {
+	var b strings.Builder
+	b.WriteString("h3. Release branch analysis\n")
+
+	if len(results) == 0 {
+		b.WriteString("No merged PRs from supported repositories were found.\n")
+		if len(mergeRequests) == 0 {
+			return b.String()
+		}
+		writeMergeRequests(&b, mergeRequests)
+		fmt.Fprintf(&b, "\n_Posted by pr-bot on %s_\n", time.Now().Format(models.DateFormat))
+		return b.String()
+	}
+
+	// Results arrive in completion order; list them by PR URL so reruns read the same
+	results = append([]*models.PRAnalysisResult(nil), results...)
+	sort.Slice(results, func(i, j int) bool { return results[i].PR.URL < results[j].PR.URL })
+
+	b.WriteString("||PR||Title||Found in||\n")
+	branches := make(map[string]models.BranchPresence)
+	for _, result := range results {
+		var found []string
+		for _, branch := range result.ReleaseBranches {
+			if !branch.Found {
+				continue
+			}
+			found = append(found, branch.BranchName)
+			if existing, ok := branches[branch.BranchName]; !ok || len(branch.UpcomingGAs) > len(existing.UpcomingGAs) {
+				branches[branch.BranchName] = branch
+			}
+		}
+		foundText := "not in any release branch yet"
+		if len(found) > 0 {
+			foundText = strings.Join(found, ", ")
+		}
+		fmt.Fprintf(&b, "|[#%d|%s]|%s|%s|\n", result.PR.Number, result.PR.URL, escapeWikiTableCell(result.PR.Title), foundText)
+	}
+
+	var names []string
+	for name := range branches {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var statusLines []string
+	for _, name := range names {
+		if status := branchReleaseStatus(branches[name]); status != "" {
+			statusLines = append(statusLines, fmt.Sprintf("* %s: %s", name, status))
+		}
+	}
+	if len(statusLines) > 0 {
+		b.WriteString("\n*Release status*\n")
+		b.WriteString(strings.Join(statusLines, "\n"))
+		b.WriteString("\n")
+	}
+	writeMergeRequests(&b, mergeRequests)
+
+	fmt.Fprintf(&b, "\n_Posted by pr-bot on %s_\n", time.Now().Format(models.DateFormat))
+	return b.String()
+}
+
+// writeMergeRequests lists GitLab merge requests, whose branches pr-bot does not check.
+func writeMergeRequests(b *strings.Builder, mergeRequests []models.MRInfo) {
+	if len(mergeRequests) == 0 {
+		return
+	}
+	b.WriteString("\n*GitLab merge requests* (release branches not checked)\n")
+	for _, mr := range mergeRequests {
+		fmt.Fprintf(b, "* [%s!%d|%s]\n", mr.Project, mr.IID, mr.URL)
+	}
+}
+
+// branchReleaseStatus summarizes the released versions or GA dates of a branch, one entry per product.
+func branchReleaseStatus(branch models.BranchPresence) string {
+	if len(branch.ReleasedVersions) > 0 {
+		return "released in " + strings.Join(branch.ReleasedVersions, ", ")
+	}
+
+	now := time.Now()
+	var parts []string
+	seen := make(map[string]bool)
+	// Prefer the first released version of each product, then the next planned one
+	for _, released := range []bool{true, false} {
+		for _, ga := range branch.UpcomingGAs {
+			if seen[ga.Product] || ga.GADate == nil || ga.GADate.Before(now) != released {
+				continue
+			}
+			seen[ga.Product] = true
+			state := "GA planned"
+			if released {
+				state = "released"
+			}
+			parts = append(parts, fmt.Sprintf("%s %s %s (%s)", ga.Product, ga.Version, state, models.FormatDate(ga.GADate)))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// escapeWikiTableCell keeps text from breaking a wiki markup table row.
+func escapeWikiTableCell(text string) string {
+	return strings.NewReplacer("|", "\\|", "\n", " ").Replace(text)
+}
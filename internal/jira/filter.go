@@ -0,0 +1,73 @@
+package jira
+
+import "strings"
+
+// JiraComponent is one entry of an issue's components field.
+type JiraComponent struct {
+	Name string `json:"name"`
+}
+
+// JiraUser is a Jira user, e.g. an issue's assignee. Jira Cloud identifies users
+// by AccountID, Jira Server by Name.
+type JiraUser struct {
+	Name         string `json:"name"`
+	AccountID    string `json:"accountId"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+// IssueFilter limits a ticket analysis to issues with a component and/or assignee.
+// Empty fields match every issue.
+type IssueFilter struct {
+	Component string // Component name, case-insensitive
+	Assignee  string // Assignee display name, email, username or account ID, case-insensitive
+}
+
+// IsZero reports whether the filter matches every issue.
+func (f IssueFilter) IsZero() bool {
+	return f.Component == "" && f.Assignee == ""
+}
+
+// Matches reports whether issue has the filter's component and assignee.
+func (f IssueFilter) Matches(issue JiraIssue) bool {
+	if f.Component != "" && !hasComponent(issue.Fields.Components, f.Component) {
+		return false
+	}
+	if f.Assignee != "" && !isAssignee(issue.Fields.Assignee, f.Assignee) {
+		return false
+	}
+	return true
+}
+
+// String describes the filter for display, e.g. "component=assisted-service assignee=jdoe".
+func (f IssueFilter) String() string {
+	var parts []string
+	if f.Component != "" {
+		parts = append(parts, "component="+f.Component)
+	}
+	if f.Assignee != "" {
+		parts = append(parts, "assignee="+f.Assignee)
+	}
+	return strings.Join(parts, " ")
+}
+
+func hasComponent(components []JiraComponent, name string) bool {
+	for _, component := range components {
+		if strings.EqualFold(component.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func isAssignee(user *JiraUser, assignee string) bool {
+	if user == nil {
+		return false
+	}
+	for _, id := range []string{user.DisplayName, user.EmailAddress, user.Name, user.AccountID} {
+		if id != "" && strings.EqualFold(id, assignee) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,160 @@
+package jira
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// IssueDependencyGraph is a DAG of "blocks" links between Jira issues.
+// An edge A -> B means A blocks B, so A's PRs should merge first.
+type IssueDependencyGraph struct {
+	keys  []string            // Issue keys in input order, used to keep the sort stable
+	index map[string]int      // Issue key -> position in keys
+	edges map[string][]string // Blocking issue key -> blocked issue keys
+}
+
+// BuildDependencyGraph builds a dependency graph from the "blocks" issue links of issues.
+// Links to issues outside the given set are ignored.
+func BuildDependencyGraph(issues []JiraIssue) (*IssueDependencyGraph, error) {
+	g := &IssueDependencyGraph{
+		index: make(map[string]int, len(issues)),
+		edges: make(map[string][]string),
+	}
+
+	for _, issue := range issues {
+		if issue.Key == "" {
+			return nil, fmt.Errorf("issue without a key cannot be added to the dependency graph")
+		}
+		if _, ok := g.index[issue.Key]; ok {
+			continue
+		}
+		g.index[issue.Key] = len(g.keys)
+		g.keys = append(g.keys, issue.Key)
+	}
+
+	seen := make(map[[2]string]bool)
+	for _, issue := range issues {
+		for _, link := range issue.Fields.IssueLinks {
+			if !isBlocksLink(link.Type) {
+				continue
+			}
+			// Outward: this issue blocks the linked one. Inward: the linked one blocks this issue.
+			from, to := issue.Key, ""
+			switch {
+			case link.OutwardIssue != nil:
+				to = link.OutwardIssue.Key
+			case link.InwardIssue != nil:
+				from, to = link.InwardIssue.Key, issue.Key
+			}
+			if _, ok := g.index[from]; !ok {
+				continue
+			}
+			if _, ok := g.index[to]; !ok || from == to {
+				continue
+			}
+			// Both ends of a link report it, so keep each edge once
+			if seen[[2]string{from, to}] {
+				continue
+			}
+			seen[[2]string{from, to}] = true
+			g.edges[from] = append(g.edges[from], to)
+		}
+	}
+
+	return g, nil
+}
+
+// isBlocksLink reports whether a link type expresses a blocking dependency.
+func isBlocksLink(linkType LinkType) bool {
+	return strings.EqualFold(linkType.Name, "Blocks") ||
+		strings.EqualFold(linkType.Outward, "blocks")
+}
+
+// HasEdges reports whether any issue blocks another.
+func (g *IssueDependencyGraph) HasEdges() bool {
+	return len(g.edges) > 0
+}
+
+// TopologicalSort orders issue keys so every issue comes after the issues blocking it,
+// using Kahn's algorithm. Independent issues keep their input order.
+func (g *IssueDependencyGraph) TopologicalSort() ([]string, error) {
+	inDegree := make(map[string]int, len(g.keys))
+	for _, targets := range g.edges {
+		for _, to := range targets {
+			inDegree[to]++
+		}
+	}
+
+	var ready []string
+	for _, key := range g.keys {
+		if inDegree[key] == 0 {
+			ready = append(ready, key)
+		}
+	}
+
+	order := make([]string, 0, len(g.keys))
+	for len(ready) > 0 {
+		key := ready[0]
+		ready = ready[1:]
+		order = append(order, key)
+
+		var unblocked []string
+		for _, to := range g.edges[key] {
+			inDegree[to]--
+			if inDegree[to] == 0 {
+				unblocked = append(unblocked, to)
+			}
+		}
+		ready = append(ready, unblocked...)
+		sort.SliceStable(ready, func(i, j int) bool {
+			return g.index[ready[i]] < g.index[ready[j]]
+		})
+	}
+
+	if len(order) != len(g.keys) {
+		var cyclic []string
+		for _, key := range g.keys {
+			if inDegree[key] > 0 {
+				cyclic = append(cyclic, key)
+			}
+		}
+		return nil, fmt.Errorf("dependency cycle between issues: %s", strings.Join(cyclic, ", "))
+	}
+
+	return order, nil
+}
+
+// MergeOrder lists PR URLs in the order their issues should be merged.
+// prURLsByIssue maps an issue key to its PR URLs; each URL is listed once.
+func (g *IssueDependencyGraph) MergeOrder(prURLsByIssue map[string][]string) ([]string, error) {
+	order, err := g.TopologicalSort()
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool)
+	var prURLs []string
+	for _, key := range order {
+		for _, prURL := range prURLsByIssue[key] {
+			if !seen[prURL] {
+				seen[prURL] = true
+				prURLs = append(prURLs, prURL)
+			}
+		}
+	}
+	return prURLs, nil
+}
+
+// SuggestedMergeOrder lists PR URLs in the order the issues' "blocks" links require,
+// or returns nil when no issue blocks another. PR URLs are filtered by the caller.
+func SuggestedMergeOrder(issues []JiraIssue, prURLsByIssue map[string][]string) ([]string, error) {
+	graph, err := BuildDependencyGraph(issues)
+	if err != nil {
+		return nil, err
+	}
+	if !graph.HasEdges() {
+		return nil, nil
+	}
+	return graph.MergeOrder(prURLsByIssue)
+}
@@ -0,0 +1,76 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// HighUrgencyMessage is shown when a P1 ticket still has PRs without confirmed backports.
+const HighUrgencyMessage = "⚠️ HIGH URGENCY: Missing backports for P1 ticket"
+
+// Priority levels that carry a backport SLA.
+const (
+	PriorityLevelNone = 0
+	PriorityLevelP1   = 1
+	PriorityLevelP2   = 2
+)
+
+// JiraPriority represents the priority field of a Jira issue.
+type JiraPriority struct {
+	Name    string `json:"name"`
+	IconURL string `json:"iconUrl"`
+}
+
+// Level maps the priority name to PriorityLevelP1, PriorityLevelP2 or PriorityLevelNone.
+// Both the "P1"/"P2" scheme and the Blocker/Critical scheme are recognized.
+func (p JiraPriority) Level() int {
+	return PriorityLevel(p.Name)
+}
+
+// PriorityLevel maps a Jira priority name to its SLA level.
+func PriorityLevel(name string) int {
+	name = strings.ToLower(strings.TrimSpace(name))
+	switch {
+	case strings.HasPrefix(name, "p1"), name == "blocker", name == "highest":
+		return PriorityLevelP1
+	case strings.HasPrefix(name, "p2"), name == "critical", name == "high":
+		return PriorityLevelP2
+	}
+	return PriorityLevelNone
+}
+
+// PriorityBadge returns a display badge for P1/P2 priorities, or an empty string.
+func PriorityBadge(name string) string {
+	switch PriorityLevel(name) {
+	case PriorityLevelP1:
+		return "🔴 P1"
+	case PriorityLevelP2:
+		return "🟡 P2"
+	}
+	return ""
+}
+
+// BackportSLA returns how soon backports are expected for a priority level.
+// Zero means no SLA applies.
+func BackportSLA(level int) time.Duration {
+	switch level {
+	case PriorityLevelP1:
+		return 48 * time.Hour
+	case PriorityLevelP2:
+		return 7 * 24 * time.Hour
+	}
+	return 0
+}
+
+// FormatSLA describes a backport SLA for display (e.g., "48h", "1 week").
+func FormatSLA(sla time.Duration) string {
+	week := 7 * 24 * time.Hour
+	if sla >= week && sla%week == 0 {
+		if sla == week {
+			return "1 week"
+		}
+		return fmt.Sprintf("%d weeks", sla/week)
+	}
+	return fmt.Sprintf("%dh", int(sla.Hours()))
+}
@@ -0,0 +1,250 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// RichText is a Jira rich-text field such as an issue description or comment body.
+// The v2 REST API returns these as wiki markup strings and the v3 API as Atlassian
+// Document Format (ADF) objects; RichText accepts both and keeps ADF as raw JSON.
+type RichText struct {
+	raw string // Wiki markup, or the ADF document as JSON
+	adf bool   // Whether Jira returned an ADF object rather than a string
+}
+
+// UnmarshalJSON accepts either a JSON string (wiki markup) or an ADF document.
+func (t *RichText) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		*t = RichText{}
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return err
+		}
+		*t = RichText{raw: s}
+	default:
+		*t = RichText{raw: string(data), adf: true}
+	}
+	return nil
+}
+
+// MarshalJSON writes the field back in the format Jira returned it in.
+func (t RichText) MarshalJSON() ([]byte, error) {
+	if t.adf {
+		return []byte(t.raw), nil
+	}
+	return json.Marshal(t.raw)
+}
+
+// PlainText renders the field as plain text, whichever format Jira returned it in.
+// An ADF document that cannot be parsed is returned unchanged.
+func (t RichText) PlainText() string {
+	if t.adf {
+		if text, err := RenderADF(t.raw); err == nil {
+			return text
+		}
+		return t.raw
+	}
+	return RenderWikiMarkup(t.raw)
+}
+
+// adfNode is a node of an Atlassian Document Format document.
+type adfNode struct {
+	Type    string         `json:"type"`
+	Text    string         `json:"text,omitempty"`
+	Attrs   map[string]any `json:"attrs,omitempty"`
+	Marks   []adfNode      `json:"marks,omitempty"`
+	Content []adfNode      `json:"content,omitempty"`
+}
+
+// attr returns a string attribute of the node, or "" if it is missing.
+func (n adfNode) attr(name string) string {
+	value, _ := n.Attrs[name].(string)
+	return value
+}
+
+// adfBlockTypes are the ADF nodes rendered on their own line.
+var adfBlockTypes = map[string]bool{
+	"paragraph":  true,
+	"heading":    true,
+	"codeBlock":  true,
+	"blockquote": true,
+	"panel":      true,
+	"rule":       true,
+	"blockCard":  true,
+	"embedCard":  true,
+	"mediaGroup": true,
+	"tableRow":   true,
+}
+
+// RenderADF renders an Atlassian Document Format document as plain text.
+// Links keep their target URL so PR links inside the document stay visible.
+func RenderADF(adfJSON string) (string, error) {
+	var doc adfNode
+	if err := json.Unmarshal([]byte(adfJSON), &doc); err != nil {
+		return "", fmt.Errorf("failed to parse ADF document: %w", err)
+	}
+
+	var b strings.Builder
+	renderADFNode(&b, doc, "")
+	return strings.TrimSpace(collapseBlankLines(b.String())), nil
+}
+
+// renderADFNode writes node and its children to b. prefix is written before
+// list items so nested lists stay readable.
+func renderADFNode(b *strings.Builder, node adfNode, prefix string) {
+	switch node.Type {
+	case "text":
+		b.WriteString(node.Text)
+		for _, mark := range node.Marks {
+			if mark.Type == "link" {
+				if href := mark.attr("href"); href != "" && href != node.Text {
+					fmt.Fprintf(b, " (%s)", href)
+				}
+			}
+		}
+		return
+	case "hardBreak":
+		b.WriteString("\n")
+		return
+	case "mention":
+		if text := node.attr("text"); text != "" {
+			if !strings.HasPrefix(text, "@") {
+				b.WriteString("@")
+			}
+			b.WriteString(text)
+		} else {
+			b.WriteString("@" + node.attr("id"))
+		}
+		return
+	case "emoji":
+		if text := node.attr("text"); text != "" {
+			b.WriteString(text)
+		} else {
+			b.WriteString(node.attr("shortName"))
+		}
+		return
+	case "inlineCard":
+		b.WriteString(node.attr("url"))
+		return
+	case "link":
+		// Not part of the ADF schema (links are text marks), but some integrations emit it
+		href := node.attr("href")
+		if href == "" {
+			href = node.attr("url")
+		}
+		if len(node.Content) > 0 {
+			for _, child := range node.Content {
+				renderADFNode(b, child, prefix)
+			}
+			if href != "" {
+				fmt.Fprintf(b, " (%s)", href)
+			}
+		} else {
+			b.WriteString(href)
+		}
+		return
+	case "blockCard", "embedCard":
+		b.WriteString(node.attr("url"))
+		b.WriteString("\n")
+		return
+	case "rule":
+		b.WriteString("---\n")
+		return
+	case "bulletList", "orderedList":
+		for i, item := range node.Content {
+			marker := "- "
+			if node.Type == "orderedList" {
+				marker = fmt.Sprintf("%d. ", i+1)
+			}
+			b.WriteString(prefix + marker)
+			for _, child := range item.Content {
+				if child.Type == "bulletList" || child.Type == "orderedList" {
+					renderADFNode(b, child, prefix+"  ")
+					continue
+				}
+				renderADFNode(b, child, prefix)
+			}
+			if !strings.HasSuffix(b.String(), "\n") {
+				b.WriteString("\n")
+			}
+		}
+		return
+	case "tableCell", "tableHeader":
+		for _, child := range node.Content {
+			renderADFNode(b, child, prefix)
+		}
+		b.WriteString("\t")
+		return
+	}
+
+	for _, child := range node.Content {
+		renderADFNode(b, child, prefix)
+	}
+	if adfBlockTypes[node.Type] {
+		b.WriteString("\n")
+	}
+}
+
+// Wiki markup patterns handled by RenderWikiMarkup.
+var (
+	wikiLinkRegex    = regexp.MustCompile(`\[([^\[\]|]*)\|([^\[\]|]+?)(?:\|[^\[\]]*)?\]`)
+	wikiBareRegex    = regexp.MustCompile(`\[((?:https?|mailto):[^\[\]|]+)\]`)
+	wikiMentionRegex = regexp.MustCompile(`\[~(?:accountid:)?([^\[\]]+)\]`)
+	wikiMacroRegex   = regexp.MustCompile(`\{(?:code|noformat|quote|panel|color)(?::[^}]*)?\}`)
+	wikiHeadingRegex = regexp.MustCompile(`(?m)^h[1-6]\.\s*`)
+	wikiListRegex    = regexp.MustCompile(`(?m)^([*#]+)\s+`)
+	wikiQuoteRegex   = regexp.MustCompile(`(?m)^bq\.\s*`)
+)
+
+// RenderWikiMarkup renders Jira wiki markup as plain text. Links become
+// "text (url)", mentions become "@user" and formatting macros are dropped.
+func RenderWikiMarkup(wiki string) string {
+	text := strings.ReplaceAll(wiki, "\r\n", "\n")
+
+	text = wikiMentionRegex.ReplaceAllString(text, "@$1")
+	text = wikiLinkRegex.ReplaceAllStringFunc(text, func(link string) string {
+		parts := wikiLinkRegex.FindStringSubmatch(link)
+		label, target := strings.TrimSpace(parts[1]), strings.TrimSpace(parts[2])
+		if label == "" || label == target {
+			return target
+		}
+		return fmt.Sprintf("%s (%s)", label, target)
+	})
+	text = wikiBareRegex.ReplaceAllString(text, "$1")
+	text = wikiMacroRegex.ReplaceAllString(text, "")
+	text = wikiHeadingRegex.ReplaceAllString(text, "")
+	text = wikiQuoteRegex.ReplaceAllString(text, "")
+	text = wikiListRegex.ReplaceAllStringFunc(text, func(marker string) string {
+		depth := len(strings.TrimSpace(marker))
+		return strings.Repeat("  ", depth-1) + "- "
+	})
+
+	return strings.TrimSpace(collapseBlankLines(text))
+}
+
+// collapseBlankLines trims trailing spaces and keeps at most one blank line in a row.
+func collapseBlankLines(text string) string {
+	lines := strings.Split(text, "\n")
+	out := make([]string, 0, len(lines))
+	blank := false
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			if blank {
+				continue
+			}
+			blank = true
+		} else {
+			blank = false
+		}
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
@@ -0,0 +1,47 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRichTextPlainText(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+		want string
+	}{
+		{
+			name: "wiki markup",
+			json: `"Fixed by [PR|https://github.com/openshift/assisted-service/pull/1]"`,
+			want: "Fixed by PR (https://github.com/openshift/assisted-service/pull/1)",
+		},
+		{
+			name: "wiki markup starting with a macro",
+			json: `"{code}make test{code}"`,
+			want: "make test",
+		},
+		{
+			name: "ADF document",
+			json: `{"type": "doc", "content": [{"type": "paragraph", "content": [{"type": "text", "text": "Fixed"}]}]}`,
+			want: "Fixed",
+		},
+		{
+			name: "null",
+			json: `null`,
+			want: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var text RichText
+			if err := json.Unmarshal([]byte(tt.json), &text); err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+			if got := text.PlainText(); got != tt.want {
+				t.Errorf("PlainText() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package jira
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// DefaultProjects are the project keys recognized in PR titles when PR_BOT_JIRA_PROJECTS is not set.
+var DefaultProjects = []string{"MGMT"}
+
+// TicketMatcher finds JIRA ticket keys of a set of projects in free text.
+// It is compiled once and safe for concurrent use.
+type TicketMatcher struct {
+	urlRegex    *regexp.Regexp // Browse URLs, preferred over bare keys
+	ticketRegex *regexp.Regexp // Bare keys such as MGMT-20662
+}
+
+// NewTicketMatcher builds a matcher for the given project keys.
+// With no projects it matches tickets of any project.
+func NewTicketMatcher(projects []string) *TicketMatcher {
+	keyPattern := `[A-Z][A-Z0-9]*`
+	var keys []string
+	for _, project := range projects {
+		if project = strings.ToUpper(strings.TrimSpace(project)); project != "" {
+			keys = append(keys, regexp.QuoteMeta(project))
+		}
+	}
+	if len(keys) > 0 {
+		keyPattern = "(?:" + strings.Join(keys, "|") + ")"
+	}
+
+	return &TicketMatcher{
+		urlRegex:    regexp.MustCompile(fmt.Sprintf(`%s/browse/(%s-\d+)`, regexp.QuoteMeta(DefaultBaseURL), keyPattern)),
+		ticketRegex: regexp.MustCompile(fmt.Sprintf(`\b(%s-\d+)\b`, keyPattern)),
+	}
+}
+
+// Match returns the first ticket key in text, or "" if there is none.
+// A browse URL wins over a bare key mentioned earlier in the text.
+func (m *TicketMatcher) Match(text string) string {
+	if matches := m.urlRegex.FindStringSubmatch(text); len(matches) >= 2 {
+		return matches[1]
+	}
+	if matches := m.ticketRegex.FindStringSubmatch(text); len(matches) >= 2 {
+		return matches[1]
+	}
+	return ""
+}
+
+// Shared matchers for the package-level helpers.
+var (
+	anyProjectMatcher = NewTicketMatcher(nil)
+	mgmtMatcher       = NewTicketMatcher(DefaultProjects)
+)
+
+// ExtractTicketFromTitle extracts a ticket of one of the client's configured
+// projects (PR_BOT_JIRA_PROJECTS, default MGMT) from a PR title.
+func (c *Client) ExtractTicketFromTitle(title string) string {
+	return c.tickets.Match(title)
+}
+
+// ExtractMGMTTicketFromTitle extracts MGMT ticket number from PR title.
+func ExtractMGMTTicketFromTitle(title string) string {
+	return mgmtMatcher.Match(title)
+}
+
+// ExtractJiraTicketFromText extracts JIRA ticket from text with any project prefix.
+// It supports URLs like https://redhat.atlassian.net/browse/ACM-22787 and direct ticket IDs like MGMT-20662
+func ExtractJiraTicketFromText(text string) string {
+	return anyProjectMatcher.Match(text)
+}
+
+// ExtractJiraTicketWithPrefix extracts JIRA ticket from text with specific project prefix.
+func ExtractJiraTicketWithPrefix(text, projectPrefix string) string {
+	return NewTicketMatcher([]string{projectPrefix}).Match(text)
+}
@@ -1,37 +1,116 @@
 // Package logger provides logging functionality with debug and info levels for the merged-pr-bot application.
+// Messages go through log/slog, so they can be written as text or JSON and carry structured attributes.
 package logger
 
 import (
-	"log"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
 	"os"
+	"sync"
+)
+
+// Log formats accepted by SetFormat.
+const (
+	FormatText = "text"
+	FormatJSON = "json"
 )
 
 var (
-	debugMode   bool
-	debugLogger *log.Logger
-	infoLogger  *log.Logger
+	level = new(slog.LevelVar) // Info unless debug logging is enabled
+
+	mu        sync.Mutex // guards output and logFormat
+	output    io.Writer  = os.Stdout
+	logFormat            = FormatText
 )
 
+// init honours PR_BOT_LOG_FORMAT for messages logged before the configuration is
+// loaded; config.Load applies the configured format again.
 func init() {
-	debugLogger = log.New(os.Stdout, "[DEBUG] ", log.LstdFlags)
-	infoLogger = log.New(os.Stdout, "", 0) // No prefix for info messages
+	if format := os.Getenv("PR_BOT_LOG_FORMAT"); format == FormatJSON {
+		logFormat = format
+	}
+	installHandler()
+}
+
+// installHandler makes a handler for the current output and format the slog default.
+// Callers must hold mu, except during init.
+func installHandler() {
+	opts := &slog.HandlerOptions{Level: level}
+	var handler slog.Handler
+	if logFormat == FormatJSON {
+		handler = slog.NewJSONHandler(output, opts)
+	} else {
+		handler = slog.NewTextHandler(output, opts)
+	}
+	slog.SetDefault(slog.New(handler))
+}
+
+// SetLevel sets the minimum level of messages that are logged.
+func SetLevel(l slog.Level) {
+	level.Set(l)
 }
 
 // SetDebugMode enables or disables debug logging.
 func SetDebugMode(enabled bool) {
-	debugMode = enabled
+	if enabled {
+		SetLevel(slog.LevelDebug)
+	} else {
+		SetLevel(slog.LevelInfo)
+	}
+}
+
+// SetFormat switches between text ("text", the default) and JSON ("json") log lines.
+func SetFormat(format string) error {
+	if format == "" {
+		format = FormatText
+	}
+	if format != FormatText && format != FormatJSON {
+		return fmt.Errorf("unknown log format %q (use %s or %s)", format, FormatText, FormatJSON)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if format != logFormat {
+		logFormat = format
+		installHandler()
+	}
+	return nil
+}
+
+// SetOutput sends log messages to w instead of stdout.
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	defer mu.Unlock()
+	output = w
+	installHandler()
+}
+
+// With returns a logger that adds attrs to every message, e.g. a component name or
+// request ID. It keeps the output and format in effect when it is called.
+func With(attrs ...slog.Attr) *slog.Logger {
+	return slog.New(slog.Default().Handler().WithAttrs(attrs))
+}
+
+// logf formats and logs a message at lvl through the default slog logger.
+func logf(lvl slog.Level, format string, args ...interface{}) {
+	l := slog.Default()
+	ctx := context.Background()
+	if !l.Enabled(ctx, lvl) {
+		return
+	}
+	l.Log(ctx, lvl, fmt.Sprintf(format, args...))
 }
 
 // Debug logs debug messages only if debug mode is enabled.
 func Debug(format string, args ...interface{}) {
-	if debugMode {
-		debugLogger.Printf(format, args...)
-	}
+	logf(slog.LevelDebug, format, args...)
 }
 
 // Info logs info messages always.
 func Info(format string, args ...interface{}) {
-	infoLogger.Printf(format, args...)
+	logf(slog.LevelInfo, format, args...)
 }
 
 // Printf is an alias for Info for compatibility.
@@ -0,0 +1,15 @@
+// Package metrics holds the Prometheus metrics shared between packages.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// DefaultDurationBuckets are histogram buckets in seconds suited to PR and JIRA
+// analyses, which take from a second to several minutes.
+var DefaultDurationBuckets = []float64{1, 2.5, 5, 10, 20, 30, 60, 120, 300}
+
+// GitHubRequests counts GitHub API requests by HTTP status code ("error" when no
+// response was received). The GitHub client records every attempt, including retries.
+var GitHubRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+	Name: "pr_bot_github_api_requests_total",
+	Help: "GitHub API requests by HTTP status code, including retries.",
+}, []string{"code"})
@@ -0,0 +1,30 @@
+package models
+
+// Default worker counts used when a ConcurrencyConfig field is not set.
+const (
+	DefaultBranchCheckWorkers   = 10
+	DefaultPRAnalysisWorkers    = 5
+	DefaultMCEValidationWorkers = 5
+)
+
+// ConcurrencyConfig bounds how many API-heavy operations run at once. Large
+// environments can raise the limits; rate-limited ones should lower them.
+type ConcurrencyConfig struct {
+	BranchCheckWorkers   int `json:"branch_check_workers"`   // Release branches checked at once for one PR (PR_BOT_BRANCH_WORKERS)
+	PRAnalysisWorkers    int `json:"pr_analysis_workers"`    // PRs analyzed at once for -jt, -prs-file and /jt (PR_BOT_PR_WORKERS)
+	MCEValidationWorkers int `json:"mce_validation_workers"` // GA snapshots validated at once for one PR (PR_BOT_MCE_WORKERS)
+}
+
+// WithDefaults returns c with unset (zero or negative) fields replaced by the defaults.
+func (c ConcurrencyConfig) WithDefaults() ConcurrencyConfig {
+	if c.BranchCheckWorkers <= 0 {
+		c.BranchCheckWorkers = DefaultBranchCheckWorkers
+	}
+	if c.PRAnalysisWorkers <= 0 {
+		c.PRAnalysisWorkers = DefaultPRAnalysisWorkers
+	}
+	if c.MCEValidationWorkers <= 0 {
+		c.MCEValidationWorkers = DefaultMCEValidationWorkers
+	}
+	return c
+}
@@ -0,0 +1,147 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// AnalysisDiff describes what changed between two analyses of the same PR.
+type AnalysisDiff struct {
+	NewBranches     []BranchPresence `json:"new_branches" yaml:"new_branches"`         // Branches that now contain the PR
+	RemovedBranches []BranchPresence `json:"removed_branches" yaml:"removed_branches"` // Branches that no longer contain the PR
+	GADateChanges   []GADateChange   `json:"ga_date_changes" yaml:"ga_date_changes"`   // GA dates that moved between analyses
+
+	UpcomingGAChanges []UpcomingGAChange `json:"upcoming_ga_changes" yaml:"upcoming_ga_changes"` // Branches whose upcoming GAs differ
+}
+
+// UpcomingGAChange records a branch whose upcoming GA versions or dates differ
+// between two analyses.
+type UpcomingGAChange struct {
+	BranchName string       `json:"branch_name" yaml:"branch_name"`
+	Before     []UpcomingGA `json:"before" yaml:"before"`
+	After      []UpcomingGA `json:"after" yaml:"after"`
+}
+
+// GADateChange records a GA date that differs between two analyses of a branch.
+type GADateChange struct {
+	BranchName string     `json:"branch_name" yaml:"branch_name"`
+	Product    string     `json:"product" yaml:"product"` // "ACM" or "MCE"
+	Version    string     `json:"version" yaml:"version"`
+	OldDate    *time.Time `json:"old_date" yaml:"-"`
+	NewDate    *time.Time `json:"new_date" yaml:"-"`
+}
+
+// HasChanges reports whether the diff contains any changes.
+func (d *AnalysisDiff) HasChanges() bool {
+	return d != nil && (len(d.NewBranches) > 0 || len(d.RemovedBranches) > 0 || len(d.GADateChanges) > 0 || len(d.UpcomingGAChanges) > 0)
+}
+
+// HasBranchChanges reports whether any release branch appeared or disappeared.
+func (d *AnalysisDiff) HasBranchChanges() bool {
+	return d != nil && (len(d.NewBranches) > 0 || len(d.RemovedBranches) > 0)
+}
+
+// DiffAnalysisResults compares two analysis results for the same PR.
+// Only branches where the PR was found are considered present.
+func DiffAnalysisResults(before, after *PRAnalysisResult) *AnalysisDiff {
+	diff := &AnalysisDiff{}
+	if before == nil || after == nil {
+		return diff
+	}
+
+	beforeBranches := foundBranches(before.ReleaseBranches)
+	afterBranches := foundBranches(after.ReleaseBranches)
+
+	for _, branch := range after.ReleaseBranches {
+		if !branch.Found {
+			continue
+		}
+		previous, existed := beforeBranches[branch.BranchName]
+		if !existed {
+			diff.NewBranches = append(diff.NewBranches, branch)
+			continue
+		}
+		diff.GADateChanges = append(diff.GADateChanges, gaDateChanges(previous, branch)...)
+		if FormatUpcomingGAs(previous.UpcomingGAs) != FormatUpcomingGAs(branch.UpcomingGAs) {
+			diff.UpcomingGAChanges = append(diff.UpcomingGAChanges, UpcomingGAChange{
+				BranchName: branch.BranchName,
+				Before:     previous.UpcomingGAs,
+				After:      branch.UpcomingGAs,
+			})
+		}
+	}
+
+	for _, branch := range before.ReleaseBranches {
+		if !branch.Found {
+			continue
+		}
+		if _, stillPresent := afterBranches[branch.BranchName]; !stillPresent {
+			diff.RemovedBranches = append(diff.RemovedBranches, branch)
+		}
+	}
+
+	return diff
+}
+
+// foundBranches indexes the branches that contain the PR by name.
+func foundBranches(branches []BranchPresence) map[string]BranchPresence {
+	found := make(map[string]BranchPresence)
+	for _, branch := range branches {
+		if branch.Found {
+			found[branch.BranchName] = branch
+		}
+	}
+	return found
+}
+
+// gaDateChanges compares the ACM and MCE GA dates of the same branch.
+func gaDateChanges(before, after BranchPresence) []GADateChange {
+	var changes []GADateChange
+	pairs := []struct {
+		product       string
+		before, after GAInfo
+	}{
+		{"ACM", before.GAStatus.ACM, after.GAStatus.ACM},
+		{"MCE", before.GAStatus.MCE, after.GAStatus.MCE},
+	}
+
+	for _, pair := range pairs {
+		if !sameDate(pair.before.GADate, pair.after.GADate) {
+			changes = append(changes, GADateChange{
+				BranchName: after.BranchName,
+				Product:    pair.product,
+				Version:    pair.after.Version,
+				OldDate:    pair.before.GADate,
+				NewDate:    pair.after.GADate,
+			})
+		}
+	}
+
+	return changes
+}
+
+// sameDate reports whether two optional dates fall on the same day.
+func sameDate(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return FormatDate(a) == FormatDate(b)
+}
+
+// FormatUpcomingGAs lists upcoming GAs as "ACM 2.14.1 (07-01-2025), MCE 2.9.1 (07-01-2025)",
+// or "none".
+func FormatUpcomingGAs(gas []UpcomingGA) string {
+	if len(gas) == 0 {
+		return "none"
+	}
+	parts := make([]string, 0, len(gas))
+	for _, ga := range gas {
+		part := ga.Product + " " + ga.Version
+		if ga.GADate != nil {
+			part += fmt.Sprintf(" (%s)", FormatDate(ga.GADate))
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,55 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+// FilterBranchesByLabels keeps only the release branches, of the PR and of its
+// related PRs, named by one of the PR's labels matching pattern. A label names a
+// branch when it ends with the branch name or version, so "backport-4.15" names
+// release-4.15.
+func (r *PRAnalysisResult) FilterBranchesByLabels(pattern *regexp.Regexp) {
+	var labels []string
+	for _, label := range r.PR.Labels {
+		if pattern.MatchString(label) {
+			labels = append(labels, label)
+		}
+	}
+
+	r.ReleaseBranches = branchesNamedByLabels(r.ReleaseBranches, labels)
+	for i := range r.RelatedPRs {
+		r.RelatedPRs[i].ReleaseBranches = branchesNamedByLabels(r.RelatedPRs[i].ReleaseBranches, labels)
+	}
+}
+
+// branchesNamedByLabels returns the branches named by at least one of labels.
+func branchesNamedByLabels(branches []BranchPresence, labels []string) []BranchPresence {
+	var kept []BranchPresence
+	for _, branch := range branches {
+		for _, label := range labels {
+			if labelNamesBranch(label, branch) {
+				kept = append(kept, branch)
+				break
+			}
+		}
+	}
+	return kept
+}
+
+// labelNamesBranch reports whether label ends with the branch name or version, not
+// counting a match inside a longer version ("backport-14.15" does not name 4.15).
+func labelNamesBranch(label string, branch BranchPresence) bool {
+	for _, name := range []string{branch.BranchName, branch.Version} {
+		if name == "" || !strings.HasSuffix(label, name) {
+			continue
+		}
+		if len(label) == len(name) {
+			return true
+		}
+		if before := label[len(label)-len(name)-1]; before != '.' && (before < '0' || before > '9') {
+			return true
+		}
+	}
+	return false
+}
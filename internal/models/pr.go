@@ -2,6 +2,9 @@
 package models
 
 import (
+	"encoding/json"
+	"fmt"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -30,117 +33,358 @@ func FormatDateWithNil(t *time.Time) string {
 
 // PRInfo represents information about a pull request.
 type PRInfo struct {
-	Number     int        `json:"number"`
-	Title      string     `json:"title"`
-	Hash       string     `json:"hash"`
-	MergedAt   *time.Time `json:"merged_at,omitempty"`
-	MergedInto string     `json:"merged_into"`
-	URL        string     `json:"url"`
+	Number     int        `json:"number" yaml:"number"`
+	Title      string     `json:"title" yaml:"title"`
+	Hash       string     `json:"hash" yaml:"hash"`
+	MergedAt   *time.Time `json:"merged_at,omitempty" yaml:"-"`
+	MergedInto string     `json:"merged_into" yaml:"merged_into"`
+	URL        string     `json:"url" yaml:"url"`
+	Author     string     `json:"author,omitempty" yaml:"author,omitempty"` // GitHub login of the PR author
+
+	IsHotfix     bool     `json:"is_hotfix,omitempty" yaml:"is_hotfix,omitempty"`         // Title contains [hotfix] or [skip-N.N], or PR has the hotfix label
+	SkipBranches []string `json:"skip_branches,omitempty" yaml:"skip_branches,omitempty"` // Branch versions from [skip-N.N] markers (e.g., "4.15")
+	CoAuthors    []string `json:"co_authors,omitempty" yaml:"co_authors,omitempty"`       // Names from Co-authored-by trailers of the merge commit
+	IsDraft      bool     `json:"is_draft,omitempty" yaml:"is_draft,omitempty"`           // PR is still a draft (only meaningful for unmerged PRs)
+	Labels       []string `json:"labels,omitempty" yaml:"labels,omitempty"`               // Names of the PR's GitHub labels
+}
+
+// ReviewStatus returns the status of an unmerged PR: StatusDraft for drafts, StatusInReview otherwise.
+func (p *PRInfo) ReviewStatus() PRStatus {
+	if p.IsDraft {
+		return StatusDraft
+	}
+	return StatusInReview
+}
+
+// SkipsBranchVersion reports whether a hotfix PR intentionally skips the given branch version.
+func (p *PRInfo) SkipsBranchVersion(version string) bool {
+	version = strings.TrimPrefix(version, "v")
+	for _, skipped := range p.SkipBranches {
+		if skipped == version {
+			return true
+		}
+	}
+	return false
+}
+
+// MRInfo represents a GitLab merge request linked from a JIRA ticket. Only the
+// URL is known; branch presence is not checked for merge requests.
+type MRInfo struct {
+	Project string `json:"project"` // Project path, e.g. "group/subgroup/project"
+	IID     int    `json:"iid"`     // Merge request number within the project
+	URL     string `json:"url"`
+}
+
+// ParseMRURL parses a GitLab merge request URL such as
+// https://gitlab.example.com/group/project/-/merge_requests/42.
+func ParseMRURL(mrURL string) (*MRInfo, error) {
+	rest, found := strings.CutPrefix(mrURL, "https://")
+	if !found {
+		return nil, fmt.Errorf("invalid merge request URL: %s", mrURL)
+	}
+	path, number, found := strings.Cut(rest, "/merge_requests/")
+	if !found {
+		return nil, fmt.Errorf("invalid merge request URL: %s", mrURL)
+	}
+	iid, err := strconv.Atoi(strings.TrimSuffix(number, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid merge request number in %s: %w", mrURL, err)
+	}
+
+	// Drop the host and the "/-" GitLab puts before project sub-pages
+	_, project, _ := strings.Cut(path, "/")
+	project = strings.TrimSuffix(project, "/-")
+	if project == "" {
+		return nil, fmt.Errorf("invalid merge request URL: %s", mrURL)
+	}
+	return &MRInfo{Project: project, IID: iid, URL: mrURL}, nil
 }
 
 // BranchPresence represents PR presence in a release branch.
 type BranchPresence struct {
-	BranchName       string       `json:"branch_name"`
-	Pattern          string       `json:"pattern"` // "release-ocm-", "release-", "release-v", or "v"
-	Version          string       `json:"version"`
-	MergedAt         *time.Time   `json:"merged_at,omitempty"`
-	Found            bool         `json:"found"`
-	ReleasedVersions []string     `json:"released_versions,omitempty"` // Exact release versions (e.g., v2.40.1, v2.40.2)
-	GAStatus         GAStatus     `json:"ga_status"`
-	UpcomingGAs      []UpcomingGA `json:"upcoming_gas,omitempty"`
+	BranchName       string       `json:"branch_name" yaml:"branch_name"`
+	Pattern          string       `json:"pattern" yaml:"pattern"` // "release-ocm-", "release-", "release-v", or "v"
+	Version          string       `json:"version" yaml:"version"`
+	MergedAt         *time.Time   `json:"merged_at,omitempty" yaml:"-"`
+	Found            bool         `json:"found" yaml:"found"`
+	ReleasedVersions []string     `json:"released_versions,omitempty" yaml:"released_versions,omitempty"` // Exact release versions (e.g., v2.40.1, v2.40.2)
+	GAStatus         GAStatus     `json:"ga_status" yaml:"ga_status"`
+	UpcomingGAs      []UpcomingGA `json:"upcoming_gas,omitempty" yaml:"upcoming_gas,omitempty"`
+	Skipped          bool         `json:"skipped,omitempty" yaml:"skipped,omitempty"` // Not checked because a hotfix PR skips this version
+
+	FoundViaCherryPick bool `json:"found_via_cherry_pick,omitempty" yaml:"found_via_cherry_pick,omitempty"` // Found as a cherry-pick of the merge commit, not the commit itself
+}
+
+// CherryPickNote returns " (cherry-pick)" for branches found via a cherry-pick, for
+// display after the branch name, and "" otherwise.
+func (b BranchPresence) CherryPickNote() string {
+	if b.FoundViaCherryPick {
+		return " (cherry-pick)"
+	}
+	return ""
+}
+
+// SkipNote describes a branch that was intentionally skipped by a hotfix PR.
+func (b BranchPresence) SkipNote() string {
+	return fmt.Sprintf("(hotfix – branch %s intentionally skipped)", b.Version)
 }
 
 // GAStatus represents GA status for both ACM and MCE.
 type GAStatus struct {
-	ACM     GAInfo `json:"acm"`
-	MCE     GAInfo `json:"mce"`
-	NextACM GAInfo `json:"next_acm"`
-	NextMCE GAInfo `json:"next_mce"`
+	ACM     GAInfo `json:"acm" yaml:"acm"`
+	MCE     GAInfo `json:"mce" yaml:"mce"`
+	NextACM GAInfo `json:"next_acm" yaml:"next_acm"`
+	NextMCE GAInfo `json:"next_mce" yaml:"next_mce"`
 }
 
 // GAInfo represents GA information for a specific product.
 type GAInfo struct {
-	Version  string     `json:"version"`
-	GADate   *time.Time `json:"ga_date,omitempty"`
-	IsGA     bool       `json:"is_ga"`
-	IsInNext bool       `json:"is_in_next"`
-	Status   string     `json:"status"` // "GA", "Next Version", "Not Found", "Merged but not GA"
+	Version  string     `json:"version" yaml:"version"`
+	GADate   *time.Time `json:"ga_date,omitempty" yaml:"-"`
+	IsGA     bool       `json:"is_ga" yaml:"is_ga"`
+	IsInNext bool       `json:"is_in_next" yaml:"is_in_next"`
+	Status   string     `json:"status" yaml:"status"` // "GA", "Next Version", "Not Found", "Merged but not GA"
 }
 
 // UpcomingGA represents upcoming GA versions after a merge date.
 type UpcomingGA struct {
-	Product       string                 `json:"product"` // "ACM" or "MCE"
-	Version       string                 `json:"version"`
-	GADate        *time.Time             `json:"ga_date"`
-	MCEValidation *MCESnapshotValidation `json:"mce_validation,omitempty"` // MCE snapshot validation result
+	Product       string                 `json:"product" yaml:"product"` // "ACM" or "MCE"
+	Version       string                 `json:"version" yaml:"version"`
+	GADate        *time.Time             `json:"ga_date" yaml:"-"`
+	MCEValidation *MCESnapshotValidation `json:"mce_validation,omitempty" yaml:"mce_validation,omitempty"` // MCE snapshot validation result
 }
 
 // MCESnapshotValidation represents the result of MCE snapshot validation.
 type MCESnapshotValidation struct {
-	Product            string     `json:"product"`              // "ACM" or "MCE"
-	Version            string     `json:"version"`              // e.g., "2.8.1"
-	GADate             *time.Time `json:"ga_date"`              // GA date
-	MCEBranch          string     `json:"mce_branch"`           // e.g., "mce-2.8"
-	SnapshotFolder     string     `json:"snapshot_folder"`      // e.g., "2025-03-14-18-55-26"
-	ValidationSuccess  bool       `json:"validation_success"`   // Whether validation passed
-	ComponentName      string     `json:"component_name"`       // e.g., "assisted-service", "assisted-installer", "assisted-installer-agent", "assisted-installer-ui"
-	AssistedServiceSHA string     `json:"assisted_service_sha"` // SHA from down-sha.yaml
-	PRCommitBeforeSHA  bool       `json:"pr_commit_before_sha"` // Whether PR commit is before the SHA
-	ErrorMessage       string     `json:"error_message"`        // Error details if validation failed
+	Product            string     `json:"product" yaml:"product"`                           // "ACM" or "MCE"
+	Version            string     `json:"version" yaml:"version"`                           // e.g., "2.8.1"
+	GADate             *time.Time `json:"ga_date" yaml:"-"`                                 // GA date
+	MCEBranch          string     `json:"mce_branch" yaml:"mce_branch"`                     // e.g., "mce-2.8"
+	SnapshotFolder     string     `json:"snapshot_folder" yaml:"snapshot_folder"`           // e.g., "2025-03-14-18-55-26"
+	ValidationSuccess  bool       `json:"validation_success" yaml:"validation_success"`     // Whether validation passed
+	ComponentName      string     `json:"component_name" yaml:"component_name"`             // e.g., "assisted-service", "assisted-installer", "assisted-installer-agent", "assisted-installer-ui"
+	AssistedServiceSHA string     `json:"assisted_service_sha" yaml:"assisted_service_sha"` // SHA from down-sha.yaml
+	PRCommitBeforeSHA  bool       `json:"pr_commit_before_sha" yaml:"pr_commit_before_sha"` // Whether PR commit is before the SHA
+	ErrorMessage       string     `json:"error_message" yaml:"error_message"`               // Error details if validation failed
 }
 
 // PRAnalysisResult represents the complete analysis result.
 type PRAnalysisResult struct {
-	PR                   PRInfo           `json:"pr"`
-	ReleaseBranches      []BranchPresence `json:"release_branches"`
-	AnalyzedAt           time.Time        `json:"analyzed_at"`
-	JiraAnalysis         *JiraAnalysis    `json:"jira_analysis,omitempty"`
-	RelatedPRs           []RelatedPR      `json:"related_prs,omitempty"`
-	SheetsUnavailable    bool             `json:"sheets_unavailable,omitempty"`
+	PR                PRInfo           `json:"pr" yaml:"pr"`
+	ReleaseBranches   []BranchPresence `json:"release_branches" yaml:"release_branches"`
+	AnalyzedAt        time.Time        `json:"analyzed_at" yaml:"analyzed_at"`
+	JiraAnalysis      *JiraAnalysis    `json:"jira_analysis,omitempty" yaml:"jira_analysis,omitempty"`
+	RelatedPRs        []RelatedPR      `json:"related_prs,omitempty" yaml:"related_prs,omitempty"`
+	UnmergedPRs       []UnmergedPR     `json:"unmerged_prs,omitempty" yaml:"unmerged_prs,omitempty"` // Related PRs not merged yet, when looked up
+	SheetsUnavailable bool             `json:"sheets_unavailable,omitempty" yaml:"sheets_unavailable,omitempty"`
 }
 
 // JiraAnalysis represents the JIRA ticket analysis result.
 type JiraAnalysis struct {
-	MainTicket      string   `json:"main_ticket"`      // The main MGMT ticket (e.g., "MGMT-20662")
-	AllTickets      []string `json:"all_tickets"`      // All related tickets including clones
-	RelatedPRURLs   []string `json:"related_pr_urls"`  // All PR URLs found in tickets
-	AnalysisSuccess bool     `json:"analysis_success"` // Whether analysis completed
-	ErrorMessage    string   `json:"error_message"`    // Error details if analysis failed
+	MainTicket      string   `json:"main_ticket" yaml:"main_ticket"`                       // The main MGMT ticket (e.g., "MGMT-20662")
+	Priority        string   `json:"priority" yaml:"priority"`                             // Priority of the main ticket (e.g., "P1", "Critical")
+	Epic            string   `json:"epic,omitempty" yaml:"epic,omitempty"`                 // Epic the main ticket belongs to (e.g., "ACM-1000")
+	EpicSummary     string   `json:"epic_summary,omitempty" yaml:"epic_summary,omitempty"` // Summary of the epic
+	FixVersions     []string `json:"fix_versions,omitempty" yaml:"fix_versions,omitempty"` // Fix versions set on the main ticket (e.g., "ACM 2.14.0")
+	AllTickets      []string `json:"all_tickets" yaml:"all_tickets"`                       // All related tickets including clones
+	RelatedPRURLs   []string `json:"related_pr_urls" yaml:"related_pr_urls"`               // All PR URLs found in tickets
+	MergeOrder      []string `json:"merge_order,omitempty" yaml:"merge_order,omitempty"`   // PR URLs ordered by "blocks" links between tickets
+	AnalysisSuccess bool     `json:"analysis_success" yaml:"analysis_success"`             // Whether analysis completed
+	ErrorMessage    string   `json:"error_message" yaml:"error_message"`                   // Error details if analysis failed
 }
 
 // RelatedPR represents a merged PR found through JIRA ticket analysis.
 type RelatedPR struct {
-	Number          int              `json:"number"`
-	Title           string           `json:"title"`
-	URL             string           `json:"url"`
-	Hash            string           `json:"hash"`             // Commit hash
-	JiraTickets     []string         `json:"jira_tickets"`     // JIRA tickets associated with this PR
-	ReleaseBranches []BranchPresence `json:"release_branches"` // Branch analysis for this PR
+	Number          int              `json:"number" yaml:"number"`
+	Title           string           `json:"title" yaml:"title"`
+	URL             string           `json:"url" yaml:"url"`
+	Hash            string           `json:"hash" yaml:"hash"`                         // Commit hash
+	JiraTickets     []string         `json:"jira_tickets" yaml:"jira_tickets"`         // JIRA tickets associated with this PR
+	ReleaseBranches []BranchPresence `json:"release_branches" yaml:"release_branches"` // Branch analysis for this PR
+}
+
+// PRStatus describes the state of an unmerged PR.
+type PRStatus int
+
+// Known PR statuses.
+const (
+	StatusInReview PRStatus = iota
+	StatusDraft
+	StatusAnalysisFailed
+	StatusClosed
+)
+
+var prStatusNames = map[PRStatus]string{
+	StatusInReview:       "In Review",
+	StatusDraft:          "Draft",
+	StatusAnalysisFailed: "Analysis Failed",
+	StatusClosed:         "Closed",
+}
+
+// String returns the human-readable status name.
+func (s PRStatus) String() string {
+	if name, ok := prStatusNames[s]; ok {
+		return name
+	}
+	return fmt.Sprintf("PRStatus(%d)", int(s))
+}
+
+// MarshalJSON encodes the status as its display string.
+func (s PRStatus) MarshalJSON() ([]byte, error) {
+	return json.Marshal(s.String())
+}
+
+// UnmarshalJSON decodes a status from its display string.
+func (s *PRStatus) UnmarshalJSON(data []byte) error {
+	var name string
+	if err := json.Unmarshal(data, &name); err != nil {
+		return fmt.Errorf("failed to decode PR status: %w", err)
+	}
+	for status, statusName := range prStatusNames {
+		if statusName == name {
+			*s = status
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown PR status %q", name)
 }
 
 // UnmergedPR represents an unmerged PR found through JIRA ticket analysis.
 type UnmergedPR struct {
-	Number int    `json:"number"` // PR number
-	Title  string `json:"title"`  // PR title
-	URL    string `json:"url"`    // PR URL
-	Status string `json:"status"` // PR status (e.g., "In Review", "Draft", "Pending")
+	Number int      `json:"number" yaml:"number"` // PR number
+	Title  string   `json:"title" yaml:"title"`   // PR title
+	URL    string   `json:"url" yaml:"url"`       // PR URL
+	Status PRStatus `json:"status" yaml:"status"` // PR status (e.g., "In Review", "Draft", "Analysis Failed")
+}
+
+// JiraAnalysisResult holds a JIRA ticket analysis together with the PRs it references.
+type JiraAnalysisResult struct {
+	JiraAnalysis *JiraAnalysis `json:"jira_analysis" yaml:"jira_analysis"`
+	RelatedPRs   []RelatedPR   `json:"related_prs" yaml:"related_prs"`   // Merged PRs with their branch analysis
+	UnmergedPRs  []UnmergedPR  `json:"unmerged_prs" yaml:"unmerged_prs"` // PRs that are unmerged or could not be analyzed
 }
 
 // Config represents the application configuration.
 type Config struct {
-	GitHubToken              string `json:"github_token"`
-	Repository               string `json:"repository"`
-	Owner                    string `json:"owner"`
-	BranchPrefix             string `json:"branch_prefix"`
-	DefaultBranch            string `json:"default_branch"`
-	SlackBotToken            string `json:"slack_bot_token"`
-	SlackSigningSecret       string `json:"slack_signing_secret"`
-	GitLabToken              string `json:"gitlab_token"`
-	JiraToken                string `json:"jira_token"`
-	JiraEmail                string `json:"jira_email"`
-	GoogleSheetID            string `json:"google_sheet_id"`
-	GoogleServiceAccountJSON string `json:"google_service_account_json"`
-	RepoCacheDir             string `json:"repo_cache_dir"`
+	GitHubToken              string              `json:"github_token"`
+	Repository               string              `json:"repository"`
+	Owner                    string              `json:"owner"`
+	BranchPrefix             string              `json:"branch_prefix"`
+	DefaultBranch            string              `json:"default_branch"`
+	GitHubMaxRetries         int                 `json:"github_max_retries"` // Retries for rate-limited and 5xx GitHub responses
+	GitHubBaseURL            string              `json:"github_base_url"`    // GitHub Enterprise API endpoint; empty uses api.github.com
+	GitHubWebhookSecret      string              `json:"github_webhook_secret"`
+	SlackBotToken            string              `json:"slack_bot_token"`
+	SlackSigningSecret       string              `json:"slack_signing_secret"`
+	GitLabToken              string              `json:"gitlab_token"`
+	GitLabBaseURL            string              `json:"gitlab_base_url"`   // GitLab server; empty uses gitlab.cee.redhat.com
+	GitLabProjectID          string              `json:"gitlab_project_id"` // Default snapshot project; empty uses acm-cicd/mce-bb2
+	JiraToken                string              `json:"jira_token"`
+	JiraEmail                string              `json:"jira_email"`
+	JiraLinkSummaries        bool                `json:"jira_link_summaries"`  // Also look for PR URLs in the summaries of linked issues
+	JiraFollowEpics          bool                `json:"jira_follow_epics"`    // Also collect PRs from epics and "is part of" links
+	JiraFollowSubtasks       bool                `json:"jira_follow_subtasks"` // Also collect PRs from sub-tasks
+	JiraProjects             []string            `json:"jira_projects"`        // Project keys recognized in PR titles (default MGMT)
+	GoogleSheetID            string              `json:"google_sheet_id"`
+	GoogleServiceAccountJSON string              `json:"google_service_account_json"`
+	RepoCacheDir             string              `json:"repo_cache_dir"`
+	GitLabMaxIdleConns       int                 `json:"gitlab_max_idle_conns"`
+	GitLabIdleConnTimeout    time.Duration       `json:"gitlab_idle_conn_timeout"`
+	ResultStorePath          string              `json:"result_store_path"`
+	IncludePreRelease        bool                `json:"include_prerelease"`
+	UrgentChannel            string              `json:"urgent_channel"`
+	SnapshotProjects         map[string]string   `json:"snapshot_projects"` // Product name -> GitLab snapshot project
+	APIToken                 string              `json:"api_token"`
+	SheetLayout              string              `json:"sheet_layout"` // "auto", "tabular" or "vertical"
+	CommandACL               map[string][]string `json:"command_acl"`  // Command name (e.g., "version") -> allowed Slack user group IDs
+	ProxyURL                 string              `json:"proxy_url"`    // Outbound proxy for GitHub, GitLab, JIRA and Slack
+	ProxySkipTLSVerify       bool                `json:"proxy_skip_tls_verify"`
+	PatternDescriptions      map[string]string   `json:"pattern_descriptions"` // Branch pattern (e.g., "release-partner-") -> display name
+	SHASkewThreshold         int                 `json:"sha_skew_threshold"`   // Commits a GitHub tag may differ from the MCE snapshot before warning
+	ServerReadTimeout        time.Duration       `json:"server_read_timeout"`
+	ServerWriteTimeout       time.Duration       `json:"server_write_timeout"`
+	ServerIdleTimeout        time.Duration       `json:"server_idle_timeout"`
+	BranchCacheTTL           time.Duration       `json:"branch_cache_ttl"` // How long listed release branches are reused
+	MetricsEnabled           bool                `json:"metrics_enabled"`  // Serve Prometheus metrics on /metrics in server mode
+	WebhookChannel           string              `json:"webhook_channel"`  // Slack channel for analyses of PRs merged via the GitHub webhook
+	LogFormat                string              `json:"log_format"`       // "text" or "json"
+	BranchPatterns           []BranchPattern     `json:"branch_patterns"`  // Release branch naming schemes; empty uses DefaultBranchPatterns
+	ResultCacheTTL           time.Duration       `json:"result_cache_ttl"` // How long the CLI reuses a cached -pr result
+	TLSCertFile              string              `json:"tls_cert_file"`    // Server certificate (PEM, may include the chain); HTTPS when set with TLSKeyFile
+	TLSKeyFile               string              `json:"tls_key_file"`
+	VersionMappings          []VersionMapping    `json:"version_mappings"`            // ACM-to-MCE minor versions; empty uses DefaultVersionMappings
+	JiraConcurrentIssueLimit int                 `json:"jira_concurrent_issue_limit"` // Concurrent JIRA issue fetches while resolving clones
+	Concurrency              ConcurrencyConfig   `json:"concurrency"`                 // Worker limits for branch checks, PR analyses and MCE validation
+	GARefreshInterval        time.Duration       `json:"ga_refresh_interval"`         // How often the server re-reads the release schedule; 0 disables scheduled refreshes
+	WorkspaceTokens          map[string]string   `json:"slack_workspace_tokens"`      // Slack team ID -> bot token, for workspaces other than PR_BOT_SLACK_BOT_TOKEN's
+	AuthorSlackUsers         map[string]string   `json:"slack_author_users"`          // GitHub login -> Slack user ID; listed authors get a DM when their PR is analyzed
+	ShutdownTimeout          time.Duration       `json:"shutdown_timeout"`            // How long the servers wait for in-flight work on SIGINT/SIGTERM
+	SubscriptionPollInterval time.Duration       `json:"subscription_poll_interval"`  // How often the Slack server checks PRs subscribed with /subscribe
+	GitHubMaxPages           int                 `json:"max_pages"`                   // Most pages read from a paginated GitHub listing
+}
+
+// BranchPattern describes a release branch naming scheme, e.g. "release-ocm-2.13".
+type BranchPattern struct {
+	Prefix              string `json:"prefix"`                // Branch name prefix, e.g. "release-ocm-"
+	PatternKey          string `json:"pattern_key"`           // Pattern reported for matching branches; empty uses Prefix
+	VersionExtractRegex string `json:"version_extract_regex"` // Matched against the rest of the name; group 1 (or the match) is the version
+}
+
+// Key returns the pattern reported for branches matching p. Built-in keys such as
+// "release-ocm-" select the GA lookups done for that kind of branch.
+func (p BranchPattern) Key() string {
+	if p.PatternKey != "" {
+		return p.PatternKey
+	}
+	return p.Prefix
+}
+
+// DefaultBranchPatterns are the release branch patterns used when none are configured,
+// in display order.
+var DefaultBranchPatterns = []BranchPattern{
+	{Prefix: "release-ocm-", PatternKey: "release-ocm-", VersionExtractRegex: `^(\d+\.\d+(?:\.\d+)?)`},
+	{Prefix: "releases/v", PatternKey: "releases/v", VersionExtractRegex: `^(\d+\.\d+(?:\.\d+)?(?:-\w+)?)`}, // assisted-installer-ui, e.g. "2.15-cim"
+	{Prefix: "release-", PatternKey: "release-", VersionExtractRegex: `^(\d+\.\d+(?:\.\d+)?)`},
+	{Prefix: "release-v", PatternKey: "release-v", VersionExtractRegex: `^(\d+\.\d+(?:\.\d+)*)`},
+	{Prefix: "v", PatternKey: "v", VersionExtractRegex: `^(\d+\.\d+(?:\.\d+)?)`},
+}
+
+// ReleaseBranchPatterns returns the configured branch patterns, or DefaultBranchPatterns.
+func (c *Config) ReleaseBranchPatterns() []BranchPattern {
+	if len(c.BranchPatterns) > 0 {
+		return c.BranchPatterns
+	}
+	return DefaultBranchPatterns
+}
+
+// BranchPatternOrder returns the pattern keys of the release branch patterns in the
+// order branch groups are displayed.
+func (c *Config) BranchPatternOrder() []string {
+	var order []string
+	for _, pattern := range c.ReleaseBranchPatterns() {
+		if key := pattern.Key(); !slices.Contains(order, key) {
+			order = append(order, key)
+		}
+	}
+	return order
+}
+
+// PatternDescription returns the configured description for a branch pattern,
+// falling back to the built-in PatternDescription.
+func (c *Config) PatternDescription(pattern string) string {
+	if description, ok := c.PatternDescriptions[pattern]; ok && description != "" {
+		return description
+	}
+	return PatternDescription(pattern)
+}
+
+// PatternDisplayName returns the configured description for a branch pattern,
+// falling back to the built-in PatternDisplayName.
+func (c *Config) PatternDisplayName(pattern string) string {
+	if description, ok := c.PatternDescriptions[pattern]; ok && description != "" {
+		return description
+	}
+	return PatternDisplayName(pattern)
 }
 
 // PatternDescription returns a human-readable description for branch patterns.
@@ -179,33 +423,111 @@ func PatternDisplayName(pattern string) string {
 	}
 }
 
-// ParseVersionNumber extracts and parses version number from version string for sorting.
-func ParseVersionNumber(version string) float64 {
-	if strings.Contains(version, "Next Version") {
-		return 999.0
-	}
-	version = strings.TrimPrefix(version, "v")
-	if parsed, err := strconv.ParseFloat(version, 64); err == nil {
-		return parsed
+// CompareBranchVersions compares the versions of two release branches for sorting,
+// component by component so "2.9" < "2.10". "Next Version" sorts after every
+// numbered version.
+func CompareBranchVersions(v1, v2 string) int {
+	next1 := strings.Contains(v1, "Next Version")
+	next2 := strings.Contains(v2, "Next Version")
+	switch {
+	case next1 && next2:
+		return 0
+	case next1:
+		return 1
+	case next2:
+		return -1
 	}
-	return 0.0
+	return CompareSemanticVersions(v1, v2)
 }
 
 // VersionComparisonResult holds the result of comparing two versions.
 type VersionComparisonResult struct {
-	Component       string
-	Owner           string
-	Repository      string
-	TargetVersion   string
-	PreviousVersion string
-	Commits         []CommitInfo
+	Component       string       `json:"component"`
+	Owner           string       `json:"owner"`
+	Repository      string       `json:"repository"`
+	TargetVersion   string       `json:"target_version"`
+	PreviousVersion string       `json:"previous_version"`
+	Commits         []CommitInfo `json:"commits"`
+	Since           string       `json:"since,omitempty"` // YYYY-MM-DD the commits were filtered by, if any
+	Until           string       `json:"until,omitempty"` // YYYY-MM-DD the commits were filtered by, if any
+}
+
+// FilterByDate keeps only the commits committed between since and until
+// (YYYY-MM-DD, inclusive). Either date may be empty.
+func (r *VersionComparisonResult) FilterByDate(since, until string) error {
+	sinceTime, untilTime, err := ParseCommitDateRange(since, until)
+	if err != nil {
+		return err
+	}
+	r.Commits = FilterCommitsByDate(r.Commits, sinceTime, untilTime)
+	r.Since, r.Until = since, until
+	return nil
 }
 
 // CommitInfo holds basic commit information for version comparison display.
 type CommitInfo struct {
-	ShortHash string
-	Date      string
-	Title     string
+	ShortHash string `json:"short_hash"`
+	Date      string `json:"date"` // Committer date in RFC 3339 format
+	Title     string `json:"title"`
+}
+
+// CommitFilterDateLayout is the layout of --since and --until dates.
+const CommitFilterDateLayout = "2006-01-02"
+
+// ParseCommitDateRange parses YYYY-MM-DD since and until dates. An empty date is
+// returned as the zero time. until covers its whole day, so the start of the next
+// day is returned for it.
+func ParseCommitDateRange(since, until string) (time.Time, time.Time, error) {
+	var sinceTime, untilTime time.Time
+	if since != "" {
+		t, err := time.Parse(CommitFilterDateLayout, since)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid since date %q (expected YYYY-MM-DD)", since)
+		}
+		sinceTime = t
+	}
+	if until != "" {
+		t, err := time.Parse(CommitFilterDateLayout, until)
+		if err != nil {
+			return time.Time{}, time.Time{}, fmt.Errorf("invalid until date %q (expected YYYY-MM-DD)", until)
+		}
+		untilTime = t.AddDate(0, 0, 1)
+	}
+	if !sinceTime.IsZero() && !untilTime.IsZero() && !sinceTime.Before(untilTime) {
+		return time.Time{}, time.Time{}, fmt.Errorf("since date %s is after until date %s", since, until)
+	}
+	return sinceTime, untilTime, nil
+}
+
+// CommitDateRangeLabel describes a since/until filter, e.g. "since 2025-01-01",
+// or returns "" when neither date is set.
+func CommitDateRangeLabel(since, until string) string {
+	var parts []string
+	if since != "" {
+		parts = append(parts, "since "+since)
+	}
+	if until != "" {
+		parts = append(parts, "until "+until)
+	}
+	return strings.Join(parts, " ")
+}
+
+// FilterCommitsByDate returns the commits committed at or after since and before
+// until. Zero bounds are ignored, and commits whose date cannot be parsed are kept.
+func FilterCommitsByDate(commits []CommitInfo, since, until time.Time) []CommitInfo {
+	if since.IsZero() && until.IsZero() {
+		return commits
+	}
+
+	var filtered []CommitInfo
+	for _, commit := range commits {
+		date, err := time.Parse(time.RFC3339, commit.Date)
+		if err == nil && ((!since.IsZero() && date.Before(since)) || (!until.IsZero() && !date.Before(until))) {
+			continue
+		}
+		filtered = append(filtered, commit)
+	}
+	return filtered
 }
 
 // CompareSemanticVersions compares two version strings numerically (e.g., "v2.9.0" vs "v2.40.0").
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// AnalysisSummary holds the aggregate numbers of a PR analysis, so the CLI, Slack and
+// JSON outputs all report the same ones.
+type AnalysisSummary struct {
+	TotalBranchesChecked int      `json:"total_branches_checked" yaml:"total_branches_checked"` // Release branches the PR was looked for in
+	FoundBranches        int      `json:"found_branches" yaml:"found_branches"`                 // Branches containing the PR or one of its backports
+	ReleasedACMVersions  []string `json:"released_acm_versions" yaml:"released_acm_versions"`   // First released ACM version of each found branch
+	ReleasedMCEVersions  []string `json:"released_mce_versions" yaml:"released_mce_versions"`   // First released MCE version of each found branch
+	PendingACMVersions   []string `json:"pending_acm_versions" yaml:"pending_acm_versions"`     // First upcoming ACM version of found branches without a released one
+	PendingMCEVersions   []string `json:"pending_mce_versions" yaml:"pending_mce_versions"`     // First upcoming MCE version of found branches without a released one
+	RelatedMergedPRs     int      `json:"related_merged_prs" yaml:"related_merged_prs"`         // Merged backports found through JIRA
+	RelatedUnmergedPRs   int      `json:"related_unmerged_prs" yaml:"related_unmerged_prs"`     // Backports found through JIRA that are not merged yet
+}
+
+// Summary returns the aggregate numbers of the analysis. Versions are sorted
+// oldest first.
+func (r *PRAnalysisResult) Summary() AnalysisSummary {
+	summary := AnalysisSummary{RelatedUnmergedPRs: len(r.UnmergedPRs)}
+
+	for _, branch := range r.ReleaseBranches {
+		if !branch.Skipped {
+			summary.TotalBranchesChecked++
+		}
+	}
+	for _, relatedPR := range r.RelatedPRs {
+		if relatedPR.Number != r.PR.Number {
+			summary.RelatedMergedPRs++
+		}
+	}
+
+	branches := r.AllFoundBranches(true)
+	summary.FoundBranches = len(branches)
+
+	now := time.Now()
+	released := make(map[string]map[string]bool)
+	pending := make(map[string]map[string]bool)
+	for _, branch := range branches {
+		if branch.IsNextVersion() {
+			continue
+		}
+		releasedGAs, pendingGAs := branch.ReleaseStatus(now)
+		addProductVersions(released, releasedGAs)
+		addProductVersions(pending, pendingGAs)
+	}
+	summary.ReleasedACMVersions = sortedVersions(released["ACM"])
+	summary.ReleasedMCEVersions = sortedVersions(released["MCE"])
+	summary.PendingACMVersions = sortedVersions(pending["ACM"])
+	summary.PendingMCEVersions = sortedVersions(pending["MCE"])
+	return summary
+}
+
+// AllFoundBranches returns the release branches containing the PR, sorted by name.
+// With includeRelated, branches that only contain one of the related backport PRs
+// are added; a branch containing the PR itself keeps the PR's own data.
+func (r *PRAnalysisResult) AllFoundBranches(includeRelated bool) []BranchPresence {
+	byName := make(map[string]BranchPresence)
+	for _, branch := range r.ReleaseBranches {
+		if branch.Found {
+			byName[branch.BranchName] = branch
+		}
+	}
+	if includeRelated {
+		for _, relatedPR := range r.RelatedPRs {
+			if relatedPR.Number == r.PR.Number {
+				continue
+			}
+			for _, branch := range relatedPR.ReleaseBranches {
+				if _, exists := byName[branch.BranchName]; branch.Found && !exists {
+					byName[branch.BranchName] = branch
+				}
+			}
+		}
+	}
+
+	branches := make([]BranchPresence, 0, len(byName))
+	for _, branch := range byName {
+		branches = append(branches, branch)
+	}
+	sort.Slice(branches, func(i, j int) bool { return branches[i].BranchName < branches[j].BranchName })
+	return branches
+}
+
+// IsNextVersion reports whether the branch is for the version still in development.
+func (b BranchPresence) IsNextVersion() bool {
+	return strings.Contains(b.Version, "Next Version") ||
+		b.GAStatus.ACM.Status == "Next Version" || b.GAStatus.MCE.Status == "Next Version"
+}
+
+// ReleaseStatus returns, per product, the first of the branch's upcoming GAs that
+// was released by now and, for products without one, the first that is still pending.
+func (b BranchPresence) ReleaseStatus(now time.Time) (released, pending []UpcomingGA) {
+	hasReleased := make(map[string]bool)
+	for _, upcomingGA := range b.UpcomingGAs {
+		if upcomingGA.GADate != nil && upcomingGA.GADate.Before(now) && !hasReleased[upcomingGA.Product] {
+			hasReleased[upcomingGA.Product] = true
+			released = append(released, upcomingGA)
+		}
+	}
+
+	hasPending := make(map[string]bool)
+	for _, upcomingGA := range b.UpcomingGAs {
+		if !hasReleased[upcomingGA.Product] && !hasPending[upcomingGA.Product] {
+			hasPending[upcomingGA.Product] = true
+			pending = append(pending, upcomingGA)
+		}
+	}
+	return released, pending
+}
+
+// addProductVersions adds the versions of gas to versions, keyed by product.
+func addProductVersions(versions map[string]map[string]bool, gas []UpcomingGA) {
+	for _, ga := range gas {
+		if versions[ga.Product] == nil {
+			versions[ga.Product] = make(map[string]bool)
+		}
+		versions[ga.Product][ga.Version] = true
+	}
+}
+
+// sortedVersions returns the versions in a set, oldest first.
+func sortedVersions(set map[string]bool) []string {
+	versions := make([]string, 0, len(set))
+	for version := range set {
+		versions = append(versions, version)
+	}
+	sort.Slice(versions, func(i, j int) bool { return CompareSemanticVersions(versions[i], versions[j]) < 0 })
+	return versions
+}
@@ -0,0 +1,68 @@
+package models
+
+import "sort"
+
+// VersionMapping pairs an ACM 2.x minor version with the MCE 2.x minor version it
+// ships, e.g. ACM 2.13 with MCE 2.8.
+type VersionMapping struct {
+	ACMMinor int `json:"acm_minor" mapstructure:"acm_minor"`
+	MCEMinor int `json:"mce_minor" mapstructure:"mce_minor"`
+}
+
+// DefaultVersionMappings are used when PR_BOT_VERSION_MAPPINGS is not set: so far
+// every MCE minor has been its ACM minor minus 5.
+var DefaultVersionMappings = []VersionMapping{
+	{ACMMinor: 5, MCEMinor: 0},
+	{ACMMinor: 6, MCEMinor: 1},
+	{ACMMinor: 7, MCEMinor: 2},
+	{ACMMinor: 8, MCEMinor: 3},
+	{ACMMinor: 9, MCEMinor: 4},
+	{ACMMinor: 10, MCEMinor: 5},
+	{ACMMinor: 11, MCEMinor: 6},
+	{ACMMinor: 12, MCEMinor: 7},
+	{ACMMinor: 13, MCEMinor: 8},
+	{ACMMinor: 14, MCEMinor: 9},
+	{ACMMinor: 15, MCEMinor: 10},
+	{ACMMinor: 16, MCEMinor: 11},
+}
+
+// VersionMappingTable returns the configured ACM-to-MCE version mappings, or
+// DefaultVersionMappings, sorted by ACM minor version.
+func (c *Config) VersionMappingTable() []VersionMapping {
+	mappings := DefaultVersionMappings
+	if len(c.VersionMappings) > 0 {
+		mappings = c.VersionMappings
+	}
+	sorted := append([]VersionMapping(nil), mappings...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].ACMMinor < sorted[j].ACMMinor })
+	return sorted
+}
+
+// MCEMinorForACM returns the MCE minor version matching ACM minor version acmMinor.
+// ACM versions missing from mappings keep the offset of the closest mapped ACM
+// version, so a table ending at ACM 2.16 still maps ACM 2.17. Without mappings,
+// DefaultVersionMappings are used.
+func MCEMinorForACM(mappings []VersionMapping, acmMinor int) int {
+	if len(mappings) == 0 {
+		mappings = DefaultVersionMappings
+	}
+
+	closest := mappings[0]
+	for _, m := range mappings {
+		if m.ACMMinor == acmMinor {
+			return m.MCEMinor
+		}
+		if abs(m.ACMMinor-acmMinor) < abs(closest.ACMMinor-acmMinor) {
+			closest = m
+		}
+	}
+	return acmMinor - (closest.ACMMinor - closest.MCEMinor)
+}
+
+// abs returns the absolute value of n.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
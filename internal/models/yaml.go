@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+// yamlDateFormat is the date format of YAML output. YAML is meant to be read and
+// processed by tools, so dates use the ISO form rather than DateFormat.
+const yamlDateFormat = "2006-01-02"
+
+// yamlDate formats a date for YAML output, or returns "" for nil.
+func yamlDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format(yamlDateFormat)
+}
+
+// MarshalYAML writes the status by name, like MarshalJSON.
+func (s PRStatus) MarshalYAML() (interface{}, error) {
+	return s.String(), nil
+}
+
+// MarshalYAML writes the merge date as YYYY-MM-DD.
+func (p PRInfo) MarshalYAML() (interface{}, error) {
+	type plain PRInfo
+	return struct {
+		plain    `yaml:",inline"`
+		MergedAt string `yaml:"merged_at,omitempty"`
+	}{plain(p), yamlDate(p.MergedAt)}, nil
+}
+
+// MarshalYAML writes the merge date as YYYY-MM-DD.
+func (b BranchPresence) MarshalYAML() (interface{}, error) {
+	type plain BranchPresence
+	return struct {
+		plain    `yaml:",inline"`
+		MergedAt string `yaml:"merged_at,omitempty"`
+	}{plain(b), yamlDate(b.MergedAt)}, nil
+}
+
+// MarshalYAML writes the GA date as YYYY-MM-DD.
+func (g GAInfo) MarshalYAML() (interface{}, error) {
+	type plain GAInfo
+	return struct {
+		plain  `yaml:",inline"`
+		GADate string `yaml:"ga_date,omitempty"`
+	}{plain(g), yamlDate(g.GADate)}, nil
+}
+
+// MarshalYAML writes the GA date as YYYY-MM-DD.
+func (u UpcomingGA) MarshalYAML() (interface{}, error) {
+	type plain UpcomingGA
+	return struct {
+		plain  `yaml:",inline"`
+		GADate string `yaml:"ga_date"`
+	}{plain(u), yamlDate(u.GADate)}, nil
+}
+
+// MarshalYAML writes the GA date as YYYY-MM-DD.
+func (v MCESnapshotValidation) MarshalYAML() (interface{}, error) {
+	type plain MCESnapshotValidation
+	return struct {
+		plain  `yaml:",inline"`
+		GADate string `yaml:"ga_date"`
+	}{plain(v), yamlDate(v.GADate)}, nil
+}
+
+// MarshalYAML writes both GA dates as YYYY-MM-DD.
+func (c GADateChange) MarshalYAML() (interface{}, error) {
+	type plain GADateChange
+	return struct {
+		plain   `yaml:",inline"`
+		OldDate string `yaml:"old_date"`
+		NewDate string `yaml:"new_date"`
+	}{plain(c), yamlDate(c.OldDate), yamlDate(c.NewDate)}, nil
+}
@@ -0,0 +1,95 @@
+// Package profile stores named repository profiles, so the CLI can be pointed at a
+// component's repository with -profile instead of exporting owner and repository.
+package profile
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// Profile is a named repository selection.
+type Profile struct {
+	Name         string `yaml:"name"`
+	Owner        string `yaml:"owner"`
+	Repo         string `yaml:"repo"`
+	BranchPrefix string `yaml:"branch_prefix,omitempty"` // Optional, overrides PR_BOT_GITHUB_BRANCH_PREFIX's default
+}
+
+// file is the layout of profiles.yaml.
+type file struct {
+	Profiles []Profile `yaml:"profiles"`
+}
+
+// DefaultPath returns ~/.pr-bot/profiles.yaml.
+func DefaultPath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find home directory: %w", err)
+	}
+	return filepath.Join(home, ".pr-bot", "profiles.yaml"), nil
+}
+
+// Load reads the profiles in path. A missing file has no profiles.
+func Load(path string) ([]Profile, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read profiles: %w", err)
+	}
+
+	var f file
+	if err := yaml.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return f.Profiles, nil
+}
+
+// Find returns the profile called name from the profiles in path.
+func Find(path, name string) (*Profile, error) {
+	profiles, err := Load(path)
+	if err != nil {
+		return nil, err
+	}
+	for i := range profiles {
+		if profiles[i].Name == name {
+			return &profiles[i], nil
+		}
+	}
+	return nil, fmt.Errorf("profile %q not found in %s", name, path)
+}
+
+// Add appends p to the profiles in path, creating the file if needed. Profile
+// names must be unique.
+func Add(path string, p Profile) error {
+	if p.Name == "" || p.Owner == "" || p.Repo == "" {
+		return fmt.Errorf("profile name, owner and repo are required")
+	}
+
+	profiles, err := Load(path)
+	if err != nil {
+		return err
+	}
+	for _, existing := range profiles {
+		if existing.Name == p.Name {
+			return fmt.Errorf("profile %q already exists in %s", p.Name, path)
+		}
+	}
+
+	data, err := yaml.Marshal(file{Profiles: append(profiles, p)})
+	if err != nil {
+		return fmt.Errorf("failed to marshal profiles: %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create profile directory: %w", err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("failed to write profiles: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Package proxy configures the outbound HTTP proxy shared by the external API clients.
+package proxy
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/models"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// Settings describes the proxy used for GitHub, GitLab, JIRA and Slack requests.
+type Settings struct {
+	URL           string // Proxy URL, e.g. http://proxy.example.com:3128; empty disables the proxy
+	SkipTLSVerify bool   // Skip certificate verification, for proxies that intercept TLS
+}
+
+// FromConfig builds Settings from the application configuration.
+func FromConfig(cfg *models.Config) Settings {
+	return Settings{
+		URL:           cfg.ProxyURL,
+		SkipTLSVerify: cfg.ProxySkipTLSVerify,
+	}
+}
+
+// Enabled reports whether a proxy URL is configured.
+func (s Settings) Enabled() bool {
+	return s.URL != ""
+}
+
+// Validate checks that the proxy URL is an absolute URL with a host.
+func (s Settings) Validate() error {
+	if !s.Enabled() {
+		return nil
+	}
+	parsed, err := url.Parse(s.URL)
+	if err != nil {
+		return fmt.Errorf("failed to parse proxy URL: %w", err)
+	}
+	if parsed.Scheme == "" || parsed.Host == "" {
+		return fmt.Errorf("proxy URL %q must include a scheme and host", s.URL)
+	}
+	return nil
+}
+
+// Apply routes transport through the proxy. Hosts matched by NO_PROXY (or
+// no_proxy) bypass it, following the net/http convention. An invalid URL
+// leaves transport unchanged; config validation reports it at startup.
+func (s Settings) Apply(transport *http.Transport) {
+	if !s.Enabled() {
+		return
+	}
+	if err := s.Validate(); err != nil {
+		logger.Debug("Ignoring proxy settings: %v", err)
+		return
+	}
+
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  s.URL,
+		HTTPSProxy: s.URL,
+		NoProxy:    noProxyFromEnv(),
+	}).ProxyFunc()
+	transport.Proxy = func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+
+	if s.SkipTLSVerify {
+		if transport.TLSClientConfig == nil {
+			transport.TLSClientConfig = &tls.Config{}
+		}
+		transport.TLSClientConfig.InsecureSkipVerify = true
+	}
+}
+
+// NewTransport returns a copy of http.DefaultTransport routed through the proxy,
+// or nil when no proxy is configured so callers keep their default transport.
+func (s Settings) NewTransport() *http.Transport {
+	if !s.Enabled() {
+		return nil
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	s.Apply(transport)
+	return transport
+}
+
+// noProxyFromEnv reads NO_PROXY, falling back to no_proxy.
+func noProxyFromEnv() string {
+	if value := os.Getenv("NO_PROXY"); value != "" {
+		return value
+	}
+	return os.Getenv("no_proxy")
+}
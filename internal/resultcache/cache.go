@@ -0,0 +1,101 @@
+// Package resultcache caches PR analysis results on disk, so repeated CLI analyses
+// of the same PR skip the GitHub API calls.
+package resultcache
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/models"
+)
+
+// DefaultTTL is how long a cached result is used when PR_BOT_RESULT_CACHE_TTL is not set.
+const DefaultTTL = time.Hour
+
+// Cache stores one JSON-encoded PRAnalysisResult per PR under
+// <dir>/<owner>/<repo>/<number>.json.
+type Cache struct {
+	dir string
+	ttl time.Duration
+}
+
+// DefaultDir returns the cache directory under the user's cache directory,
+// e.g. ~/.cache/pr-bot/results on Linux.
+func DefaultDir() (string, error) {
+	base, err := os.UserCacheDir()
+	if err != nil {
+		return "", fmt.Errorf("failed to find user cache directory: %w", err)
+	}
+	return filepath.Join(base, "pr-bot", "results"), nil
+}
+
+// New creates a cache in dir whose results are used for ttl after they were analyzed.
+// A ttl of zero or less uses DefaultTTL.
+func New(dir string, ttl time.Duration) *Cache {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+	return &Cache{dir: dir, ttl: ttl}
+}
+
+// path returns the cache file of a PR.
+func (c *Cache) path(owner, repo string, prNumber int) string {
+	return filepath.Join(c.dir, owner, repo, strconv.Itoa(prNumber)+".json")
+}
+
+// Get returns the cached result of a PR, or nil if there is none or it was analyzed
+// more than the TTL ago.
+func (c *Cache) Get(owner, repo string, prNumber int) (*models.PRAnalysisResult, error) {
+	result, err := c.GetLatest(owner, repo, prNumber)
+	if err != nil || result == nil {
+		return nil, err
+	}
+	if time.Since(result.AnalyzedAt) > c.ttl {
+		return nil, nil
+	}
+	return result, nil
+}
+
+// GetLatest returns the cached result of a PR however old it is, or nil if there is none.
+func (c *Cache) GetLatest(owner, repo string, prNumber int) (*models.PRAnalysisResult, error) {
+	data, err := os.ReadFile(c.path(owner, repo, prNumber))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read cached result: %w", err)
+	}
+
+	var result models.PRAnalysisResult
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse cached result: %w", err)
+	}
+	return &result, nil
+}
+
+// Put caches the result of a PR, replacing any earlier one.
+func (c *Cache) Put(owner, repo string, prNumber int, result *models.PRAnalysisResult) error {
+	path := c.path(owner, repo, prNumber)
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	data, err := json.Marshal(result)
+	if err != nil {
+		return fmt.Errorf("failed to encode result: %w", err)
+	}
+
+	tmpPath := path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write cached result: %w", err)
+	}
+	if err := os.Rename(tmpPath, path); err != nil {
+		return fmt.Errorf("failed to replace cached result: %w", err)
+	}
+	return nil
+}
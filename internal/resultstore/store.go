@@ -0,0 +1,102 @@
+// Package resultstore persists PR analysis results so re-analyses can be diffed against them.
+package resultstore
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/shay23bra/pr-bot/internal/models"
+)
+
+// Store keeps the latest analysis result per PR in a single JSON file.
+type Store struct {
+	path string
+	mu   sync.Mutex
+}
+
+// New creates a store backed by the JSON file at path.
+func New(path string) (*Store, error) {
+	if path == "" {
+		return nil, fmt.Errorf("result store path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create result store directory: %w", err)
+	}
+	return &Store{path: path}, nil
+}
+
+// Key returns the store key for a PR, e.g. "openshift/assisted-service#1234".
+func Key(owner, repo string, prNumber int) string {
+	return fmt.Sprintf("%s/%s#%d", owner, repo, prNumber)
+}
+
+// Swap stores result under key and returns the result it replaced, if any.
+func (s *Store) Swap(key string, result *models.PRAnalysisResult) (*models.PRAnalysisResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results, err := s.readAll()
+	if err != nil {
+		return nil, err
+	}
+
+	previous := results[key]
+	results[key] = result
+
+	if err := s.writeAll(results); err != nil {
+		return nil, err
+	}
+
+	return previous, nil
+}
+
+// Get returns the stored result for key, or nil if none exists.
+func (s *Store) Get(key string) (*models.PRAnalysisResult, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	results, err := s.readAll()
+	if err != nil {
+		return nil, err
+	}
+	return results[key], nil
+}
+
+// readAll loads every stored result. A missing file is treated as empty.
+func (s *Store) readAll() (map[string]*models.PRAnalysisResult, error) {
+	results := make(map[string]*models.PRAnalysisResult)
+
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return results, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read result store: %w", err)
+	}
+
+	if err := json.Unmarshal(data, &results); err != nil {
+		return nil, fmt.Errorf("failed to parse result store: %w", err)
+	}
+	return results, nil
+}
+
+// writeAll replaces the store file atomically.
+func (s *Store) writeAll(results map[string]*models.PRAnalysisResult) error {
+	data, err := json.MarshalIndent(results, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode result store: %w", err)
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write result store: %w", err)
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("failed to replace result store: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/gitlocal"
+	"github.com/shay23bra/pr-bot/internal/jira"
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/models"
+	"github.com/shay23bra/pr-bot/pkg/analyzer"
+)
+
+// DefaultAPIRequestTimeout bounds how long a single API request may run.
+const DefaultAPIRequestTimeout = 60 * time.Second
+
+// requestIDHeader carries the request ID in both directions.
+const requestIDHeader = "X-Request-Id"
+
+// APIServer serves analysis results as JSON for programmatic access.
+type APIServer struct {
+	config         *models.Config
+	repoManager    *gitlocal.RepoManager
+	requestTimeout time.Duration
+	syncWait       time.Duration
+	jobs           *jobStore
+}
+
+// APIError is the JSON body returned for failed API requests.
+type APIError struct {
+	Error  string `json:"error"`
+	Code   string `json:"code"`
+	Detail string `json:"detail,omitempty"`
+}
+
+// NewAPIServer creates a new REST API server instance.
+func NewAPIServer(cfg *models.Config, repoManager *gitlocal.RepoManager) (*APIServer, error) {
+	if cfg.APIToken == "" {
+		return nil, fmt.Errorf("PR_BOT_API_TOKEN is required for API server mode")
+	}
+
+	return &APIServer{
+		config:         cfg,
+		repoManager:    repoManager,
+		requestTimeout: DefaultAPIRequestTimeout,
+		syncWait:       DefaultAPISyncWait,
+		jobs:           newJobStore(DefaultJobTTL),
+	}, nil
+}
+
+// Start starts the REST API server with graceful shutdown.
+func (s *APIServer) Start(port int) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /api/v1/pr/{owner}/{repo}/{number}", s.handlePR)
+	mux.HandleFunc("GET /api/v1/pr", s.handlePR)
+	mux.HandleFunc("GET /api/v1/jira/{ticket}", s.handleJira)
+	mux.HandleFunc("GET /api/v1/jira", s.handleJira)
+	mux.HandleFunc("GET /api/v1/jobs/{id}", s.handleJob)
+	mux.HandleFunc("GET /api/v1/version/{component}/{version}", s.handleVersion)
+
+	addr := fmt.Sprintf(":%d", port)
+	fmt.Printf("🚀 REST API server starting on port %d\n", port)
+	fmt.Printf("📝 Endpoints:\n")
+	fmt.Printf("   GET /api/v1/pr/{owner}/{repo}/{number}      - PR analysis\n")
+	fmt.Printf("   GET /api/v1/pr?owner=&repo=&number=         - PR analysis\n")
+	fmt.Printf("   GET /api/v1/jira/{ticket}                   - JIRA ticket analysis\n")
+	fmt.Printf("   GET /api/v1/jira?ticket=                    - JIRA ticket analysis\n")
+	fmt.Printf("   GET /api/v1/jobs/{id}                       - Result of an analysis answered with 202\n")
+	fmt.Printf("   GET /api/v1/version/{component}/{version}   - Version comparison\n")
+
+	srv := &http.Server{
+		Addr:    addr,
+		Handler: s.withRequestID(s.requireToken(s.withTimeout(mux))),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	go func() {
+		<-ctx.Done()
+		fmt.Println("\n🛑 Shutting down API server...")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), durationOrDefault(s.config.ShutdownTimeout, DefaultShutdownTimeout))
+		defer cancel()
+		srv.Shutdown(shutdownCtx)
+	}()
+
+	return listenAndServe(srv, s.config)
+}
+
+// withRequestID propagates the caller's X-Request-Id or assigns a new one.
+// Every response is JSON, so the content type is set here as well.
+func (s *APIServer) withRequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestID := r.Header.Get(requestIDHeader)
+		if requestID == "" {
+			requestID = newRequestID()
+		}
+		w.Header().Set(requestIDHeader, requestID)
+		w.Header().Set("Content-Type", "application/json")
+
+		logger.Debug("API request %s: %s %s", requestID, r.Method, r.URL.Path)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// requireToken rejects requests without the configured bearer token.
+func (s *APIServer) requireToken(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.config.APIToken)) != 1 {
+			writeAPIError(w, http.StatusUnauthorized, "unauthorized", "missing or invalid bearer token", "")
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// withTimeout aborts requests that run longer than the request timeout.
+func (s *APIServer) withTimeout(next http.Handler) http.Handler {
+	body, _ := json.Marshal(APIError{
+		Error:  "request timed out",
+		Code:   "timeout",
+		Detail: fmt.Sprintf("analysis did not complete within %s", s.requestTimeout),
+	})
+	return http.TimeoutHandler(next, s.requestTimeout, string(body))
+}
+
+// handlePR handles GET /api/v1/pr/{owner}/{repo}/{number} and GET /api/v1/pr?owner=&repo=&number=.
+func (s *APIServer) handlePR(w http.ResponseWriter, r *http.Request) {
+	owner, repo := requestParam(r, "owner"), requestParam(r, "repo")
+	if owner == "" || repo == "" {
+		writeAPIError(w, http.StatusBadRequest, "missing_parameter", "owner and repo are required", "")
+		return
+	}
+	prNumber, err := strconv.Atoi(requestParam(r, "number"))
+	if err != nil || prNumber <= 0 {
+		writeAPIError(w, http.StatusBadRequest, "invalid_pr_number", "PR number must be a positive integer", requestParam(r, "number"))
+		return
+	}
+
+	cfg := *s.config
+	cfg.Owner = owner
+	cfg.Repository = repo
+	s.runJob(w, r, "pr", func() (interface{}, *jobFailure) {
+		// The job outlives the request when it is answered with 202
+		a, err := analyzer.New(context.Background(), &cfg, s.repoManager)
+		if err != nil {
+			return nil, &jobFailure{http.StatusInternalServerError, "analyzer_unavailable", "failed to create analyzer", err}
+		}
+
+		result, err := a.AnalyzePR(prNumber)
+		if err != nil {
+			return nil, &jobFailure{http.StatusUnprocessableEntity, "analysis_failed", "failed to analyze PR", err}
+		}
+		return result, nil
+	})
+}
+
+// handleJira handles GET /api/v1/jira/{ticket} and GET /api/v1/jira?ticket=. The
+// optional component and assignee query parameters limit the tickets whose PRs are analyzed.
+func (s *APIServer) handleJira(w http.ResponseWriter, r *http.Request) {
+	ticket := requestParam(r, "ticket")
+	if ticket == "" {
+		writeAPIError(w, http.StatusBadRequest, "missing_parameter", "ticket is required", "")
+		return
+	}
+
+	filter := jira.IssueFilter{
+		Component: strings.TrimSpace(r.URL.Query().Get("component")),
+		Assignee:  strings.TrimSpace(r.URL.Query().Get("assignee")),
+	}
+
+	cfg := *s.config
+	s.runJob(w, r, "jira", func() (interface{}, *jobFailure) {
+		result, err := runJiraAnalysis(cfg, s.repoManager, jiraCommandOptions{Ticket: ticket, Filter: filter})
+		if err != nil {
+			return nil, &jobFailure{http.StatusUnprocessableEntity, "analysis_failed", "failed to analyze JIRA ticket", err}
+		}
+		return result, nil
+	})
+}
+
+// handleJob handles GET /api/v1/jobs/{id}, reporting the status of a job and,
+// once it has finished, its result or error.
+func (s *APIServer) handleJob(w http.ResponseWriter, r *http.Request) {
+	job, ok := s.jobs.get(r.PathValue("id"))
+	if !ok {
+		writeAPIError(w, http.StatusNotFound, "job_not_found", "unknown or expired job", r.PathValue("id"))
+		return
+	}
+
+	writeAPIResponse(w, job)
+}
+
+// runJob runs an analysis as a job. If it finishes within the sync wait its
+// result or error is the response; otherwise the response is 202 Accepted with
+// the job, to be polled at /api/v1/jobs/{id}.
+func (s *APIServer) runJob(w http.ResponseWriter, r *http.Request, kind string, fn func() (interface{}, *jobFailure)) {
+	job := s.jobs.start(kind, fn)
+
+	select {
+	case <-job.done:
+		finished, _ := s.jobs.get(job.ID)
+		if finished.Error != nil {
+			writeAPIError(w, finished.status, finished.Error.Code, finished.Error.Error, finished.Error.Detail)
+			return
+		}
+		writeAPIResponse(w, finished.Result)
+	case <-time.After(s.syncWait):
+		logger.Debug("API %s job %s still running, answering 202", kind, job.ID)
+		pending, _ := s.jobs.get(job.ID)
+		w.Header().Set("Location", "/api/v1/jobs/"+job.ID)
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(pending); err != nil {
+			logger.Debug("Failed to encode API response: %v", err)
+		}
+	case <-r.Context().Done():
+		logger.Debug("API request for %s job %s canceled; the job keeps running", kind, job.ID)
+	}
+}
+
+// requestParam returns a path parameter, falling back to the query parameter of the same name.
+func requestParam(r *http.Request, name string) string {
+	if value := r.PathValue(name); value != "" {
+		return value
+	}
+	return strings.TrimSpace(r.URL.Query().Get(name))
+}
+
+// AnalyzeJiraTicket runs the JIRA ticket analysis behind /jt and GET /api/v1/jira/{ticket}.
+// Only PRs of tickets matching filter are analyzed.
+func AnalyzeJiraTicket(cfg models.Config, repoManager *gitlocal.RepoManager, ticket string, filter jira.IssueFilter) (*models.JiraAnalysisResult, error) {
+	return runJiraAnalysis(cfg, repoManager, jiraCommandOptions{Ticket: ticket, Filter: filter})
+}
+
+// handleVersion handles GET /api/v1/version/{component}/{version}. The optional
+// since and until query parameters (YYYY-MM-DD) limit the commits returned.
+func (s *APIServer) handleVersion(w http.ResponseWriter, r *http.Request) {
+	since, until := r.URL.Query().Get("since"), r.URL.Query().Get("until")
+	if _, _, err := models.ParseCommitDateRange(since, until); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_date", "invalid date filter", err.Error())
+		return
+	}
+
+	cfg := *s.config
+	a, err := analyzer.New(r.Context(), &cfg, s.repoManager)
+	if err != nil {
+		writeAPIError(w, http.StatusInternalServerError, "analyzer_unavailable", "failed to create analyzer", err.Error())
+		return
+	}
+
+	result, err := a.CompareVersions(r.PathValue("component"), r.PathValue("version"))
+	if err != nil {
+		writeAPIError(w, http.StatusUnprocessableEntity, "comparison_failed", "failed to compare versions", err.Error())
+		return
+	}
+	if err := result.FilterByDate(since, until); err != nil {
+		writeAPIError(w, http.StatusBadRequest, "invalid_date", "invalid date filter", err.Error())
+		return
+	}
+
+	writeAPIResponse(w, result)
+}
+
+// writeAPIResponse writes a 200 response with a JSON body.
+func writeAPIResponse(w http.ResponseWriter, body interface{}) {
+	w.WriteHeader(http.StatusOK)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		logger.Debug("Failed to encode API response: %v", err)
+	}
+}
+
+// writeAPIError writes a structured JSON error response.
+func writeAPIError(w http.ResponseWriter, status int, code, message, detail string) {
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(APIError{Error: message, Code: code, Detail: detail}); err != nil {
+		logger.Debug("Failed to encode API error: %v", err)
+	}
+}
+
+// newRequestID returns a random 16-character hex request ID.
+func newRequestID() string {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return strconv.FormatInt(time.Now().UnixNano(), 16)
+	}
+	return hex.EncodeToString(b)
+}
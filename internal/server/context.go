@@ -0,0 +1,149 @@
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/logger"
+)
+
+// userContextTTL is how long a user's conversation context is kept after their last command.
+const userContextTTL = 7 * 24 * time.Hour
+
+// UserContext is what the bot remembers about a user's conversation.
+type UserContext struct {
+	LastCommand string    `json:"last_command"` // Full text of the last command, e.g. "pr https://github.com/..."
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+// expired reports whether the context is older than userContextTTL.
+func (c *UserContext) expired(now time.Time) bool {
+	return now.Sub(c.UpdatedAt) > userContextTTL
+}
+
+// ContextStore keeps per-user conversation context. Implementations must be safe for concurrent use.
+type ContextStore interface {
+	Get(userID string) (*UserContext, bool)
+	Set(userID string, ctx *UserContext)
+	Delete(userID string)
+}
+
+// InMemoryContextStore keeps conversation context in memory; it is lost on restart.
+type InMemoryContextStore struct {
+	mu       sync.Mutex
+	contexts map[string]*UserContext
+}
+
+// NewInMemoryContextStore creates an empty in-memory context store.
+func NewInMemoryContextStore() *InMemoryContextStore {
+	return &InMemoryContextStore{contexts: make(map[string]*UserContext)}
+}
+
+// Get returns the user's context, or false if there is none or it has expired.
+func (s *InMemoryContextStore) Get(userID string) (*UserContext, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	userCtx, ok := s.contexts[userID]
+	if !ok || userCtx.expired(time.Now()) {
+		return nil, false
+	}
+	return userCtx, true
+}
+
+// Set stores the user's context.
+func (s *InMemoryContextStore) Set(userID string, ctx *UserContext) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.contexts[userID] = ctx
+}
+
+// Delete removes the user's context.
+func (s *InMemoryContextStore) Delete(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.contexts, userID)
+}
+
+// FileContextStore keeps conversation context in memory and mirrors it to a JSON
+// file so it survives restarts. Expired entries are pruned when the file is loaded.
+type FileContextStore struct {
+	InMemoryContextStore
+	path string
+}
+
+// NewFileContextStore creates a context store backed by the JSON file at path,
+// loading any contexts saved by a previous run.
+func NewFileContextStore(path string) (*FileContextStore, error) {
+	if path == "" {
+		return nil, fmt.Errorf("context store path is required")
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create context store directory: %w", err)
+	}
+
+	s := &FileContextStore{
+		InMemoryContextStore: InMemoryContextStore{contexts: make(map[string]*UserContext)},
+		path:                 path,
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read context store: %w", err)
+	}
+	if err := json.Unmarshal(data, &s.contexts); err != nil {
+		return nil, fmt.Errorf("failed to parse context store: %w", err)
+	}
+
+	now := time.Now()
+	for userID, userCtx := range s.contexts {
+		if userCtx == nil || userCtx.expired(now) {
+			delete(s.contexts, userID)
+		}
+	}
+
+	return s, nil
+}
+
+// Set stores the user's context and saves the file.
+func (s *FileContextStore) Set(userID string, ctx *UserContext) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.contexts[userID] = ctx
+	s.save()
+}
+
+// Delete removes the user's context and saves the file.
+func (s *FileContextStore) Delete(userID string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.contexts, userID)
+	s.save()
+}
+
+// save replaces the store file atomically. The caller must hold s.mu.
+// Failures are logged; the in-memory context stays usable.
+func (s *FileContextStore) save() {
+	data, err := json.MarshalIndent(s.contexts, "", "  ")
+	if err != nil {
+		logger.Debug("Failed to encode context store: %v", err)
+		return
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0600); err != nil {
+		logger.Debug("Failed to write context store: %v", err)
+		return
+	}
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		logger.Debug("Failed to replace context store: %v", err)
+	}
+}
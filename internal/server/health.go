@@ -0,0 +1,117 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/ga"
+)
+
+// healthProbeTimeout bounds each dependency probe of /health.
+const healthProbeTimeout = 5 * time.Second
+
+// Dependency states reported by /health. Failed probes report "error: <reason>".
+const (
+	dependencyOK            = "ok"
+	dependencyNotConfigured = "not configured"
+	dependencyLoading       = "loading"
+)
+
+// healthResponse is the body returned by /health.
+type healthResponse struct {
+	Status       string            `json:"status"` // "healthy", or "degraded" when a dependency probe failed
+	Service      string            `json:"service"`
+	DataSource   string            `json:"data_source,omitempty"`
+	Dependencies map[string]string `json:"dependencies"`
+}
+
+// handleHealth probes GitHub, JIRA, GitLab and the Google Sheets release schedule
+// and reports each one. It responds 503 when any configured dependency is failing.
+func (s *SlackServer) handleHealth(w http.ResponseWriter, r *http.Request) {
+	health := healthResponse{
+		Status:  "healthy",
+		Service: "pr-bot",
+	}
+	health.Dependencies, health.DataSource = s.probeDependencies(r.Context())
+
+	status := http.StatusOK
+	for _, state := range health.Dependencies {
+		if !dependencyHealthy(state) {
+			health.Status = "degraded"
+			status = http.StatusServiceUnavailable
+		}
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(health)
+}
+
+// dependencyHealthy reports whether a dependency state is not a failure.
+func dependencyHealthy(state string) bool {
+	return state == dependencyOK || state == dependencyNotConfigured || state == dependencyLoading
+}
+
+// probeDependencies returns the state of GitHub, JIRA, GitLab and the Google
+// Sheets release schedule, keyed by dependency name, and the release schedule's
+// data source.
+func (s *SlackServer) probeDependencies(ctx context.Context) (map[string]string, string) {
+	a := s.currentAnalyzer()
+
+	probes := make(map[string]func(ctx context.Context) error)
+	if githubClient := a.GetGitHubClient(); githubClient != nil {
+		probes["github"] = githubClient.Ping
+	}
+	if jiraClient := a.GetJiraClient(); jiraClient != nil {
+		probes["jira"] = jiraClient.Ping
+	}
+	if gitlabClient := a.GetGitLabClient(); gitlabClient != nil {
+		probes["gitlab"] = gitlabClient.Ping
+	}
+
+	dependencies := map[string]string{
+		"github": dependencyNotConfigured,
+		"jira":   dependencyNotConfigured,
+		"gitlab": dependencyNotConfigured,
+	}
+
+	// The release schedule is loaded in the background; report how that went rather than re-reading the sheet
+	var dataSource string
+	dependencies["google_sheets"] = dependencyNotConfigured
+	if gaParser := a.GetGAParser(); gaParser != nil {
+		dataSource = gaParser.DataSource()
+		switch dataSource {
+		case "":
+			dependencies["google_sheets"] = dependencyLoading
+		case ga.DataSourceUnavailable:
+			dependencies["google_sheets"] = "error: release schedule could not be loaded"
+		default:
+			dependencies["google_sheets"] = dependencyOK
+		}
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, probe := range probes {
+		wg.Add(1)
+		go func(name string, probe func(ctx context.Context) error) {
+			defer wg.Done()
+			ctx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+			defer cancel()
+
+			state := dependencyOK
+			if err := probe(ctx); err != nil {
+				state = "error: " + err.Error()
+			}
+			mu.Lock()
+			dependencies[name] = state
+			mu.Unlock()
+		}(name, probe)
+	}
+
+	wg.Wait()
+	return dependencies, dataSource
+}
@@ -0,0 +1,201 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/models"
+	"github.com/shay23bra/pr-bot/internal/slack"
+	"github.com/shay23bra/pr-bot/internal/version"
+)
+
+// recentAnalysesPerUser is how many PR analyses the Home tab lists per user.
+const recentAnalysesPerUser = 5
+
+// homeReanalyzeAction is the action ID of the Home tab's "Re-analyze" buttons,
+// whose value is the PR URL.
+const homeReanalyzeAction = "home_reanalyze_pr"
+
+// recentAnalysis is a PR analysis listed on the Home tab of the user who ran it.
+type recentAnalysis struct {
+	URL        string
+	Number     int
+	Title      string
+	Branches   int // Release branches containing the PR
+	AnalyzedAt time.Time
+}
+
+// recentAnalyses keeps the last recentAnalysesPerUser PR analyses of each user in
+// memory, newest first. A PR analyzed again moves to the front.
+type recentAnalyses struct {
+	mu     sync.Mutex
+	byUser map[string][]recentAnalysis
+}
+
+func newRecentAnalyses() *recentAnalyses {
+	return &recentAnalyses{byUser: make(map[string][]recentAnalysis)}
+}
+
+// add records a PR analysis of userID.
+func (r *recentAnalyses) add(userID string, result *models.PRAnalysisResult) {
+	if userID == "" || result == nil {
+		return
+	}
+
+	entry := recentAnalysis{
+		URL:        result.PR.URL,
+		Number:     result.PR.Number,
+		Title:      result.PR.Title,
+		AnalyzedAt: result.AnalyzedAt,
+	}
+	for _, branch := range result.ReleaseBranches {
+		if branch.Found {
+			entry.Branches++
+		}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entries := []recentAnalysis{entry}
+	for _, previous := range r.byUser[userID] {
+		if previous.URL != entry.URL && len(entries) < recentAnalysesPerUser {
+			entries = append(entries, previous)
+		}
+	}
+	r.byUser[userID] = entries
+}
+
+// list returns the recent analyses of userID, newest first.
+func (r *recentAnalyses) list(userID string) []recentAnalysis {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]recentAnalysis(nil), r.byUser[userID]...)
+}
+
+// homeDependencies are the dependencies shown on the Home tab, in display order.
+var homeDependencies = []struct{ name, label string }{
+	{"github", "GitHub"},
+	{"gitlab", "GitLab"},
+	{"jira", "JIRA"},
+	{"google_sheets", "Release schedule (Google Sheets)"},
+}
+
+// publishHomeView publishes the Home tab of userID in workspace teamID: the pr-bot
+// version, whether each dependency is reachable and the user's recent PR analyses.
+func (s *SlackServer) publishHomeView(ctx context.Context, teamID, userID string) {
+	botClient := s.botClientFor(teamID)
+	if botClient == nil || userID == "" {
+		return
+	}
+
+	dependencies, _ := s.probeDependencies(ctx)
+	view := homeView(currentVersion(), dependencies, s.recent.list(userID))
+	if err := botClient.PublishView(ctx, userID, view); err != nil {
+		logger.Debug("Failed to publish Home tab for %s: %v", userID, err)
+	}
+}
+
+// homeView builds the Home tab blocks.
+func homeView(botVersion string, dependencies map[string]string, recent []recentAnalysis) *slack.HomeView {
+	blocks := []slack.Block{
+		homeSection(fmt.Sprintf("🤖 *PR Bot* %s\nFinds the release branches and GA versions a PR has landed in. Use `/info` for all commands.", botVersion)),
+		{Type: "divider"},
+	}
+
+	status := "*Status*"
+	for _, dependency := range homeDependencies {
+		status += fmt.Sprintf("\n%s %s", dependencyIcon(dependencies[dependency.name]), dependency.label)
+		if state := dependencies[dependency.name]; state != dependencyOK {
+			status += ": " + state
+		}
+	}
+	blocks = append(blocks, homeSection(status), slack.Block{Type: "divider"})
+
+	blocks = append(blocks, homeSection("*Your recent PR analyses*"))
+	if len(recent) == 0 {
+		blocks = append(blocks, slack.Block{
+			Type:     "context",
+			Elements: []*slack.TextObject{{Type: "mrkdwn", Text: "No analyses yet. Run `/pr <PR_URL>` and it will show up here."}},
+		})
+	}
+	for _, analysis := range recent {
+		block := homeSection(fmt.Sprintf("*<%s|PR #%d>* %s\nIn %d release branch(es) · analyzed %s",
+			analysis.URL, analysis.Number, analysis.Title, analysis.Branches, analysis.AnalyzedAt.UTC().Format("2006-01-02 15:04 MST")))
+		block.Accessory = &slack.Button{
+			Type:     "button",
+			Text:     slack.PlainText("Re-analyze"),
+			ActionID: homeReanalyzeAction,
+			Value:    analysis.URL,
+		}
+		blocks = append(blocks, block)
+	}
+
+	return &slack.HomeView{Type: "home", Blocks: blocks}
+}
+
+// homeSection returns a section block with mrkdwn text.
+func homeSection(text string) slack.Block {
+	return slack.Block{Type: "section", Text: &slack.TextObject{Type: "mrkdwn", Text: text}}
+}
+
+// dependencyIcon returns the Home tab icon for a dependency state.
+func dependencyIcon(state string) string {
+	switch state {
+	case dependencyOK:
+		return "✅"
+	case dependencyNotConfigured:
+		return "⚪"
+	case dependencyLoading:
+		return "⏳"
+	default:
+		return "❌"
+	}
+}
+
+// currentVersion returns the pr-bot version for display, or "(unknown version)".
+func currentVersion() string {
+	v, err := version.GetCurrentVersion()
+	if err != nil {
+		logger.Debug("Could not determine pr-bot version: %v", err)
+		return "(unknown version)"
+	}
+	return v
+}
+
+// handleHomeAction handles a "Re-analyze" click on the Home tab. The analysis runs
+// in the background and its result is sent to the user as a direct message.
+func (s *SlackServer) handleHomeAction(ctx context.Context, teamID, userID string, action slack.BlockAction) {
+	botClient := s.botClientFor(teamID)
+	if botClient == nil {
+		return
+	}
+
+	if s.shuttingDown.Load() {
+		botClient.PostSimpleMessage(ctx, userID, shuttingDownMessage)
+		return
+	}
+	if !s.isCommandAllowed(ctx, teamID, "/pr", userID) {
+		botClient.PostSimpleMessage(ctx, userID, commandDeniedMessage)
+		return
+	}
+
+	prURL := action.Value
+	logger.Debug("=== RE-ANALYZING PR FROM HOME TAB: %s, user: %s ===", prURL, userID)
+	s.goTracked(func() {
+		ctx := context.Background()
+		message, err := s.analyzePRWithOptions(prCommandOptions{URL: prURL, IncludeRelated: true}, userID)
+		if err != nil {
+			message = fmt.Sprintf("❌ Error analyzing PR: %v", err)
+		}
+		if botClient := s.botClientFor(teamID); botClient != nil {
+			if err := botClient.PostSimpleMessage(ctx, userID, message); err != nil {
+				logger.Debug("Failed to send re-analysis of %s to %s: %v", prURL, userID, err)
+			}
+		}
+		s.publishHomeView(ctx, teamID, userID)
+	})
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultJobTTL is how long a finished API job's result can be fetched.
+const DefaultJobTTL = 30 * time.Minute
+
+// DefaultAPISyncWait is how long an API request waits for its analysis before
+// answering 202 Accepted with a job to poll instead.
+const DefaultAPISyncWait = 15 * time.Second
+
+// Job states reported by GET /api/v1/jobs/{id}.
+const (
+	JobRunning   = "running"
+	JobSucceeded = "succeeded"
+	JobFailed    = "failed"
+)
+
+// Job is an analysis run in the background for the REST API.
+type Job struct {
+	ID          string      `json:"id"`
+	Kind        string      `json:"kind"` // "pr" or "jira"
+	Status      string      `json:"status"`
+	CreatedAt   time.Time   `json:"created_at"`
+	CompletedAt *time.Time  `json:"completed_at,omitempty"`
+	Result      interface{} `json:"result,omitempty"`
+	Error       *APIError   `json:"error,omitempty"`
+
+	status int           // HTTP status of a failed job
+	done   chan struct{} // closed when the job finishes
+}
+
+// jobFailure is returned by job functions to choose the API error of a failed job.
+type jobFailure struct {
+	status  int
+	code    string
+	message string
+	err     error
+}
+
+func (f *jobFailure) Error() string {
+	return f.message + ": " + f.err.Error()
+}
+
+// jobStore keeps API jobs in memory until their TTL after completion expires.
+type jobStore struct {
+	mu   sync.Mutex
+	jobs map[string]*Job
+	ttl  time.Duration
+}
+
+// newJobStore creates an empty job store.
+func newJobStore(ttl time.Duration) *jobStore {
+	return &jobStore{jobs: make(map[string]*Job), ttl: ttl}
+}
+
+// start runs fn in the background as a new job of kind and returns the job.
+func (s *jobStore) start(kind string, fn func() (interface{}, *jobFailure)) *Job {
+	job := &Job{
+		ID:        newRequestID(),
+		Kind:      kind,
+		Status:    JobRunning,
+		CreatedAt: time.Now(),
+		done:      make(chan struct{}),
+	}
+
+	s.mu.Lock()
+	s.pruneLocked()
+	s.jobs[job.ID] = job
+	s.mu.Unlock()
+
+	go func() {
+		result, failure := fn()
+
+		s.mu.Lock()
+		now := time.Now()
+		job.CompletedAt = &now
+		if failure != nil {
+			job.Status = JobFailed
+			job.status = failure.status
+			job.Error = &APIError{Error: failure.message, Code: failure.code, Detail: failure.err.Error()}
+		} else {
+			job.Status = JobSucceeded
+			job.Result = result
+		}
+		s.mu.Unlock()
+		close(job.done)
+	}()
+
+	return job
+}
+
+// get returns a copy of the job with id, or false if it is unknown or expired.
+func (s *jobStore) get(id string) (Job, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.pruneLocked()
+	job, ok := s.jobs[id]
+	if !ok {
+		return Job{}, false
+	}
+	return *job, true
+}
+
+// pruneLocked drops jobs that finished more than the TTL ago. Callers must hold mu.
+func (s *jobStore) pruneLocked() {
+	cutoff := time.Now().Add(-s.ttl)
+	for id, job := range s.jobs {
+		if job.CompletedAt != nil && job.CompletedAt.Before(cutoff) {
+			delete(s.jobs, id)
+		}
+	}
+}
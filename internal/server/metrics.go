@@ -0,0 +1,78 @@
+package server
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/metrics"
+)
+
+// Analysis kinds used as the "kind" label of analysis metrics.
+const (
+	analysisKindPR   = "pr"
+	analysisKindJira = "jira"
+)
+
+// serverMetrics are the Prometheus metrics served on /metrics when
+// PR_BOT_METRICS_ENABLED is set. A nil *serverMetrics records nothing.
+type serverMetrics struct {
+	registry         *prometheus.Registry
+	commands         *prometheus.CounterVec
+	analyses         *prometheus.CounterVec
+	analysisDuration *prometheus.HistogramVec
+}
+
+// newServerMetrics creates the server metrics and registers them together with the GitHub API request counter.
+func newServerMetrics() *serverMetrics {
+	m := &serverMetrics{
+		registry: prometheus.NewRegistry(),
+		commands: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pr_bot_slash_commands_total",
+			Help: "Slack slash commands received by command name.",
+		}, []string{"command"}),
+		analyses: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "pr_bot_analyses_total",
+			Help: "Completed PR and JIRA analyses by kind and result (success or failure).",
+		}, []string{"kind", "result"}),
+		analysisDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "pr_bot_analysis_duration_seconds",
+			Help:    "Time taken by PR and JIRA analyses.",
+			Buckets: metrics.DefaultDurationBuckets,
+		}, []string{"kind"}),
+	}
+	for _, collector := range []prometheus.Collector{m.commands, m.analyses, m.analysisDuration, metrics.GitHubRequests} {
+		if err := m.registry.Register(collector); err != nil {
+			logger.Info("Failed to register metrics: %v", err)
+		}
+	}
+	return m
+}
+
+// handler serves the registered metrics for Prometheus scrapes.
+func (m *serverMetrics) handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// commandReceived counts a slash command.
+func (m *serverMetrics) commandReceived(command string) {
+	if m == nil {
+		return
+	}
+	m.commands.WithLabelValues(command).Inc()
+}
+
+// analysisDone records the result and duration of an analysis that started at start.
+func (m *serverMetrics) analysisDone(kind string, start time.Time, err error) {
+	if m == nil {
+		return
+	}
+	result := "success"
+	if err != nil {
+		result = "failure"
+	}
+	m.analyses.WithLabelValues(kind, result).Inc()
+	m.analysisDuration.WithLabelValues(kind).Observe(time.Since(start).Seconds())
+}
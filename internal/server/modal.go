@@ -0,0 +1,234 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/shay23bra/pr-bot/internal/github"
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/models"
+	"github.com/shay23bra/pr-bot/internal/slack"
+)
+
+// prModalCallbackID identifies submissions of the modal opened by /pr without arguments.
+const prModalCallbackID = "pr_analysis"
+
+// Block, action and option IDs of the /pr modal.
+const (
+	prModalURLBlock     = "pr_url"
+	prModalJiraBlock    = "jira_ticket"
+	prModalOptionsBlock = "options"
+	prModalChannelBlock = "channel"
+	prModalInputAction  = "value"
+	prOptionRelated     = "include_related"
+	prOptionBrief       = "brief"
+)
+
+// prUsageMessage is the /pr response when the modal cannot be opened.
+const prUsageMessage = "❌ Usage: `/pr <PR_URL>`"
+
+// handleSlashCommandWithModal opens the /pr form for the user who ran /pr without
+// a URL in workspace teamID and returns the slash command response. Without a bot
+// token no modal can be opened, so the usage message is returned instead.
+func (s *SlackServer) handleSlashCommandWithModal(ctx context.Context, teamID, triggerID string) string {
+	botClient := s.botClientFor(teamID)
+	if botClient == nil || triggerID == "" {
+		return prUsageMessage
+	}
+
+	if err := botClient.OpenView(ctx, triggerID, prAnalysisModal()); err != nil {
+		logger.Info("Failed to open /pr modal: %v", err)
+		return prUsageMessage
+	}
+
+	// The modal is the response; an empty body keeps Slack from posting anything
+	return ""
+}
+
+// prAnalysisModal builds the /pr form: the PR URL, an optional JIRA ticket, output
+// options and the conversation the results are posted to. The conversation select
+// makes Slack include a response_url in the submission, so results are delivered
+// the same way as for /pr <PR_URL>.
+func prAnalysisModal() *slack.View {
+	relatedOption := slack.Option{Text: slack.PlainText("Include related PRs"), Value: prOptionRelated}
+	briefOption := slack.Option{Text: slack.PlainText("Brief output (release branches only)"), Value: prOptionBrief}
+
+	return &slack.View{
+		Type:       "modal",
+		CallbackID: prModalCallbackID,
+		Title:      slack.PlainText("Analyze PR"),
+		Submit:     slack.PlainText("Analyze"),
+		Close:      slack.PlainText("Cancel"),
+		Blocks: []slack.InputBlock{
+			{
+				Type:    "input",
+				BlockID: prModalURLBlock,
+				Label:   slack.PlainText("PR URL"),
+				Element: slack.InputElement{
+					Type:        "plain_text_input",
+					ActionID:    prModalInputAction,
+					Placeholder: slack.PlainText("https://github.com/openshift/assisted-service/pull/7788"),
+				},
+			},
+			{
+				Type:     "input",
+				BlockID:  prModalJiraBlock,
+				Label:    slack.PlainText("JIRA ticket"),
+				Optional: true,
+				Element: slack.InputElement{
+					Type:        "plain_text_input",
+					ActionID:    prModalInputAction,
+					Placeholder: slack.PlainText("MGMT-20662"),
+				},
+				Hint: slack.PlainText("Also analyze all PRs of this ticket"),
+			},
+			{
+				Type:     "input",
+				BlockID:  prModalOptionsBlock,
+				Label:    slack.PlainText("Options"),
+				Optional: true,
+				Element: slack.InputElement{
+					Type:           "multi_static_select",
+					ActionID:       prModalInputAction,
+					Placeholder:    slack.PlainText("Select options"),
+					Options:        []slack.Option{relatedOption, briefOption},
+					InitialOptions: []slack.Option{relatedOption},
+				},
+			},
+			{
+				Type:    "input",
+				BlockID: prModalChannelBlock,
+				Label:   slack.PlainText("Post results to"),
+				Element: slack.InputElement{
+					Type:                         "conversations_select",
+					ActionID:                     prModalInputAction,
+					DefaultToCurrentConversation: true,
+					ResponseURLEnabled:           true,
+				},
+			},
+		},
+	}
+}
+
+// handleInteraction handles Slack interactivity payloads: submissions of the /pr
+// modal and "Re-analyze" clicks on the Home tab. Other interactions are
+// acknowledged and ignored.
+func (s *SlackServer) handleInteraction(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	var payload slack.InteractionPayload
+	if err := json.Unmarshal([]byte(r.FormValue("payload")), &payload); err != nil {
+		logger.Debug("Failed to decode interaction payload: %v", err)
+		http.Error(w, "Invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	if payload.Type == "block_actions" {
+		for _, action := range payload.Actions {
+			if action.ActionID == homeReanalyzeAction {
+				s.handleHomeAction(r.Context(), payload.Team.ID, payload.User.ID, action)
+			}
+		}
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	if payload.Type != "view_submission" || payload.View.CallbackID != prModalCallbackID {
+		logger.Debug("Ignoring Slack %q interaction (callback %q)", payload.Type, payload.View.CallbackID)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	opts := prOptionsFromModal(&payload)
+	userID := payload.User.ID
+	logger.Debug("=== RECEIVED /pr MODAL SUBMISSION: %s, jira: %s, user: %s ===", opts.URL, opts.JiraTicket, userID)
+
+	// Field errors are shown next to the input and keep the modal open
+	if _, _, _, err := github.ParsePRInput(opts.URL, github.EnterpriseHost(s.currentConfig().GitHubBaseURL)); err != nil {
+		writeModalErrors(w, map[string]string{prModalURLBlock: "Enter a GitHub PR URL"})
+		return
+	}
+	if s.shuttingDown.Load() {
+		writeModalErrors(w, map[string]string{prModalURLBlock: shuttingDownMessage})
+		return
+	}
+	if !s.isCommandAllowed(r.Context(), payload.Team.ID, "/pr", userID) {
+		writeModalErrors(w, map[string]string{prModalURLBlock: commandDeniedMessage})
+		return
+	}
+
+	var responseURL string
+	if len(payload.ResponseURLs) > 0 {
+		responseURL = payload.ResponseURLs[0].ResponseURL
+	}
+	s.goTracked(func() { s.analyzePRAsync(opts, responseURL, userID) })
+
+	// An empty 200 response closes the modal
+	w.WriteHeader(http.StatusOK)
+}
+
+// prOptionsFromModal reads the submitted /pr modal fields.
+func prOptionsFromModal(payload *slack.InteractionPayload) prCommandOptions {
+	opts := prCommandOptions{
+		URL:        strings.TrimSpace(payload.StateValue(prModalURLBlock, prModalInputAction).Value),
+		JiraTicket: strings.TrimSpace(payload.StateValue(prModalJiraBlock, prModalInputAction).Value),
+	}
+	for _, option := range payload.StateValue(prModalOptionsBlock, prModalInputAction).SelectedOptions {
+		switch option.Value {
+		case prOptionRelated:
+			opts.IncludeRelated = true
+		case prOptionBrief:
+			opts.Brief = true
+		}
+	}
+	return opts
+}
+
+// writeModalErrors responds to a view_submission with errors keyed by block ID.
+func writeModalErrors(w http.ResponseWriter, errors map[string]string) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"response_action": "errors",
+		"errors":          errors,
+	})
+}
+
+// formatBriefPRAnalysisForSlack lists only the release branches containing the PR.
+func formatBriefPRAnalysisForSlack(result *models.PRAnalysisResult) string {
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("📋 *PR #%d*: %s\n", result.PR.Number, result.PR.Title))
+	response.WriteString(fmt.Sprintf("🔗 %s\n", result.PR.URL))
+
+	var found []models.BranchPresence
+	for _, branch := range result.ReleaseBranches {
+		if branch.Found {
+			found = append(found, branch)
+		}
+	}
+	if len(found) == 0 {
+		response.WriteString("❌ No release branches found containing this PR\n")
+		return response.String()
+	}
+
+	sort.Slice(found, func(i, j int) bool {
+		return models.CompareBranchVersions(found[i].Version, found[j].Version) < 0
+	})
+	var names []string
+	for _, branch := range found {
+		names = append(names, fmt.Sprintf("`%s`%s", branch.BranchName, branch.CherryPickNote()))
+	}
+	response.WriteString(fmt.Sprintf("✅ Found in: %s\n", strings.Join(names, ", ")))
+	return truncateForSlack(response.String(), "pr-bot -pr "+result.PR.URL)
+}
@@ -0,0 +1,165 @@
+package server
+
+import (
+	"context"
+	"maps"
+	"os"
+	"os/signal"
+	"reflect"
+	"strings"
+	"syscall"
+
+	"github.com/shay23bra/pr-bot/internal/config"
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/models"
+	"github.com/shay23bra/pr-bot/internal/proxy"
+	"github.com/shay23bra/pr-bot/internal/slack"
+	"github.com/shay23bra/pr-bot/pkg/analyzer"
+)
+
+// currentConfig returns the active configuration.
+func (s *SlackServer) currentConfig() *models.Config {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.config
+}
+
+// currentAnalyzer returns the active analyzer.
+func (s *SlackServer) currentAnalyzer() *analyzer.Analyzer {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.analyzer
+}
+
+// currentBotClient returns the active Slack bot client.
+func (s *SlackServer) currentBotClient() *slack.BotClient {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.botClient
+}
+
+// botClientFor returns the bot client for the Slack workspace teamID: the one from
+// PR_BOT_SLACK_WORKSPACE_TOKENS, or the PR_BOT_SLACK_BOT_TOKEN client for other workspaces.
+func (s *SlackServer) botClientFor(teamID string) *slack.BotClient {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if client, ok := s.workspaceClients[teamID]; ok {
+		return client
+	}
+	return s.botClient
+}
+
+// newBotClients creates the PR_BOT_SLACK_BOT_TOKEN client (nil when unset) and one
+// client per workspace in PR_BOT_SLACK_WORKSPACE_TOKENS, keyed by team ID.
+func newBotClients(ctx context.Context, cfg *models.Config) (*slack.BotClient, map[string]*slack.BotClient) {
+	var botClient *slack.BotClient
+	if cfg.SlackBotToken != "" {
+		botClient = slack.NewBotClient(cfg.SlackBotToken, slack.OptionsFromConfig(cfg))
+		if err := botClient.TestAuth(ctx); err != nil {
+			logger.Debug("Failed to authenticate Slack bot: %v", err)
+		}
+	}
+
+	workspaceClients := make(map[string]*slack.BotClient, len(cfg.WorkspaceTokens))
+	for teamID, token := range cfg.WorkspaceTokens {
+		client := slack.NewBotClient(token, slack.OptionsFromConfig(cfg))
+		if err := client.TestAuth(ctx); err != nil {
+			logger.Debug("Failed to authenticate Slack bot for workspace %s: %v", teamID, err)
+		}
+		workspaceClients[teamID] = client
+	}
+	return botClient, workspaceClients
+}
+
+// registerHooks adds the server's analysis hooks to a. Authors listed in
+// PR_BOT_SLACK_AUTHOR_USERS get a DM from botClient when their PR is analyzed.
+func registerHooks(a *analyzer.Analyzer, cfg *models.Config, botClient *slack.BotClient) {
+	if botClient == nil || len(cfg.AuthorSlackUsers) == 0 {
+		return
+	}
+	a.RegisterHook(analyzer.NewSlackNotificationHook(botClient, cfg.AuthorSlackUsers))
+}
+
+// watchReloadSignal reloads the configuration on every SIGHUP until ctx is done.
+func (s *SlackServer) watchReloadSignal(ctx context.Context) {
+	sighup := make(chan os.Signal, 1)
+	signal.Notify(sighup, syscall.SIGHUP)
+	defer signal.Stop(sighup)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-sighup:
+			logger.Info("🔄 SIGHUP received, reloading configuration")
+			if err := s.reloadConfig(); err != nil {
+				logger.Info("⚠️  Configuration reload failed, keeping previous configuration: %v", err)
+			}
+		}
+	}
+}
+
+// reloadConfig loads the configuration again and swaps in a new analyzer when any field changed,
+// and new Slack bot clients when their tokens or proxy settings changed.
+func (s *SlackServer) reloadConfig() error {
+	newCfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	oldCfg := s.currentConfig()
+	changed := changedConfigFields(oldCfg, newCfg)
+	if len(changed) == 0 {
+		logger.Info("Configuration unchanged")
+		return nil
+	}
+	logger.Info("Configuration fields changed: %s", strings.Join(changed, ", "))
+
+	// The analyzer holds the configuration and owns the GitHub, GitLab and Jira
+	// clients, so it is rebuilt for any change and picks up rotated tokens for all three.
+	ctx := context.Background()
+	newAnalyzer, err := analyzer.New(ctx, newCfg, s.repoManager, analyzer.WithGARefreshInterval(newCfg.GARefreshInterval))
+	if err != nil {
+		return err
+	}
+
+	s.mu.RLock()
+	newBotClient, newWorkspaceClients := s.botClient, s.workspaceClients
+	s.mu.RUnlock()
+	if newCfg.SlackBotToken != oldCfg.SlackBotToken || !maps.Equal(newCfg.WorkspaceTokens, oldCfg.WorkspaceTokens) || proxy.FromConfig(newCfg) != proxy.FromConfig(oldCfg) {
+		newBotClient, newWorkspaceClients = newBotClients(ctx, newCfg)
+	}
+	registerHooks(newAnalyzer, newCfg, newBotClient)
+
+	s.mu.Lock()
+	oldAnalyzer := s.analyzer
+	s.config = newCfg
+	s.analyzer = newAnalyzer
+	s.botClient = newBotClient
+	s.workspaceClients = newWorkspaceClients
+	s.mu.Unlock()
+
+	oldAnalyzer.Close()
+
+	return nil
+}
+
+// changedConfigFields returns the JSON names of fields that differ between two configurations.
+func changedConfigFields(oldCfg, newCfg *models.Config) []string {
+	var changed []string
+	oldVal := reflect.ValueOf(*oldCfg)
+	newVal := reflect.ValueOf(*newCfg)
+	cfgType := oldVal.Type()
+
+	for i := 0; i < cfgType.NumField(); i++ {
+		if !reflect.DeepEqual(oldVal.Field(i).Interface(), newVal.Field(i).Interface()) {
+			name := strings.Split(cfgType.Field(i).Tag.Get("json"), ",")[0]
+			if name == "" {
+				name = cfgType.Field(i).Name
+			}
+			changed = append(changed, name)
+		}
+	}
+
+	return changed
+}
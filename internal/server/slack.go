@@ -2,23 +2,28 @@
 package server
 
 import (
+	"bytes"
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"flag"
 	"fmt"
 	"io"
 	"math"
 	"net/http"
-	"strconv"
 	"os"
 	"os/signal"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"time"
+	"unicode/utf8"
 
 	"github.com/shay23bra/pr-bot/internal/ga"
 	"github.com/shay23bra/pr-bot/internal/github"
@@ -26,41 +31,105 @@ import (
 	"github.com/shay23bra/pr-bot/internal/jira"
 	"github.com/shay23bra/pr-bot/internal/logger"
 	"github.com/shay23bra/pr-bot/internal/models"
+	"github.com/shay23bra/pr-bot/internal/resultstore"
 	"github.com/shay23bra/pr-bot/internal/slack"
 	"github.com/shay23bra/pr-bot/pkg/analyzer"
 )
 
 // SlackServer handles Slack bot requests
 type SlackServer struct {
-	config      *models.Config
-	analyzer    *analyzer.Analyzer
-	repoManager *gitlocal.RepoManager
-	botClient   *slack.BotClient
-	botUserID   string
+	mu               sync.RWMutex // guards config, analyzer and the bot clients across reloads
+	config           *models.Config
+	analyzer         *analyzer.Analyzer
+	repoManager      *gitlocal.RepoManager
+	botClient        *slack.BotClient
+	workspaceClients map[string]*slack.BotClient // Bot clients of PR_BOT_SLACK_WORKSPACE_TOKENS, keyed by team ID
+	botUserID        string
+	resultStore      *resultstore.Store
+	contexts         ContextStore
+	metrics          *serverMetrics  // nil unless PR_BOT_METRICS_ENABLED is set
+	recent           *recentAnalyses // PR analyses listed on each user's Home tab
+	subscriptions    *prSubscriptions
+	webhookPRs       webhookDedup // merged PRs recently analyzed for GitHub webhook events
+
+	shuttingDown  atomic.Bool    // set once shutdown starts; new commands are turned away
+	inFlight      sync.WaitGroup // async analyses and event handlers still running
+	inFlightCount atomic.Int64   // number of goroutines in inFlight, for shutdown logs
+}
+
+// Server timeouts and the grace period for in-flight work on shutdown.
+const (
+	DefaultServerReadTimeout  = 15 * time.Second
+	DefaultServerWriteTimeout = 30 * time.Second
+	DefaultServerIdleTimeout  = 60 * time.Second
+	DefaultShutdownTimeout    = 30 * time.Second
+)
+
+// shuttingDownMessage is returned for commands received after shutdown started.
+const shuttingDownMessage = "🛑 PR Bot is restarting, please try again in a minute."
+
+// SlackServerOption customizes a SlackServer created by NewSlackServer.
+type SlackServerOption func(*SlackServer)
+
+// WithContextStore sets where conversation context is kept, e.g. a FileContextStore
+// to keep it across restarts. Without it the server uses an in-memory store.
+func WithContextStore(store ContextStore) SlackServerOption {
+	return func(s *SlackServer) {
+		s.contexts = store
+	}
 }
 
 // NewSlackServer creates a new Slack server instance
-func NewSlackServer(cfg *models.Config, repoManager *gitlocal.RepoManager) (*SlackServer, error) {
+func NewSlackServer(cfg *models.Config, repoManager *gitlocal.RepoManager, opts ...SlackServerOption) (*SlackServer, error) {
+	if cfg.SlackSigningSecret == "" {
+		return nil, errors.New("Slack signing secret not configured: set PR_BOT_SLACK_SIGNING_SECRET")
+	}
+
 	ctx := context.Background()
-	a, err := analyzer.New(ctx, cfg, repoManager)
+	a, err := analyzer.New(ctx, cfg, repoManager, analyzer.WithGARefreshInterval(cfg.GARefreshInterval))
 	if err != nil {
 		return nil, fmt.Errorf("failed to create analyzer: %w", err)
 	}
 
-	var botClient *slack.BotClient
-	if cfg.SlackBotToken != "" {
-		botClient = slack.NewBotClient(cfg.SlackBotToken)
-		if err := botClient.TestAuth(ctx); err != nil {
-			logger.Debug("Failed to authenticate Slack bot: %v", err)
+	botClient, workspaceClients := newBotClients(ctx, cfg)
+	registerHooks(a, cfg, botClient)
+
+	var store *resultstore.Store
+	if cfg.ResultStorePath != "" {
+		store, err = resultstore.New(cfg.ResultStorePath)
+		if err != nil {
+			logger.Debug("Result store unavailable, analysis diffs disabled: %v", err)
 		}
 	}
 
-	return &SlackServer{
-		config:      cfg,
-		repoManager: repoManager,
-		analyzer:    a,
-		botClient:   botClient,
-	}, nil
+	server := &SlackServer{
+		config:           cfg,
+		repoManager:      repoManager,
+		analyzer:         a,
+		botClient:        botClient,
+		workspaceClients: workspaceClients,
+		resultStore:      store,
+		recent:           newRecentAnalyses(),
+		subscriptions:    newPRSubscriptions(),
+	}
+	for _, opt := range opts {
+		opt(server)
+	}
+
+	if server.contexts == nil {
+		server.contexts = NewInMemoryContextStore()
+	}
+
+	if cfg.MetricsEnabled {
+		server.metrics = newServerMetrics()
+	}
+
+	return server, nil
+}
+
+// WarmUp pre-fetches the release branches of the supported repositories.
+func (s *SlackServer) WarmUp() error {
+	return s.currentAnalyzer().WarmUp()
 }
 
 // Start starts the Slack bot server with graceful shutdown.
@@ -68,43 +137,112 @@ func (s *SlackServer) Start(port int) error {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/slack/commands", s.verifySlackRequest(s.handleSlashCommand))
 	mux.HandleFunc("/slack/events", s.verifySlackRequest(s.handleEvents))
+	mux.HandleFunc("/slack/interactive", s.verifySlackRequest(s.handleInteraction))
 	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/github/webhook", s.handleGitHubWebhook)
+	if s.metrics != nil {
+		mux.Handle("/metrics", s.metrics.handler())
+	}
 
 	addr := fmt.Sprintf(":%d", port)
 	fmt.Printf("🚀 Slack bot server starting on port %d\n", port)
 	fmt.Printf("📝 Endpoints:\n")
 	fmt.Printf("   POST /slack/commands - Slack slash commands\n")
 	fmt.Printf("   POST /slack/events   - Slack event subscriptions\n")
+	fmt.Printf("   POST /slack/interactive - Slack modal submissions (/pr form)\n")
 	fmt.Printf("   GET  /health        - Health check\n")
+	fmt.Printf("   POST /github/webhook - GitHub pull_request events (analyzes merged PRs)\n")
+	if s.metrics != nil {
+		fmt.Printf("   GET  /metrics       - Prometheus metrics\n")
+	}
 
-	if s.config.SlackSigningSecret == "" {
-		logger.Debug("⚠️  Slack signing secret not configured — requests will not be verified")
+	if s.currentConfig().GitHubWebhookSecret == "" {
+		logger.Debug("GitHub webhook secret not configured — /github/webhook is disabled")
 	}
 
+	cfg := s.currentConfig()
 	srv := &http.Server{
-		Addr:    addr,
-		Handler: mux,
+		Addr:         addr,
+		Handler:      mux,
+		ReadTimeout:  durationOrDefault(cfg.ServerReadTimeout, DefaultServerReadTimeout),
+		WriteTimeout: durationOrDefault(cfg.ServerWriteTimeout, DefaultServerWriteTimeout),
+		IdleTimeout:  durationOrDefault(cfg.ServerIdleTimeout, DefaultServerIdleTimeout),
 	}
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
 
+	go s.watchReloadSignal(ctx)
+	go s.pollSubscriptions(ctx)
+
+	shutdownDone := make(chan struct{})
 	go func() {
+		defer close(shutdownDone)
 		<-ctx.Done()
-		fmt.Println("\n🛑 Shutting down server...")
-		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		s.shuttingDown.Store(true)
+		timeout := durationOrDefault(s.currentConfig().ShutdownTimeout, DefaultShutdownTimeout)
+		logger.Info("🛑 Shutting down gracefully, waiting up to %s for %d in-flight request(s)…", timeout, s.inFlightCount.Load())
+
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
 		defer cancel()
-		srv.Shutdown(shutdownCtx)
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Info("HTTP server shutdown: %v", err)
+		}
+		s.waitForInFlight(shutdownCtx)
+	}()
+
+	if err := listenAndServe(srv, cfg); err != nil {
+		return err
+	}
+	<-shutdownDone
+	return nil
+}
+
+// goTracked runs fn in a goroutine that shutdown waits for.
+func (s *SlackServer) goTracked(fn func()) {
+	s.inFlight.Add(1)
+	s.inFlightCount.Add(1)
+	go func() {
+		defer s.inFlight.Done()
+		defer s.inFlightCount.Add(-1)
+		fn()
+	}()
+}
+
+// waitForInFlight waits for tracked goroutines until ctx expires.
+func (s *SlackServer) waitForInFlight(ctx context.Context) {
+	done := make(chan struct{})
+	go func() {
+		s.inFlight.Wait()
+		close(done)
 	}()
 
-	return srv.ListenAndServe()
+	select {
+	case <-done:
+		logger.Info("All in-flight analyses completed")
+	case <-ctx.Done():
+		logger.Info("Shutdown timeout expired with %d in-flight request(s) still running", s.inFlightCount.Load())
+	}
 }
 
+// durationOrDefault returns d, or fallback when d is not positive.
+func durationOrDefault(d, fallback time.Duration) time.Duration {
+	if d > 0 {
+		return d
+	}
+	return fallback
+}
+
+// maxSlackRequestBytes caps the request body read for signature verification.
+const maxSlackRequestBytes = 1 << 20
+
 // verifySlackRequest wraps a handler with Slack request signature verification.
 func (s *SlackServer) verifySlackRequest(next http.HandlerFunc) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if s.config.SlackSigningSecret == "" {
-			next(w, r)
+		// Without a secret no request can be verified, so none are accepted
+		secret := s.currentConfig().SlackSigningSecret
+		if secret == "" {
+			http.Error(w, "Slack signing secret not configured", http.StatusUnauthorized)
 			return
 		}
 
@@ -123,15 +261,16 @@ func (s *SlackServer) verifySlackRequest(next http.HandlerFunc) http.HandlerFunc
 			return
 		}
 
-		body, err := io.ReadAll(r.Body)
+		// Buffer the body so the signature and the handler both see it
+		body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSlackRequestBytes))
 		if err != nil {
 			http.Error(w, "Failed to read body", http.StatusBadRequest)
 			return
 		}
-		r.Body = io.NopCloser(strings.NewReader(string(body)))
+		r.Body = io.NopCloser(bytes.NewReader(body))
 
 		baseString := fmt.Sprintf("v0:%s:%s", timestamp, string(body))
-		mac := hmac.New(sha256.New, []byte(s.config.SlackSigningSecret))
+		mac := hmac.New(sha256.New, []byte(secret))
 		mac.Write([]byte(baseString))
 		expected := "v0=" + hex.EncodeToString(mac.Sum(nil))
 
@@ -144,13 +283,6 @@ func (s *SlackServer) verifySlackRequest(next http.HandlerFunc) http.HandlerFunc
 	}
 }
 
-// handleHealth provides a health check endpoint
-func (s *SlackServer) handleHealth(w http.ResponseWriter, r *http.Request) {
-	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(http.StatusOK)
-	w.Write([]byte(`{"status":"healthy","service":"pr-bot"}`))
-}
-
 // handleSlashCommand processes Slack slash commands
 func (s *SlackServer) handleSlashCommand(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -167,10 +299,27 @@ func (s *SlackServer) handleSlashCommand(w http.ResponseWriter, r *http.Request)
 	// Extract Slack command data
 	command := r.FormValue("command")
 	text := strings.TrimSpace(r.FormValue("text"))
+	teamID := r.FormValue("team_id")
 	userID := r.FormValue("user_id")
 	channelID := r.FormValue("channel_id")
 
-	logger.Debug("=== RECEIVED SLACK COMMAND: %s, text: %s, user: %s, channel: %s ===", command, text, userID, channelID)
+	logger.Debug("=== RECEIVED SLACK COMMAND: %s, text: %s, team: %s, user: %s, channel: %s ===", command, text, teamID, userID, channelID)
+	s.metrics.commandReceived(command)
+
+	if s.shuttingDown.Load() {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(shuttingDownMessage))
+		return
+	}
+
+	// Protected commands get an ephemeral denial before any work is started
+	if !s.isCommandAllowed(r.Context(), teamID, command, userID) {
+		w.Header().Set("Content-Type", "text/plain")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(commandDeniedMessage))
+		return
+	}
 
 	// Route command
 	var response string
@@ -181,26 +330,45 @@ func (s *SlackServer) handleSlashCommand(w http.ResponseWriter, r *http.Request)
 		response = s.getHelpMessage()
 	case "/pr":
 		if text == "" {
-			response = "❌ Usage: `/pr <PR_URL>`"
+			response = s.handleSlashCommandWithModal(r.Context(), teamID, r.FormValue("trigger_id"))
 		} else {
 			// Send immediate response and process async
-			go s.analyzePRAsync(text, r.FormValue("response_url"), userID)
+			responseURL := r.FormValue("response_url")
+			opts := prCommandOptions{URL: text, IncludeRelated: true}
+			s.goTracked(func() { s.analyzePRAsync(opts, responseURL, userID) })
 			response = "🔍 Analyzing PR... This may take a moment. Results will appear shortly."
 		}
+	case "/pr-diff":
+		if text == "" {
+			response = "❌ Usage: `/pr-diff <PR_URL>`"
+		} else {
+			responseURL := r.FormValue("response_url")
+			s.goTracked(func() { s.analyzePRDiffAsync(text, responseURL) })
+			response = "🔍 Analyzing PR again to compare with the previous analysis... Results will appear shortly."
+		}
 	case "/jt":
 		if text == "" {
-			response = "❌ Usage: `/jt <JIRA_TICKET>`"
+			response = "❌ Usage: `/jt <JIRA_TICKET> [--project <KEY>] [--repo <OWNER/REPO>] [component=<NAME>] [assignee=<USER>]`"
+		} else if opts, parseErr := parseJiraCommand(text); parseErr != nil {
+			response = fmt.Sprintf("❌ %v\nUsage: `/jt <JIRA_TICKET> [--project <KEY>] [--repo <OWNER/REPO>] [component=<NAME>] [assignee=<USER>]`", parseErr)
 		} else {
 			// Send immediate response and process async
-			go s.analyzeJiraTicketAsync(text, r.FormValue("response_url"), userID)
+			responseURL := r.FormValue("response_url")
+			s.goTracked(func() { s.analyzeJiraTicketAsync(opts, responseURL, userID) })
 			response = "🔍 Analyzing JIRA ticket... This may take a moment. Results will appear shortly."
 		}
 	case "/version":
 		if text == "" {
-			response = "❌ Usage: `/version <COMPONENT> <VERSION>` or `/version mce <COMPONENT> <VERSION>`"
+			response = "❌ Usage: `/version <COMPONENT> <VERSION> [SINCE] [UNTIL]`, `/version mce <COMPONENT> <VERSION> [SINCE] [UNTIL]` or `/version timeline [ACM|MCE]`"
 		} else {
 			response, err = s.handleVersionCommand(text)
 		}
+	case "/subscribe":
+		response = s.handleSubscribeCommand(text, teamID, channelID)
+	case "/unsubscribe":
+		response = s.handleUnsubscribeCommand(text)
+	case "/subscriptions":
+		response = s.handleSubscriptionsCommand(text)
 	default:
 		response = fmt.Sprintf("Unknown command: %s\n\nUse `/info` to see available commands.", command)
 	}
@@ -216,16 +384,75 @@ func (s *SlackServer) handleSlashCommand(w http.ResponseWriter, r *http.Request)
 	w.Write([]byte(response))
 }
 
+// commandDeniedMessage is returned when a user is not in any group allowed by the command ACL.
+const commandDeniedMessage = "❌ You don't have permission to use this command"
+
+// commandAliases maps text command aliases to the command names used in PR_BOT_COMMAND_ACL.
+var commandAliases = map[string]string{
+	"v":    "version",
+	"jira": "jt",
+}
+
+// isCommandAllowed checks the command ACL. Commands without an ACL entry are open to everyone;
+// protected commands require membership in at least one of the listed Slack user groups,
+// checked in the user's workspace teamID.
+func (s *SlackServer) isCommandAllowed(ctx context.Context, teamID, command, userID string) bool {
+	name := strings.TrimPrefix(command, "/")
+	if alias, ok := commandAliases[name]; ok {
+		name = alias
+	}
+
+	groups := s.currentConfig().CommandACL[name]
+	if len(groups) == 0 {
+		return true
+	}
+
+	botClient := s.botClientFor(teamID)
+	if botClient == nil {
+		logger.Debug("Denying %s for %s: bot client unavailable to check user groups", name, userID)
+		return false
+	}
+
+	for _, groupID := range groups {
+		inGroup, err := botClient.CheckUserInGroup(ctx, userID, groupID)
+		if err != nil {
+			logger.Debug("Failed to check user %s in group %s: %v", userID, groupID, err)
+			continue
+		}
+		if inGroup {
+			return true
+		}
+	}
+
+	logger.Debug("Denying %s for %s: not in any allowed user group", name, userID)
+	return false
+}
+
+// prCommandOptions holds the input of a PR analysis requested from Slack.
+type prCommandOptions struct {
+	URL            string // PR URL or number
+	JiraTicket     string // Optional JIRA ticket analyzed after the PR
+	IncludeRelated bool   // Show related PRs found through the PR's JIRA tickets
+	Brief          bool   // Only list the release branches containing the PR
+}
+
 // analyzePR analyzes a PR via Slack
 func (s *SlackServer) analyzePR(prURL, userID string) (string, error) {
+	return s.analyzePRWithOptions(prCommandOptions{URL: prURL, IncludeRelated: true}, userID)
+}
+
+// analyzePRWithOptions analyzes a PR via Slack, formatting the result as opts asks
+func (s *SlackServer) analyzePRWithOptions(opts prCommandOptions, userID string) (_ string, err error) {
+	defer func(start time.Time) { s.metrics.analysisDone(analysisKindPR, start, err) }(time.Now())
+
 	// Parse PR number and repository
-	prNumber, owner, repo, err := github.ParsePRInput(prURL)
+	prNumber, owner, repo, err := github.ParsePRInput(opts.URL, github.EnterpriseHost(s.currentConfig().GitHubBaseURL))
 	if err != nil {
 		return "", fmt.Errorf("failed to parse PR URL: %w", err)
 	}
 
 	// Create analyzer with correct repository info
-	cfg := *s.config
+	cfg := *s.currentConfig()
 	if owner != "" && repo != "" {
 		cfg.Owner = owner
 		cfg.Repository = repo
@@ -242,8 +469,16 @@ func (s *SlackServer) analyzePR(prURL, userID string) (string, error) {
 		return "", fmt.Errorf("failed to analyze PR: %w", err)
 	}
 
+	// Compare against the previous analysis before formatting
+	diffMessage := s.recordAnalysisResult(cfg.Owner, cfg.Repository, prNumber, result)
+	s.recent.add(userID, result)
+
+	if opts.Brief {
+		return formatBriefPRAnalysisForSlack(result) + diffMessage, nil
+	}
+
 	// If JIRA analysis was performed and found related PRs, enhance the response
-	if result.JiraAnalysis != nil && len(result.RelatedPRs) > 0 {
+	if opts.IncludeRelated && result.JiraAnalysis != nil && len(result.RelatedPRs) > 0 {
 		// Find unmerged related PRs from the same JIRA tickets
 		var unmergedPRs []models.UnmergedPR
 
@@ -272,9 +507,7 @@ func (s *SlackServer) analyzePR(prURL, userID string) (string, error) {
 
 			// If not found in merged PRs, check if it's unmerged
 			if !found {
-				ctx := context.Background()
-				githubClient := github.NewClient(ctx, s.config.GitHubToken)
-				prInfo, prErr := githubClient.GetBasicPRInfo(relatedOwner, relatedRepo, relatedPRNumber)
+				prInfo, prErr := a.GetGitHubClient().GetBasicPRInfo(relatedOwner, relatedRepo, relatedPRNumber)
 				if prErr != nil {
 					logger.Debug("Failed to get basic info for related PR %d: %v", relatedPRNumber, prErr)
 				} else if prInfo.MergedAt == nil {
@@ -283,7 +516,7 @@ func (s *SlackServer) analyzePR(prURL, userID string) (string, error) {
 						Number: prInfo.Number,
 						Title:  prInfo.Title,
 						URL:    prInfo.URL,
-						Status: "In Review",
+						Status: prInfo.ReviewStatus(),
 					}
 					unmergedPRs = append(unmergedPRs, unmergedPR)
 					logger.Debug("Found unmerged related PR #%d: %s", relatedPRNumber, prInfo.Title)
@@ -292,11 +525,12 @@ func (s *SlackServer) analyzePR(prURL, userID string) (string, error) {
 		}
 
 		// Use enhanced formatting that shows related PRs and unmerged PRs
-		response := s.formatEnhancedPRAnalysisForSlack(result, unmergedPRs, userID)
+		result.UnmergedPRs = unmergedPRs
+		response := s.formatEnhancedPRAnalysisForSlack(result, userID)
 		if result.SheetsUnavailable {
 			response += "\n" + sheetsUnavailableSlackMessage()
 		}
-		return response, nil
+		return response + diffMessage, nil
 	}
 
 	// Format response for Slack (standard format for PRs without JIRA analysis)
@@ -304,30 +538,226 @@ func (s *SlackServer) analyzePR(prURL, userID string) (string, error) {
 	if result.SheetsUnavailable {
 		response += "\n" + sheetsUnavailableSlackMessage()
 	}
-	return response, nil
+	return response + diffMessage, nil
+}
+
+// recordAnalysisResult stores the result and returns a Slack message describing
+// branch changes since the previous analysis, or an empty string.
+func (s *SlackServer) recordAnalysisResult(owner, repo string, prNumber int, result *models.PRAnalysisResult) string {
+	if s.resultStore == nil {
+		return ""
+	}
+
+	previous, err := s.resultStore.Swap(resultstore.Key(owner, repo, prNumber), result)
+	if err != nil {
+		logger.Debug("Failed to update result store: %v", err)
+		return ""
+	}
+
+	diff := models.DiffAnalysisResults(previous, result)
+	if !diff.HasBranchChanges() {
+		return ""
+	}
+	return "\n" + formatAnalysisDiffForSlack(diff)
+}
+
+// formatAnalysisDiffForSlack formats an analysis diff for Slack
+func formatAnalysisDiffForSlack(diff *models.AnalysisDiff) string {
+	var response strings.Builder
+	response.WriteString("🔀 *Changes since last analysis:*\n")
+	for _, branch := range diff.NewBranches {
+		response.WriteString(fmt.Sprintf("  ➕ Now in `%s`\n", branch.BranchName))
+	}
+	for _, branch := range diff.RemovedBranches {
+		response.WriteString(fmt.Sprintf("  ➖ No longer found in `%s`\n", branch.BranchName))
+	}
+	for _, change := range diff.GADateChanges {
+		response.WriteString(fmt.Sprintf("  📅 %s %s GA date (`%s`): %s → %s\n",
+			change.Product, change.Version, change.BranchName,
+			models.FormatDateWithNil(change.OldDate), models.FormatDateWithNil(change.NewDate)))
+	}
+	for _, change := range diff.UpcomingGAChanges {
+		response.WriteString(fmt.Sprintf("  🗓️ Upcoming GAs for `%s`: %s → %s\n",
+			change.BranchName, models.FormatUpcomingGAs(change.Before), models.FormatUpcomingGAs(change.After)))
+	}
+	return response.String()
+}
+
+// analyzePRDiff analyzes a PR again and describes what changed since the result
+// stored by its previous analysis from Slack. The fresh result replaces the stored one.
+func (s *SlackServer) analyzePRDiff(prURL string) (_ string, err error) {
+	defer func(start time.Time) { s.metrics.analysisDone(analysisKindPR, start, err) }(time.Now())
+
+	if s.resultStore == nil {
+		return "", fmt.Errorf("analysis diffs are disabled, set PR_BOT_RESULT_STORE to keep previous results")
+	}
+
+	prNumber, owner, repo, err := github.ParsePRInput(prURL, github.EnterpriseHost(s.currentConfig().GitHubBaseURL))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse PR URL: %w", err)
+	}
+
+	cfg := *s.currentConfig()
+	if owner != "" && repo != "" {
+		cfg.Owner = owner
+		cfg.Repository = repo
+	}
+	a, err := analyzer.New(context.Background(), &cfg, s.repoManager)
+	if err != nil {
+		return "", fmt.Errorf("failed to create analyzer: %w", err)
+	}
+
+	result, err := a.AnalyzePR(prNumber)
+	if err != nil {
+		return "", fmt.Errorf("failed to analyze PR: %w", err)
+	}
+
+	previous, err := s.resultStore.Swap(resultstore.Key(cfg.Owner, cfg.Repository, prNumber), result)
+	if err != nil {
+		return "", fmt.Errorf("failed to update result store: %w", err)
+	}
+	if previous == nil {
+		return fmt.Sprintf("ℹ️ No earlier analysis of PR #%d to compare with. This one is saved, so `/pr-diff` will compare against it next time.", prNumber), nil
+	}
+
+	diff := models.DiffAnalysisResults(previous, result)
+	header := fmt.Sprintf("*PR #%d* compared with the analysis from %s\n", prNumber, previous.AnalyzedAt.UTC().Format("2006-01-02 15:04 MST"))
+	if !diff.HasChanges() {
+		return header + "✅ No changes", nil
+	}
+	return header + formatAnalysisDiffForSlack(diff), nil
+}
+
+// analyzePRDiffAsync runs analyzePRDiff and sends the result via response_url
+func (s *SlackServer) analyzePRDiffAsync(prURL, responseURL string) {
+	message, err := s.analyzePRDiff(prURL)
+	if err != nil {
+		message = fmt.Sprintf("❌ Error comparing PR analyses: %v", err)
+	}
+	s.sendDelayedResponse(responseURL, message)
+}
+
+// jiraCommandOptions holds the arguments of a /jt command.
+type jiraCommandOptions struct {
+	Ticket  string           // Ticket key or URL
+	Project string           // JIRA project key used to qualify bare ticket numbers
+	Repo    string           // Extra owner/repo whose PRs should be analyzed
+	Filter  jira.IssueFilter // Only analyze PRs of tickets with this component and assignee
+}
+
+// parseJiraCommand parses "/jt <ticket> [--project KEY] [--repo owner/repo]
+// [component=NAME] [assignee=USER]".
+func parseJiraCommand(text string) (jiraCommandOptions, error) {
+	var opts jiraCommandOptions
+
+	fs := flag.NewFlagSet("jt", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&opts.Project, "project", "", "JIRA project key")
+	fs.StringVar(&opts.Repo, "repo", "", "GitHub repository (owner/repo)")
+
+	// component= and assignee= may appear anywhere, so take them out before parsing flags
+	var args []string
+	for _, arg := range strings.Fields(text) {
+		switch {
+		case strings.HasPrefix(arg, "component="):
+			opts.Filter.Component = strings.TrimPrefix(arg, "component=")
+		case strings.HasPrefix(arg, "assignee="):
+			opts.Filter.Assignee = strings.TrimPrefix(arg, "assignee=")
+		default:
+			args = append(args, arg)
+		}
+	}
+
+	// Allow the ticket before or after the flags
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		opts.Ticket = args[0]
+		args = args[1:]
+	}
+	if err := fs.Parse(args); err != nil {
+		return opts, fmt.Errorf("invalid arguments: %v", err)
+	}
+	if opts.Ticket == "" {
+		opts.Ticket = fs.Arg(0)
+	}
+	if opts.Ticket == "" {
+		return opts, fmt.Errorf("a JIRA ticket is required")
+	}
+
+	if opts.Repo != "" {
+		parts := strings.Split(opts.Repo, "/")
+		if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return opts, fmt.Errorf("--repo must be in owner/repo form, got %q", opts.Repo)
+		}
+	}
+
+	if opts.Project != "" {
+		opts.Project = strings.ToUpper(opts.Project)
+		if _, err := strconv.Atoi(opts.Ticket); err == nil {
+			opts.Ticket = opts.Project + "-" + opts.Ticket
+		}
+	}
+
+	return opts, nil
 }
 
 // analyzeJiraTicket analyzes a JIRA ticket via Slack
-func (s *SlackServer) analyzeJiraTicket(ticketURL, userID string) (string, error) {
+func (s *SlackServer) analyzeJiraTicket(opts jiraCommandOptions, userID string) (_ string, err error) {
+	defer func(start time.Time) { s.metrics.analysisDone(analysisKindJira, start, err) }(time.Now())
+
+	serverCfg := *s.currentConfig()
+	result, err := runJiraAnalysis(serverCfg, s.repoManager, opts)
+	if err != nil {
+		return "", err
+	}
+
+	response := s.formatJiraAnalysisForSlack(result.JiraAnalysis, result.RelatedPRs, result.UnmergedPRs, userID)
+	if a := s.currentAnalyzer(); a != nil && a.IsSheetsUnavailable() {
+		response += "\n" + sheetsUnavailableSlackMessage()
+	}
+
+	// P1 results also go to the urgent channel so they are not lost in a DM or thread
+	if jira.PriorityLevel(result.JiraAnalysis.Priority) == jira.PriorityLevelP1 && serverCfg.UrgentChannel != "" {
+		if botClient := s.currentBotClient(); botClient != nil {
+			if err := botClient.PostSimpleMessage(context.Background(), serverCfg.UrgentChannel, response); err != nil {
+				logger.Debug("Failed to post P1 analysis to urgent channel %s: %v", serverCfg.UrgentChannel, err)
+			}
+		}
+	}
+	return response, nil
+}
+
+// runJiraAnalysis analyzes every supported PR referenced by a JIRA ticket and its clones.
+// serverCfg is a copy, so per-request overrides such as --repo do not leak into the server config.
+func runJiraAnalysis(serverCfg models.Config, repoManager *gitlocal.RepoManager, opts jiraCommandOptions) (*models.JiraAnalysisResult, error) {
+	ticketURL := opts.Ticket
 	logger.Debug("=== STARTING JIRA TICKET ANALYSIS FOR: %s ===", ticketURL)
 	// Extract JIRA ticket ID (supports any project prefix like ACM, MGMT, etc.)
 	ticketID := jira.ExtractJiraTicketFromText(ticketURL)
 	if ticketID == "" {
-		return "", fmt.Errorf("failed to extract JIRA ticket ID from: %s", ticketURL)
+		return nil, fmt.Errorf("failed to extract JIRA ticket ID from: %s", ticketURL)
+	}
+	if opts.Project != "" && !strings.HasPrefix(ticketID, opts.Project+"-") {
+		return nil, fmt.Errorf("ticket %s is not in project %s", ticketID, opts.Project)
 	}
 
-	if s.config.JiraToken == "" || s.config.JiraEmail == "" {
-		return "", fmt.Errorf("JIRA not configured. Please set PR_BOT_JIRA_TOKEN and PR_BOT_JIRA_EMAIL in your .env file")
+	defaultOwner := serverCfg.Owner
+	if opts.Repo != "" {
+		parts := strings.SplitN(opts.Repo, "/", 2)
+		serverCfg.Owner = parts[0]
+		serverCfg.Repository = parts[1]
+	}
+	if serverCfg.JiraToken == "" || serverCfg.JiraEmail == "" {
+		return nil, fmt.Errorf("JIRA not configured. Please set PR_BOT_JIRA_TOKEN and PR_BOT_JIRA_EMAIL in your .env file")
 	}
 
 	// Create JIRA client
 	ctx := context.Background()
-	jiraClient := jira.NewClient(ctx, s.config.JiraEmail, s.config.JiraToken)
+	jiraClient := jira.NewClient(ctx, serverCfg.JiraEmail, serverCfg.JiraToken, jira.OptionsFromConfig(&serverCfg))
 
 	// Get all related JIRA tickets (main ticket + cloned tickets)
 	allTicketIssues, err := jiraClient.GetAllClonedIssues(ticketID)
 	if err != nil {
-		return "", fmt.Errorf("failed to get related JIRA tickets: %w", err)
+		return nil, fmt.Errorf("failed to get related JIRA tickets: %w", err)
 	}
 
 	// Extract ticket keys for display
@@ -338,8 +768,14 @@ func (s *SlackServer) analyzeJiraTicket(ticketURL, userID string) (string, error
 
 	// Extract all PR URLs from all tickets
 	var allPRURLs []string
+	prURLsByTicket := make(map[string][]string)
 	for _, ticket := range allTicketIssues {
+		if !opts.Filter.Matches(ticket) {
+			logger.Debug("Skipping %s: does not match %s", ticket.Key, opts.Filter)
+			continue
+		}
 		prURLs := jiraClient.ExtractGitHubPRsFromIssue(ticket)
+		prURLsByTicket[ticket.Key] = prURLs
 		allPRURLs = append(allPRURLs, prURLs...)
 	}
 
@@ -349,11 +785,14 @@ func (s *SlackServer) analyzeJiraTicket(ticketURL, userID string) (string, error
 
 	// Support assisted-service, assisted-installer, assisted-installer-agent, and assisted-installer-ui repositories
 	supportedRepos := []string{
-		fmt.Sprintf("github.com/%s/assisted-service/pull/", s.config.Owner),
-		fmt.Sprintf("github.com/%s/assisted-installer/pull/", s.config.Owner),
-		fmt.Sprintf("github.com/%s/assisted-installer-agent/pull/", s.config.Owner),
+		fmt.Sprintf("github.com/%s/assisted-service/pull/", defaultOwner),
+		fmt.Sprintf("github.com/%s/assisted-installer/pull/", defaultOwner),
+		fmt.Sprintf("github.com/%s/assisted-installer-agent/pull/", defaultOwner),
 		fmt.Sprintf("github.com/openshift-assisted/assisted-installer-ui/pull/"), // Different owner
 	}
+	if opts.Repo != "" {
+		supportedRepos = append(supportedRepos, fmt.Sprintf("github.com/%s/pull/", opts.Repo))
+	}
 
 	logger.Debug("Found %d total PR URLs from JIRA tickets", len(allPRURLs))
 	logger.Debug("Supported repos: %v", supportedRepos)
@@ -383,11 +822,34 @@ func (s *SlackServer) analyzeJiraTicket(ticketURL, userID string) (string, error
 
 	logger.Debug("After filtering: %d unique PR URLs", len(uniquePRURLs))
 
+	// Blocking links between the tickets decide the order their PRs should merge in
+	supportedPRURLsByTicket := make(map[string][]string, len(prURLsByTicket))
+	for key, prURLs := range prURLsByTicket {
+		for _, prURL := range prURLs {
+			if prURLsMap[prURL] {
+				supportedPRURLsByTicket[key] = append(supportedPRURLsByTicket[key], prURL)
+			}
+		}
+	}
+	mergeOrder, err := jira.SuggestedMergeOrder(allTicketIssues, supportedPRURLsByTicket)
+	if err != nil {
+		logger.Debug("Cannot suggest a merge order: %v", err)
+	}
+
 	// Create JIRA analysis result
+	var mainFields jira.JiraFields
+	if len(allTicketIssues) > 0 {
+		mainFields = allTicketIssues[0].Fields
+	}
 	jiraAnalysis := &models.JiraAnalysis{
 		MainTicket:      ticketID,
+		Priority:        mainFields.Priority.Name,
+		Epic:            mainFields.Epic,
+		EpicSummary:     mainFields.EpicSummary,
+		FixVersions:     mainFields.FixVersions,
 		AllTickets:      allTicketKeys,
 		RelatedPRURLs:   uniquePRURLs,
+		MergeOrder:      mergeOrder,
 		AnalysisSuccess: true,
 	}
 
@@ -401,10 +863,10 @@ func (s *SlackServer) analyzeJiraTicket(ticketURL, userID string) (string, error
 		if a, ok := analyzerCache[key]; ok {
 			return a, nil
 		}
-		cfg := *s.config
+		cfg := serverCfg
 		cfg.Owner = owner
 		cfg.Repository = repo
-		a, err := analyzer.New(ctx, &cfg, s.repoManager)
+		a, err := analyzer.New(ctx, &cfg, repoManager)
 		if err != nil {
 			return nil, err
 		}
@@ -418,8 +880,7 @@ func (s *SlackServer) analyzeJiraTicket(ticketURL, userID string) (string, error
 
 	logger.Debug("Starting parallel analysis of %d unique PR URLs", len(uniquePRURLs))
 
-	const maxWorkers = 3
-	sem := make(chan struct{}, maxWorkers)
+	sem := make(chan struct{}, serverCfg.Concurrency.WithDefaults().PRAnalysisWorkers)
 	var wg sync.WaitGroup
 
 	for i, prURL := range uniquePRURLs {
@@ -444,84 +905,44 @@ func (s *SlackServer) analyzeJiraTicket(ticketURL, userID string) (string, error
 				return
 			}
 
+			addUnmerged := func(unmergedPR models.UnmergedPR) {
+				mu.Lock()
+				unmergedPRs = append(unmergedPRs, unmergedPR)
+				mu.Unlock()
+			}
+
+			// Unmerged PRs are listed from their basic info without being analyzed
+			prInfo, err := a.GetGitHubClient().GetBasicPRInfo(owner, repo, prNumber)
+			if err != nil {
+				logger.Debug("Failed to get basic info for PR %d: %v", prNumber, err)
+				addUnmerged(models.UnmergedPR{
+					Number: prNumber,
+					Title:  fmt.Sprintf("PR #%d (analysis failed)", prNumber),
+					URL:    url,
+					Status: models.StatusAnalysisFailed,
+				})
+				return
+			}
+			if prInfo.MergedAt == nil {
+				logger.Debug("Added unmerged PR #%d to results: %s", prNumber, prInfo.Title)
+				addUnmerged(models.UnmergedPR{
+					Number: prInfo.Number,
+					Title:  prInfo.Title,
+					URL:    prInfo.URL,
+					Status: prInfo.ReviewStatus(),
+				})
+				return
+			}
+
 			result, err := a.AnalyzePR(prNumber)
 			if err != nil {
-				logger.Debug("PR %d analysis failed with error: %s", prNumber, err.Error())
-				// Check if it's an unmerged PR
-				if strings.Contains(err.Error(), "is not merged") || strings.Contains(err.Error(), "not merged") {
-					logger.Debug("Detected unmerged PR %d, getting basic info", prNumber)
-					// Get basic PR info for unmerged PR
-					githubClient := github.NewClient(ctx, s.config.GitHubToken)
-					prInfo, prErr := githubClient.GetBasicPRInfo(owner, repo, prNumber)
-					if prErr != nil {
-						logger.Debug("Failed to get basic info for unmerged PR %d: %v", prNumber, prErr)
-						// Even if we can't get basic info, still add it as an unmerged PR with minimal info
-						unmergedPR := models.UnmergedPR{
-							Number: prNumber,
-							Title:  fmt.Sprintf("PR #%d (unmerged)", prNumber),
-							URL:    url,
-							Status: "In Review",
-						}
-						mu.Lock()
-						unmergedPRs = append(unmergedPRs, unmergedPR)
-						mu.Unlock()
-						logger.Debug("Added unmerged PR #%d to results (basic info failed): %s", prNumber, url)
-					} else {
-						unmergedPR := models.UnmergedPR{
-							Number: prInfo.Number,
-							Title:  prInfo.Title,
-							URL:    prInfo.URL,
-							Status: "In Review",
-						}
-						mu.Lock()
-						unmergedPRs = append(unmergedPRs, unmergedPR)
-						mu.Unlock()
-						logger.Debug("Added unmerged PR #%d to results: %s", prNumber, prInfo.Title)
-					}
-				} else {
-					logger.Debug("Failed to analyze PR %d (not unmerged), getting basic info", prNumber)
-					// For other analysis failures, still try to get basic PR info
-					githubClient := github.NewClient(ctx, s.config.GitHubToken)
-					prInfo, prErr := githubClient.GetBasicPRInfo(owner, repo, prNumber)
-					if prErr != nil {
-						logger.Debug("Failed to get basic info for PR %d: %v", prNumber, prErr)
-						// Even if we can't get basic info, still add it as an unmerged PR with minimal info
-						unmergedPR := models.UnmergedPR{
-							Number: prNumber,
-							Title:  fmt.Sprintf("PR #%d (analysis failed)", prNumber),
-							URL:    url,
-							Status: "Analysis Failed",
-						}
-						mu.Lock()
-						unmergedPRs = append(unmergedPRs, unmergedPR)
-						mu.Unlock()
-						logger.Debug("Added PR #%d to results (analysis failed): %s", prNumber, url)
-					} else if prInfo.MergedAt == nil {
-						// It's an unmerged PR
-						unmergedPR := models.UnmergedPR{
-							Number: prInfo.Number,
-							Title:  prInfo.Title,
-							URL:    prInfo.URL,
-							Status: "In Review",
-						}
-						mu.Lock()
-						unmergedPRs = append(unmergedPRs, unmergedPR)
-						mu.Unlock()
-						logger.Debug("Added unmerged PR #%d to results (analysis failed): %s", prNumber, prInfo.Title)
-					} else {
-						// It's a merged PR but analysis failed - add it as unmerged with error status
-						unmergedPR := models.UnmergedPR{
-							Number: prInfo.Number,
-							Title:  prInfo.Title,
-							URL:    prInfo.URL,
-							Status: "Analysis Failed",
-						}
-						mu.Lock()
-						unmergedPRs = append(unmergedPRs, unmergedPR)
-						mu.Unlock()
-						logger.Debug("Added merged PR #%d to results (analysis failed): %s", prNumber, prInfo.Title)
-					}
-				}
+				logger.Debug("Added merged PR #%d to results (analysis failed): %v", prNumber, err)
+				addUnmerged(models.UnmergedPR{
+					Number: prInfo.Number,
+					Title:  prInfo.Title,
+					URL:    prInfo.URL,
+					Status: models.StatusAnalysisFailed,
+				})
 				return
 			}
 
@@ -547,37 +968,92 @@ func (s *SlackServer) analyzeJiraTicket(ticketURL, userID string) (string, error
 	wg.Wait()
 	logger.Debug("Parallel PR analysis completed: %d merged, %d unmerged", len(relatedPRs), len(unmergedPRs))
 
-	response := s.formatJiraAnalysisForSlack(jiraAnalysis, relatedPRs, unmergedPRs, userID)
-	if s.analyzer != nil && s.analyzer.IsSheetsUnavailable() {
-		response += "\n" + sheetsUnavailableSlackMessage()
-	}
-	return response, nil
+	return &models.JiraAnalysisResult{
+		JiraAnalysis: jiraAnalysis,
+		RelatedPRs:   relatedPRs,
+		UnmergedPRs:  unmergedPRs,
+	}, nil
 }
 
 // handleVersionCommand handles version comparison commands
 func (s *SlackServer) handleVersionCommand(text string) (string, error) {
 	args := strings.Fields(text)
+	if len(args) >= 1 && args[0] == "timeline" {
+		// Upcoming GA dates: /version timeline [ACM|MCE]
+		product := ""
+		if len(args) >= 2 {
+			product = args[1]
+		}
+		return s.releaseTimeline(product)
+	}
 	if len(args) < 2 {
-		return "❌ Usage: `/version <COMPONENT> <VERSION>` or `/version mce <COMPONENT> <VERSION>`\n\nAvailable components: assisted-service, assisted-installer, assisted-installer-agent, assisted-installer-ui", nil
+		return "❌ Usage: `/version <COMPONENT> <VERSION> [SINCE] [UNTIL]` or `/version mce <COMPONENT> <VERSION> [SINCE] [UNTIL]`\n\nSINCE and UNTIL are YYYY-MM-DD dates limiting the commits listed.\nAvailable components: assisted-service, assisted-installer, assisted-installer-agent, assisted-installer-ui", nil
 	}
 
 	if len(args) >= 3 && args[0] == "mce" {
-		// MCE version comparison: /version mce assisted-service 2.8.0
+		// MCE version comparison: /version mce assisted-service 2.8.0 [2025-06-01] [2025-06-30]
 		component := args[1]
 		version := args[2]
-		return s.compareMCEVersionWithComponent(component, version)
+		since, until := optionalArg(args, 3), optionalArg(args, 4)
+		return s.compareMCEVersionWithComponent(component, version, since, until)
 	} else {
-		// Regular version comparison: /version assisted-service v2.40.1
+		// Regular version comparison: /version assisted-service v2.40.1 [2025-06-01] [2025-06-30]
 		component := args[0]
 		version := args[1]
-		return s.compareVersionWithComponent(component, version)
+		since, until := optionalArg(args, 2), optionalArg(args, 3)
+		return s.compareVersionWithComponent(component, version, since, until)
 	}
 }
 
-// compareVersionWithComponent compares regular versions with component
-func (s *SlackServer) compareVersionWithComponent(component, version string) (string, error) {
+// optionalArg returns args[i], or "" if there are not that many arguments
+func optionalArg(args []string, i int) string {
+	if i < len(args) {
+		return args[i]
+	}
+	return ""
+}
+
+// releaseTimeline lists upcoming GA dates, optionally for one product
+func (s *SlackServer) releaseTimeline(product string) (string, error) {
+	a := s.currentAnalyzer()
+	if a == nil || a.GetGAParser() == nil {
+		return "", fmt.Errorf("release schedule is not configured (PR_BOT_GOOGLE_SHEET_ID)")
+	}
+
+	entries, err := a.GetGAParser().GetReleaseTimeline(product, time.Now())
+	if err != nil {
+		return "", fmt.Errorf("failed to build release timeline: %w", err)
+	}
+	return formatReleaseTimelineForSlack(entries), nil
+}
+
+// formatReleaseTimelineForSlack formats upcoming GA dates for Slack
+func formatReleaseTimelineForSlack(entries []ga.TimelineEntry) string {
+	var response strings.Builder
+	response.WriteString("📅 *Upcoming GA dates*\n\n")
+	if len(entries) == 0 {
+		response.WriteString("No upcoming GA dates in the release schedule\n")
+		return response.String()
+	}
+	for _, entry := range entries {
+		line := fmt.Sprintf("• %s — %s %s", models.FormatDate(&entry.GADate), entry.Product, entry.Version)
+		if entry.Branch != "" {
+			line += fmt.Sprintf(" (`%s`)", entry.Branch)
+		}
+		response.WriteString(line + "\n")
+	}
+	return truncateForSlack(response.String(), "pr-bot -timeline")
+}
+
+// compareVersionWithComponent compares regular versions with component, listing the
+// commits committed between since and until (YYYY-MM-DD, either may be empty)
+func (s *SlackServer) compareVersionWithComponent(component, version, since, until string) (string, error) {
+	if _, _, err := models.ParseCommitDateRange(since, until); err != nil {
+		return "", err
+	}
+
 	ctx := context.Background()
-	cfg := *s.config
+	cfg := *s.currentConfig()
 	a, err := analyzer.New(ctx, &cfg, s.repoManager)
 	if err != nil {
 		return "", fmt.Errorf("failed to create analyzer: %w", err)
@@ -587,30 +1063,76 @@ func (s *SlackServer) compareVersionWithComponent(component, version string) (st
 	if err != nil {
 		return "", err
 	}
+	if err := result.FilterByDate(since, until); err != nil {
+		return "", err
+	}
 
 	return s.formatVersionComparisonForSlack(result), nil
 }
 
-// compareMCEVersionWithComponent compares MCE versions with component
-func (s *SlackServer) compareMCEVersionWithComponent(component, version string) (string, error) {
-	return "", fmt.Errorf("MCE version comparison is not yet available in Slack mode — use CLI: `pr-bot -v mce %s %s`", component, version)
+// compareMCEVersionWithComponent compares the component SHAs of an MCE version and
+// its previous release, taken from their GitLab snapshots
+func (s *SlackServer) compareMCEVersionWithComponent(component, version, since, until string) (string, error) {
+	if _, _, err := models.ParseCommitDateRange(since, until); err != nil {
+		return "", err
+	}
+
+	ctx := context.Background()
+	cfg := *s.currentConfig()
+	a, err := analyzer.New(ctx, &cfg, s.repoManager)
+	if err != nil {
+		return "", fmt.Errorf("failed to create analyzer: %w", err)
+	}
+	defer a.Close()
+
+	result, err := a.CompareMCEVersions(component, version)
+	if err != nil {
+		return "", err
+	}
+	if err := result.FilterByDate(since, until); err != nil {
+		return "", err
+	}
+
+	return s.formatVersionComparisonForSlack(result), nil
 }
 
+// maxSlackVersionCommits caps the commits listed in a Slack version comparison.
+const maxSlackVersionCommits = 30
+
+// formatVersionComparisonForSlack formats a version comparison as a Slack message,
+// listing at most maxSlackVersionCommits commits in a monospace block
 func (s *SlackServer) formatVersionComparisonForSlack(result *models.VersionComparisonResult) string {
 	var response strings.Builder
 
 	response.WriteString(fmt.Sprintf("📦 *Version Comparison: %s*\n", result.TargetVersion))
 	response.WriteString(fmt.Sprintf("Component: `%s` (%s/%s)\n", result.Component, result.Owner, result.Repository))
 	response.WriteString(fmt.Sprintf("Comparing: `%s` → `%s`\n", result.PreviousVersion, result.TargetVersion))
-	response.WriteString(fmt.Sprintf("Total commits: %d\n\n", len(result.Commits)))
+	if label := models.CommitDateRangeLabel(result.Since, result.Until); label != "" {
+		response.WriteString(fmt.Sprintf("Total commits (committed %s): %d\n\n", label, len(result.Commits)))
+	} else {
+		response.WriteString(fmt.Sprintf("Total commits: %d\n\n", len(result.Commits)))
+	}
 
 	if len(result.Commits) == 0 {
 		response.WriteString("No commits found between versions\n")
 		return response.String()
 	}
 
-	for _, c := range result.Commits {
-		response.WriteString(fmt.Sprintf("`%s`  %s  %s\n", c.ShortHash, c.Date, c.Title))
+	commits := result.Commits
+	if len(commits) > maxSlackVersionCommits {
+		commits = commits[:maxSlackVersionCommits]
+	}
+	response.WriteString("```\n")
+	for _, c := range commits {
+		date := c.Date
+		if len(date) > len(models.CommitFilterDateLayout) {
+			date = date[:len(models.CommitFilterDateLayout)]
+		}
+		response.WriteString(fmt.Sprintf("%-10s  %s  %s\n", c.ShortHash, date, c.Title))
+	}
+	response.WriteString("```\n")
+	if more := len(result.Commits) - len(commits); more > 0 {
+		response.WriteString(fmt.Sprintf("…and %d more commits. Use the CLI for the full list.\n", more))
 	}
 
 	return response.String()
@@ -629,28 +1151,25 @@ func (s *SlackServer) formatPRAnalysisForSlack(result *models.PRAnalysisResult,
 	response.WriteString(fmt.Sprintf("📋 *PR Analysis: #%d*\n", result.PR.Number))
 	response.WriteString(fmt.Sprintf("🔗 %s\n", result.PR.URL))
 	response.WriteString(fmt.Sprintf("📝 %s\n", result.PR.Title))
-	response.WriteString(fmt.Sprintf("🔨 Merged to `%s` at %s\n\n", result.PR.MergedInto, models.FormatDate(result.PR.MergedAt)))
-
-	allBranchesMap := make(map[string]models.BranchPresence)
-	for _, branch := range result.ReleaseBranches {
-		if branch.Found {
-			allBranchesMap[branch.BranchName] = branch
-		}
-	}
+	response.WriteString(fmt.Sprintf("🔨 Merged to `%s` at %s\n", result.PR.MergedInto, models.FormatDate(result.PR.MergedAt)))
+	writeSlackCoAuthors(&response, result.PR.CoAuthors)
+	response.WriteString("\n")
 
-	if len(allBranchesMap) == 0 {
+	if branches := result.AllFoundBranches(false); len(branches) == 0 {
 		response.WriteString("❌ No release branches found containing this PR\n")
 	} else {
-		s.writeSlackBranchList(&response, allBranchesMap)
+		s.writeSlackBranchList(&response, branches)
 	}
+	writeSlackSkippedBranches(&response, result.ReleaseBranches)
 
-	return response.String()
+	return truncateForSlack(response.String(), "pr-bot -pr "+result.PR.URL)
 }
 
 // formatEnhancedPRAnalysisForSlack formats PR analysis results with related PRs for Slack,
 // combining all branches from the main PR and backports into one unified view (matching CLI output).
-func (s *SlackServer) formatEnhancedPRAnalysisForSlack(result *models.PRAnalysisResult, unmergedPRs []models.UnmergedPR, userID string) string {
+func (s *SlackServer) formatEnhancedPRAnalysisForSlack(result *models.PRAnalysisResult, userID string) string {
 	var response strings.Builder
+	summary := result.Summary()
 
 	if userID != "" {
 		response.WriteString(fmt.Sprintf("Hi <@%s>, here's the analysis for pull request %s #%d\n\n", userID, result.PR.URL, result.PR.Number))
@@ -662,7 +1181,9 @@ func (s *SlackServer) formatEnhancedPRAnalysisForSlack(result *models.PRAnalysis
 	response.WriteString(fmt.Sprintf("📋 *PR Analysis: #%d*\n", result.PR.Number))
 	response.WriteString(fmt.Sprintf("🔗 %s\n", result.PR.URL))
 	response.WriteString(fmt.Sprintf("📝 %s\n", result.PR.Title))
-	response.WriteString(fmt.Sprintf("🔨 Merged to `%s` at %s\n\n", result.PR.MergedInto, models.FormatDate(result.PR.MergedAt)))
+	response.WriteString(fmt.Sprintf("🔨 Merged to `%s` at %s\n", result.PR.MergedInto, models.FormatDate(result.PR.MergedAt)))
+	writeSlackCoAuthors(&response, result.PR.CoAuthors)
+	response.WriteString("\n")
 
 	// JIRA information
 	if result.JiraAnalysis != nil {
@@ -671,23 +1192,17 @@ func (s *SlackServer) formatEnhancedPRAnalysisForSlack(result *models.PRAnalysis
 			response.WriteString(fmt.Sprintf("🔗 Related tickets: %s\n", strings.Join(result.JiraAnalysis.AllTickets[1:], ", ")))
 		}
 
-		backportCount := 0
-		for _, rp := range result.RelatedPRs {
-			if rp.Number != result.PR.Number {
-				backportCount++
-			}
-		}
-		if backportCount > 0 {
-			response.WriteString(fmt.Sprintf("📊 Found %d related backport PRs:\n", backportCount))
+		if summary.RelatedMergedPRs > 0 {
+			response.WriteString(fmt.Sprintf("📊 Found %d related backport PRs:\n", summary.RelatedMergedPRs))
 			for _, rp := range result.RelatedPRs {
 				if rp.Number != result.PR.Number {
 					response.WriteString(fmt.Sprintf("  • PR #%d: %s\n", rp.Number, rp.Title))
 				}
 			}
 		}
-		if len(unmergedPRs) > 0 {
-			response.WriteString(fmt.Sprintf("🔄 %d PRs in review:\n", len(unmergedPRs)))
-			for _, up := range unmergedPRs {
+		if summary.RelatedUnmergedPRs > 0 {
+			response.WriteString(fmt.Sprintf("🔄 %d PRs in review:\n", summary.RelatedUnmergedPRs))
+			for _, up := range result.UnmergedPRs {
 				response.WriteString(fmt.Sprintf("  • PR #%d: %s\n", up.Number, up.Title))
 			}
 		}
@@ -695,84 +1210,50 @@ func (s *SlackServer) formatEnhancedPRAnalysisForSlack(result *models.PRAnalysis
 	}
 
 	// Combine all branches from main PR and related PRs (same as CLI)
-	allBranchesMap := make(map[string]models.BranchPresence)
-	for _, branch := range result.ReleaseBranches {
-		if branch.Found {
-			allBranchesMap[branch.BranchName] = branch
-		}
-	}
-	for _, relatedPR := range result.RelatedPRs {
-		if relatedPR.Number != result.PR.Number {
-			for _, branch := range relatedPR.ReleaseBranches {
-				if branch.Found {
-					if _, exists := allBranchesMap[branch.BranchName]; !exists {
-						allBranchesMap[branch.BranchName] = branch
-					}
-				}
-			}
-		}
-	}
-
-	if len(allBranchesMap) == 0 {
+	if summary.FoundBranches == 0 {
 		response.WriteString("❌ No release branches found\n")
 	} else {
-		s.writeSlackBranchList(&response, allBranchesMap)
+		s.writeSlackBranchList(&response, result.AllFoundBranches(true))
 	}
+	writeSlackSkippedBranches(&response, result.ReleaseBranches)
 
-	return response.String()
+	return truncateForSlack(response.String(), "pr-bot -pr "+result.PR.URL)
 }
 
-// addGAInfoToSlackResponse adds GA release information to the Slack response
-func (s *SlackServer) addGAInfoToSlackResponse(response *strings.Builder, branch models.BranchPresence) {
-	now := time.Now()
-
-	// Show upcoming GA versions (including released ones)
-	if len(branch.UpcomingGAs) > 0 {
-		// Track products to avoid duplicates
-		productStatus := make(map[string]bool)
-
-		// First pass: show released versions
-		for _, upcomingGA := range branch.UpcomingGAs {
-			if upcomingGA.GADate != nil && upcomingGA.GADate.Before(now) {
-				if !productStatus[upcomingGA.Product] {
-					productStatus[upcomingGA.Product] = true
-					response.WriteString(fmt.Sprintf("\n    🚀 %s %s: Released (GA: %s)",
-						upcomingGA.Product, upcomingGA.Version, models.FormatDate(upcomingGA.GADate)))
-				}
-			}
-		}
-
-		// Second pass: show upcoming releases for products without released versions
-		productNotReleased := make(map[string]bool)
-		for _, upcomingGA := range branch.UpcomingGAs {
-			if !productStatus[upcomingGA.Product] && !productNotReleased[upcomingGA.Product] {
-				productNotReleased[upcomingGA.Product] = true
-				response.WriteString(fmt.Sprintf("\n    ⏳ %s %s: Upcoming (GA: %s)",
-					upcomingGA.Product, upcomingGA.Version, models.FormatDate(upcomingGA.GADate)))
-			}
-		}
+// truncateForSlack keeps a response within Slack's block text limit, pointing to
+// the CLI command that prints the full output.
+func truncateForSlack(response, cliCommand string) string {
+	note := fmt.Sprintf("\n... (truncated – run `%s` CLI for full output)", cliCommand)
+	truncated, wasTruncated := slack.TruncateSlackMessage(response, slack.MaxBlockTextLength-utf8.RuneCountInString(note))
+	if !wasTruncated {
+		return response
 	}
+	return truncated + note
+}
 
-	// Show latest GA status (already released versions from GAStatus)
-	hasLatestGA := (branch.GAStatus.ACM.Version != "" && branch.GAStatus.ACM.Status == "GA" &&
-		branch.GAStatus.ACM.GADate != nil && branch.GAStatus.ACM.GADate.Before(now)) ||
-		(branch.GAStatus.MCE.Version != "" && branch.GAStatus.MCE.Status == "GA" &&
-			branch.GAStatus.MCE.GADate != nil && branch.GAStatus.MCE.GADate.Before(now))
+// writeSlackCoAuthors adds a context line crediting the PR's co-authors
+func writeSlackCoAuthors(response *strings.Builder, coAuthors []string) {
+	if len(coAuthors) > 0 {
+		response.WriteString(fmt.Sprintf("👥 _Co-authored by: %s_\n", strings.Join(coAuthors, ", ")))
+	}
+}
 
-	if hasLatestGA {
-		if branch.GAStatus.ACM.Version != "" && branch.GAStatus.ACM.Status == "GA" &&
-			branch.GAStatus.ACM.GADate != nil && branch.GAStatus.ACM.GADate.Before(now) {
-			response.WriteString(fmt.Sprintf("\n    ✅ ACM %s: Released (GA: %s)",
-				branch.GAStatus.ACM.Version, models.FormatDate(branch.GAStatus.ACM.GADate)))
-		}
-		if branch.GAStatus.MCE.Version != "" && branch.GAStatus.MCE.Status == "GA" &&
-			branch.GAStatus.MCE.GADate != nil && branch.GAStatus.MCE.GADate.Before(now) {
-			response.WriteString(fmt.Sprintf("\n    ✅ MCE %s: Released (GA: %s)",
-				branch.GAStatus.MCE.Version, models.FormatDate(branch.GAStatus.MCE.GADate)))
+// writeSlackSkippedBranches lists branches a hotfix PR intentionally skipped
+func writeSlackSkippedBranches(response *strings.Builder, branches []models.BranchPresence) {
+	for _, branch := range branches {
+		if branch.Skipped {
+			response.WriteString(fmt.Sprintf("⏭️ `%s` %s\n", branch.BranchName, branch.SkipNote()))
 		}
 	}
 }
 
+// addGAInfoToSlackResponse adds GA release information to the Slack response
+func (s *SlackServer) addGAInfoToSlackResponse(response *strings.Builder, branch models.BranchPresence) {
+	for _, block := range slack.GAStatusToSlackBlocks(branch.GAStatus, branch.UpcomingGAs) {
+		response.WriteString("\n    " + block.Text.Text)
+	}
+}
+
 // formatJiraAnalysisForSlack formats JIRA analysis results for Slack with combined branch view.
 func (s *SlackServer) formatJiraAnalysisForSlack(jiraAnalysis *models.JiraAnalysis, relatedPRs []models.RelatedPR, unmergedPRs []models.UnmergedPR, userID string) string {
 	var response strings.Builder
@@ -784,6 +1265,20 @@ func (s *SlackServer) formatJiraAnalysisForSlack(jiraAnalysis *models.JiraAnalys
 	}
 
 	response.WriteString(fmt.Sprintf("🎫 *JIRA Ticket Analysis: %s*\n", jiraAnalysis.MainTicket))
+	if badge := jira.PriorityBadge(jiraAnalysis.Priority); badge != "" {
+		sla := jira.BackportSLA(jira.PriorityLevel(jiraAnalysis.Priority))
+		response.WriteString(fmt.Sprintf("%s - backports expected within %s\n", badge, jira.FormatSLA(sla)))
+	}
+	if jiraAnalysis.Epic != "" {
+		epic := fmt.Sprintf("<%s|%s>", jira.BrowseURL(jiraAnalysis.Epic), jiraAnalysis.Epic)
+		if jiraAnalysis.EpicSummary != "" {
+			epic += fmt.Sprintf(" (%s)", jiraAnalysis.EpicSummary)
+		}
+		response.WriteString(fmt.Sprintf("📚 Epic: %s\n", epic))
+	}
+	if len(jiraAnalysis.FixVersions) > 0 {
+		response.WriteString(fmt.Sprintf("🎯 Fix versions: %s\n", strings.Join(jiraAnalysis.FixVersions, ", ")))
+	}
 
 	if len(jiraAnalysis.AllTickets) > 1 {
 		response.WriteString(fmt.Sprintf("🔗 Related tickets: %s\n", strings.Join(jiraAnalysis.AllTickets[1:], ", ")))
@@ -802,7 +1297,13 @@ func (s *SlackServer) formatJiraAnalysisForSlack(jiraAnalysis *models.JiraAnalys
 		response.WriteString(fmt.Sprintf("  • PR #%d: %s\n", rp.Number, rp.Title))
 	}
 	for _, up := range unmergedPRs {
-		response.WriteString(fmt.Sprintf("  • PR #%d: %s _(in review)_\n", up.Number, up.Title))
+		response.WriteString(fmt.Sprintf("  • PR #%d: %s _(%s)_\n", up.Number, up.Title, strings.ToLower(up.Status.String())))
+	}
+	if len(jiraAnalysis.MergeOrder) > 0 {
+		response.WriteString("🧭 *Suggested merge order:*\n")
+		for i, prURL := range jiraAnalysis.MergeOrder {
+			response.WriteString(fmt.Sprintf("  %d. %s\n", i+1, prURL))
+		}
 	}
 	response.WriteString("\n")
 
@@ -821,16 +1322,45 @@ func (s *SlackServer) formatJiraAnalysisForSlack(jiraAnalysis *models.JiraAnalys
 	if len(allBranchesMap) == 0 {
 		response.WriteString("❌ No release branches found across all analyzed PRs\n")
 	} else {
-		s.writeSlackBranchList(&response, allBranchesMap)
+		var branches []models.BranchPresence
+		for _, branch := range allBranchesMap {
+			branches = append(branches, branch)
+		}
+		s.writeSlackBranchList(&response, branches)
 	}
 
-	return response.String()
+	if jira.PriorityLevel(jiraAnalysis.Priority) == jira.PriorityLevelP1 && hasMissingBackports(relatedPRs, unmergedPRs) {
+		response.WriteString(fmt.Sprintf("\n%s\n", jira.HighUrgencyMessage))
+	}
+
+	return truncateForSlack(response.String(), "pr-bot -jt "+jiraAnalysis.MainTicket)
 }
 
-// analyzePRAsync analyzes a PR asynchronously and sends result via response_url
-func (s *SlackServer) analyzePRAsync(prURL, responseURL, userID string) {
+// hasMissingBackports reports whether any related PR is unmerged or not yet in a release branch.
+func hasMissingBackports(relatedPRs []models.RelatedPR, unmergedPRs []models.UnmergedPR) bool {
+	if len(unmergedPRs) > 0 {
+		return true
+	}
+	for _, rp := range relatedPRs {
+		found := false
+		for _, branch := range rp.ReleaseBranches {
+			if branch.Found {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return true
+		}
+	}
+	return false
+}
+
+// analyzePRAsync analyzes a PR asynchronously and sends result via response_url.
+// A JIRA ticket in opts is analyzed afterwards and sent as a second response.
+func (s *SlackServer) analyzePRAsync(opts prCommandOptions, responseURL, userID string) {
 	// Perform the analysis
-	result, err := s.analyzePR(prURL, userID)
+	result, err := s.analyzePRWithOptions(opts, userID)
 
 	var message string
 	if err != nil {
@@ -841,13 +1371,17 @@ func (s *SlackServer) analyzePRAsync(prURL, responseURL, userID string) {
 
 	// Send the result back to Slack using response_url
 	s.sendDelayedResponse(responseURL, message)
+
+	if opts.JiraTicket != "" {
+		s.analyzeJiraTicketAsync(jiraCommandOptions{Ticket: opts.JiraTicket}, responseURL, userID)
+	}
 }
 
 // analyzeJiraTicketAsync analyzes a JIRA ticket asynchronously and sends result via response_url
-func (s *SlackServer) analyzeJiraTicketAsync(ticketURL, responseURL, userID string) {
-	logger.Debug("=== ASYNC JIRA ANALYSIS STARTED: %s (response_url: %s) ===", ticketURL, responseURL)
+func (s *SlackServer) analyzeJiraTicketAsync(opts jiraCommandOptions, responseURL, userID string) {
+	logger.Debug("=== ASYNC JIRA ANALYSIS STARTED: %s (response_url: %s) ===", opts.Ticket, responseURL)
 	// Perform the analysis
-	result, err := s.analyzeJiraTicket(ticketURL, userID)
+	result, err := s.analyzeJiraTicket(opts, userID)
 	logger.Debug("=== ASYNC JIRA ANALYSIS COMPLETED: err=%v ===", err)
 
 	var message string
@@ -897,15 +1431,22 @@ func (s *SlackServer) getHelpMessage() string {
 
 *Available Slash Commands:*
 • ` + "`" + `/info` + "`" + ` - Show this help message
-• ` + "`" + `/pr <PR_URL>` + "`" + ` - Analyze a PR across release branches
-• ` + "`" + `/jt <JIRA_TICKET>` + "`" + ` - Analyze all PRs related to a JIRA ticket
-• ` + "`" + `/version <COMPONENT> <VERSION>` + "`" + ` - Compare GitHub tag with previous version
+• ` + "`" + `/pr <PR_URL>` + "`" + ` - Analyze a PR across release branches (without a URL, opens a form with more options)
+• ` + "`" + `/pr-diff <PR_URL>` + "`" + ` - Analyze a PR again and show what changed since its previous analysis
+• ` + "`" + `/jt <JIRA_TICKET> [--project <KEY>] [--repo <OWNER/REPO>] [component=<NAME>] [assignee=<USER>]` + "`" + ` - Analyze all PRs related to a JIRA ticket
+• ` + "`" + `/version <COMPONENT> <VERSION> [SINCE] [UNTIL]` + "`" + ` - Compare GitHub tag with previous version (optional YYYY-MM-DD commit date range)
 • ` + "`" + `/version mce <COMPONENT> <VERSION>` + "`" + ` - Compare MCE version with previous version
+• ` + "`" + `/version timeline [ACM|MCE]` + "`" + ` - List upcoming GA dates
+• ` + "`" + `/subscribe pr <PR_URL> [channel <#channel>]` + "`" + ` - Post to a channel when the PR lands in a new release branch
+• ` + "`" + `/unsubscribe pr <PR_URL>` + "`" + ` - Stop posting updates about a PR
+• ` + "`" + `/subscriptions list` + "`" + ` - List the PR subscriptions
 
 *Examples:*
 • ` + "`" + `/pr https://github.com/openshift/assisted-service/pull/7788` + "`" + `
 • ` + "`" + `/jt MGMT-20662` + "`" + ` or ` + "`" + `/jt ACM-22787` + "`" + `
 • ` + "`" + `/jt https://issues.redhat.com/browse/ACM-22787` + "`" + `
+• ` + "`" + `/jt OCPBUGS-1234 --repo openshift/installer` + "`" + `
+• ` + "`" + `/jt MGMT-12345 component=assisted-service` + "`" + `
 • ` + "`" + `/version assisted-service v2.40.1` + "`" + `
 • ` + "`" + `/version mce assisted-service 2.8.0` + "`" + `
 
@@ -916,7 +1457,6 @@ func (s *SlackServer) getHelpMessage() string {
 You can also mention the bot (` + "`" + `@pr-bot` + "`" + `) or send direct messages using the same command syntax without the slash.`
 }
 
-
 // handleEvents handles Slack event subscriptions
 func (s *SlackServer) handleEvents(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost {
@@ -928,6 +1468,7 @@ func (s *SlackServer) handleEvents(w http.ResponseWriter, r *http.Request) {
 		Token     string       `json:"token"`
 		Challenge string       `json:"challenge"`
 		Type      string       `json:"type"`
+		TeamID    string       `json:"team_id"`
 		Event     *slack.Event `json:"event,omitempty"`
 	}
 
@@ -945,9 +1486,14 @@ func (s *SlackServer) handleEvents(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Handle event callbacks
+	// Handle event callbacks. During shutdown Slack is asked to retry later instead.
 	if payload.Type == "event_callback" && payload.Event != nil {
-		go s.processSlackEvent(payload.Event)
+		if s.shuttingDown.Load() {
+			http.Error(w, "Shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		teamID, event := payload.TeamID, payload.Event
+		s.goTracked(func() { s.processSlackEvent(teamID, event) })
 	}
 
 	// Acknowledge the event
@@ -955,9 +1501,9 @@ func (s *SlackServer) handleEvents(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("ok"))
 }
 
-// processSlackEvent processes incoming Slack events
-func (s *SlackServer) processSlackEvent(event *slack.Event) {
-	if s.botClient == nil {
+// processSlackEvent processes incoming Slack events from the workspace teamID
+func (s *SlackServer) processSlackEvent(teamID string, event *slack.Event) {
+	if s.botClientFor(teamID) == nil {
 		logger.Debug("Bot client not configured, ignoring event")
 		return
 	}
@@ -972,46 +1518,50 @@ func (s *SlackServer) processSlackEvent(event *slack.Event) {
 	// Handle different event types
 	switch event.Type {
 	case "app_mention":
-		s.handleMention(ctx, event)
+		s.handleMention(ctx, teamID, event)
 	case "message":
 		// Only handle direct messages
 		if event.IsDirectMessage() {
-			s.handleDirectMessage(ctx, event)
+			s.handleDirectMessage(ctx, teamID, event)
+		}
+	case "app_home_opened":
+		if event.Tab == "home" {
+			s.publishHomeView(ctx, teamID, event.User)
 		}
 	}
 }
 
 // handleMention handles when the bot is mentioned in a channel
-func (s *SlackServer) handleMention(ctx context.Context, event *slack.Event) {
+func (s *SlackServer) handleMention(ctx context.Context, teamID string, event *slack.Event) {
 	command := event.ExtractCommand(s.botUserID)
-	response, err := s.handleTextCommand(command, event.User)
+	response, err := s.handleTextCommand(command, teamID, event.User)
 
 	if err != nil {
 		response = fmt.Sprintf("❌ Error: %v", err)
 	}
 
 	// Post response in thread
-	if err := s.botClient.PostThreadReply(ctx, event.Channel, response, event.Timestamp); err != nil {
+	if err := s.botClientFor(teamID).PostThreadReply(ctx, event.Channel, response, event.Timestamp); err != nil {
 		logger.Debug("Failed to post thread reply: %v", err)
 	}
 }
 
 // handleDirectMessage handles direct messages to the bot
-func (s *SlackServer) handleDirectMessage(ctx context.Context, event *slack.Event) {
-	response, err := s.handleTextCommand(event.Text, event.User)
+func (s *SlackServer) handleDirectMessage(ctx context.Context, teamID string, event *slack.Event) {
+	response, err := s.handleTextCommand(event.Text, teamID, event.User)
 
 	if err != nil {
 		response = fmt.Sprintf("❌ Error: %v", err)
 	}
 
 	// Post response in DM
-	if err := s.botClient.PostSimpleMessage(ctx, event.Channel, response); err != nil {
+	if err := s.botClientFor(teamID).PostSimpleMessage(ctx, event.Channel, response); err != nil {
 		logger.Debug("Failed to post DM response: %v", err)
 	}
 }
 
 // handleTextCommand handles text-based commands (from mentions or DMs)
-func (s *SlackServer) handleTextCommand(text, userID string) (string, error) {
+func (s *SlackServer) handleTextCommand(text, teamID, userID string) (string, error) {
 	text = strings.TrimSpace(text)
 
 	if text == "" || text == "help" || text == "info" {
@@ -1029,6 +1579,10 @@ func (s *SlackServer) handleTextCommand(text, userID string) (string, error) {
 		commandText = strings.Join(args[1:], " ")
 	}
 
+	if !s.isCommandAllowed(context.Background(), teamID, command, userID) {
+		return commandDeniedMessage, nil
+	}
+
 	switch command {
 	case "pr":
 		if commandText == "" {
@@ -1036,11 +1590,21 @@ func (s *SlackServer) handleTextCommand(text, userID string) (string, error) {
 		}
 		return s.analyzePR(commandText, userID)
 
+	case "pr-diff":
+		if commandText == "" {
+			return "❌ Usage: `pr-diff <PR_URL>`", nil
+		}
+		return s.analyzePRDiff(commandText)
+
 	case "jt", "jira":
 		if commandText == "" {
-			return "❌ Usage: `jt <JIRA_TICKET>`", nil
+			return "❌ Usage: `jt <JIRA_TICKET> [--project <KEY>] [--repo <OWNER/REPO>] [component=<NAME>] [assignee=<USER>]`", nil
 		}
-		return s.analyzeJiraTicket(commandText, userID)
+		opts, err := parseJiraCommand(commandText)
+		if err != nil {
+			return "", err
+		}
+		return s.analyzeJiraTicket(opts, userID)
 
 	case "version", "v":
 		if commandText == "" {
@@ -1054,22 +1618,22 @@ func (s *SlackServer) handleTextCommand(text, userID string) (string, error) {
 }
 
 // writeSlackBranchList writes a combined branch list to the response, grouped by pattern and sorted.
-func (s *SlackServer) writeSlackBranchList(response *strings.Builder, allBranchesMap map[string]models.BranchPresence) {
+func (s *SlackServer) writeSlackBranchList(response *strings.Builder, allBranches []models.BranchPresence) {
 	// Group by pattern
 	branchGroups := make(map[string][]models.BranchPresence)
-	for _, branch := range allBranchesMap {
+	for _, branch := range allBranches {
 		branchGroups[branch.Pattern] = append(branchGroups[branch.Pattern], branch)
 	}
 
 	// Sort within each group
-	patternOrder := []string{"release-ocm-", "releases/v", "release-", "release-v", "v"}
+	patternOrder := s.currentConfig().BranchPatternOrder()
 	for _, branches := range branchGroups {
 		sort.Slice(branches, func(i, j int) bool {
-			return models.ParseVersionNumber(branches[i].Version) < models.ParseVersionNumber(branches[j].Version)
+			return models.CompareBranchVersions(branches[i].Version, branches[j].Version) < 0
 		})
 	}
 
-	totalBranches := len(allBranchesMap)
+	totalBranches := len(allBranches)
 	response.WriteString(fmt.Sprintf("✅ *Found in %d release branches:*\n", totalBranches))
 
 	for _, pattern := range patternOrder {
@@ -1077,9 +1641,9 @@ func (s *SlackServer) writeSlackBranchList(response *strings.Builder, allBranche
 		if len(branches) == 0 {
 			continue
 		}
-		response.WriteString(fmt.Sprintf("📂 *%s branches (%d):*\n", models.PatternDisplayName(pattern), len(branches)))
+		response.WriteString(fmt.Sprintf("📂 *%s branches (%d):*\n", s.currentConfig().PatternDisplayName(pattern), len(branches)))
 		for _, branch := range branches {
-			response.WriteString(fmt.Sprintf("  • `%s` (v%s)", branch.BranchName, branch.Version))
+			response.WriteString(fmt.Sprintf("  • `%s` (v%s)%s", branch.BranchName, branch.Version, branch.CherryPickNote()))
 			if branch.MergedAt != nil {
 				response.WriteString(fmt.Sprintf(" - merged %s", models.FormatDate(branch.MergedAt)))
 			}
@@ -1088,7 +1652,7 @@ func (s *SlackServer) writeSlackBranchList(response *strings.Builder, allBranche
 			}
 			if len(branch.ReleasedVersions) > 0 {
 				releasedVersionsText := strings.Join(branch.ReleasedVersions, ", ")
-				if pattern == "v" && s.analyzer != nil && s.analyzer.GetGitLabClient() != nil {
+				if pattern == "v" {
 					releasedVersionsText += s.getSaaSVersionBadge(branch.ReleasedVersions[0])
 				}
 				response.WriteString(fmt.Sprintf("\n    📦 Released in: %s", releasedVersionsText))
@@ -1103,14 +1667,17 @@ func sheetsUnavailableSlackMessage() string {
 	return fmt.Sprintf("⚠️ Release schedule data is currently unavailable (Google Sheets API error).\n📊 View the release schedule directly: <%s|Release Schedule>", ga.ReleaseScheduleURL)
 }
 
-// getSaaSVersionBadge returns the badge text for a SaaS version
+// getSaaSVersionBadge returns the badge text for a SaaS version, or "" when it
+// cannot be determined (including when GitLab is not configured)
 func (s *SlackServer) getSaaSVersionBadge(releasedVersion string) string {
-	if s.analyzer == nil {
+	gitlabClient := s.currentAnalyzer().GetGitLabClient()
+	if gitlabClient == nil {
 		return ""
 	}
-	gitlabClient := s.analyzer.GetGitLabClient()
-	if gitlabClient == nil {
+	badge, err := gitlabClient.GetSaaSVersionBadge(releasedVersion)
+	if err != nil {
+		logger.Debug("Failed to get SaaS badge for %s: %v", releasedVersion, err)
 		return ""
 	}
-	return gitlabClient.GetSaaSVersionBadge(releasedVersion)
+	return badge
 }
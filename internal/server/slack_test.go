@@ -0,0 +1,78 @@
+package server
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/models"
+)
+
+// slackSignature signs body the way Slack does for the given timestamp.
+func slackSignature(secret, timestamp, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySlackRequest(t *testing.T) {
+	const (
+		secret = "signing-secret"
+		body   = "command=%2Fpr&text=help"
+	)
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	stale := strconv.FormatInt(time.Now().Add(-10*time.Minute).Unix(), 10)
+
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp string
+		signature string
+		wantCode  int
+	}{
+		{name: "valid signature", secret: secret, timestamp: timestamp, signature: slackSignature(secret, timestamp, body), wantCode: http.StatusOK},
+		{name: "unsigned request", secret: secret, wantCode: http.StatusUnauthorized},
+		{name: "bad signature", secret: secret, timestamp: timestamp, signature: slackSignature("other-secret", timestamp, body), wantCode: http.StatusUnauthorized},
+		{name: "stale timestamp", secret: secret, timestamp: stale, signature: slackSignature(secret, stale, body), wantCode: http.StatusUnauthorized},
+		{name: "no signing secret configured", timestamp: timestamp, signature: slackSignature("", timestamp, body), wantCode: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &SlackServer{config: &models.Config{SlackSigningSecret: tt.secret}}
+			called := false
+			handler := s.verifySlackRequest(func(w http.ResponseWriter, r *http.Request) {
+				called = true
+			})
+
+			req := httptest.NewRequest(http.MethodPost, "/slack/commands", strings.NewReader(body))
+			if tt.timestamp != "" {
+				req.Header.Set("X-Slack-Request-Timestamp", tt.timestamp)
+			}
+			if tt.signature != "" {
+				req.Header.Set("X-Slack-Signature", tt.signature)
+			}
+			rec := httptest.NewRecorder()
+			handler(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if called != (tt.wantCode == http.StatusOK) {
+				t.Errorf("handler called = %v, want %v", called, !called)
+			}
+		})
+	}
+}
+
+func TestNewSlackServerRequiresSigningSecret(t *testing.T) {
+	if _, err := NewSlackServer(&models.Config{}, nil); err == nil {
+		t.Fatal("NewSlackServer() error = nil, want an error without a signing secret")
+	}
+}
@@ -0,0 +1,293 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/github"
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/pkg/analyzer"
+)
+
+// Usage messages of the subscription commands.
+const (
+	subscribeUsage     = "❌ Usage: `/subscribe pr <PR_URL> [channel <#channel>]` (defaults to this channel)"
+	unsubscribeUsage   = "❌ Usage: `/unsubscribe pr <PR_URL>`"
+	subscriptionsUsage = "❌ Usage: `/subscriptions list`"
+)
+
+// slackChannelPattern matches a channel reference as Slack escapes it in slash
+// command text (<#C123|name>), or a bare channel ID.
+var slackChannelPattern = regexp.MustCompile(`^(?:<#([CG][A-Z0-9]+)(?:\|[^>]*)?>|([CG][A-Z0-9]+))$`)
+
+// prSubscription is a PR whose new release branches are posted to Slack channels.
+type prSubscription struct {
+	URL      string
+	Number   int
+	Owner    string
+	Repo     string
+	Channels map[string]string // Channel ID -> team ID of its workspace
+	Known    map[string]bool   // Release branches the PR was last seen in; nil until first checked
+}
+
+// prSubscriptions keeps the PR subscriptions in memory, keyed by "owner/repo#number".
+type prSubscriptions struct {
+	mu   sync.Mutex
+	byPR map[string]*prSubscription
+}
+
+func newPRSubscriptions() *prSubscriptions {
+	return &prSubscriptions{byPR: make(map[string]*prSubscription)}
+}
+
+// subscriptionKey identifies a PR independently of how its URL was written.
+func subscriptionKey(owner, repo string, number int) string {
+	return fmt.Sprintf("%s/%s#%d", owner, repo, number)
+}
+
+// add subscribes channelID of workspace teamID to a PR and reports whether the PR
+// had no subscription yet.
+func (p *prSubscriptions) add(sub prSubscription, channelID, teamID string) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := subscriptionKey(sub.Owner, sub.Repo, sub.Number)
+	existing, ok := p.byPR[key]
+	if !ok {
+		sub.Channels = make(map[string]string)
+		existing = &sub
+		p.byPR[key] = existing
+	}
+	existing.Channels[channelID] = teamID
+	return !ok
+}
+
+// remove drops every subscription to a PR and reports whether there was one.
+func (p *prSubscriptions) remove(owner, repo string, number int) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	key := subscriptionKey(owner, repo, number)
+	_, ok := p.byPR[key]
+	delete(p.byPR, key)
+	return ok
+}
+
+// list returns copies of the subscriptions, sorted by key.
+func (p *prSubscriptions) list() []prSubscription {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	keys := make([]string, 0, len(p.byPR))
+	for key := range p.byPR {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	subs := make([]prSubscription, 0, len(keys))
+	for _, key := range keys {
+		sub := *p.byPR[key]
+		sub.Channels = make(map[string]string, len(p.byPR[key].Channels))
+		for channelID, teamID := range p.byPR[key].Channels {
+			sub.Channels[channelID] = teamID
+		}
+		subs = append(subs, sub)
+	}
+	return subs
+}
+
+// update records the branches a PR is in now and returns the ones it was not in
+// before, sorted, with the channels to notify. Nothing is returned when the PR was
+// not checked before or is no longer subscribed.
+func (p *prSubscriptions) update(key string, branches map[string]bool) ([]string, map[string]string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	sub, ok := p.byPR[key]
+	if !ok {
+		return nil, nil
+	}
+	previous := sub.Known
+	sub.Known = branches
+	if previous == nil {
+		return nil, nil
+	}
+
+	var added []string
+	for branch := range branches {
+		if !previous[branch] {
+			added = append(added, branch)
+		}
+	}
+	sort.Strings(added)
+
+	channels := make(map[string]string, len(sub.Channels))
+	for channelID, teamID := range sub.Channels {
+		channels[channelID] = teamID
+	}
+	return added, channels
+}
+
+// handleSubscribeCommand handles `/subscribe pr <URL> [channel <#channel>]`. Without
+// a channel, the channel the command was sent from is subscribed.
+func (s *SlackServer) handleSubscribeCommand(text, teamID, channelID string) string {
+	args := strings.Fields(text)
+	if len(args) < 2 || args[0] != "pr" {
+		return subscribeUsage
+	}
+	if len(args) == 4 && args[2] == "channel" {
+		match := slackChannelPattern.FindStringSubmatch(args[3])
+		if match == nil {
+			return "❌ Pick the channel from Slack's suggestions when typing `#`, so it is sent as a channel reference"
+		}
+		channelID = match[1] + match[2]
+	} else if len(args) != 2 {
+		return subscribeUsage
+	}
+	if channelID == "" {
+		return subscribeUsage
+	}
+
+	prNumber, owner, repo, err := github.ParsePRInput(args[1], github.EnterpriseHost(s.currentConfig().GitHubBaseURL))
+	if err != nil || owner == "" || repo == "" {
+		return "❌ Please give the full PR URL, e.g. `/subscribe pr https://github.com/openshift/assisted-service/pull/7788`"
+	}
+
+	sub := prSubscription{URL: args[1], Number: prNumber, Owner: owner, Repo: repo}
+	if s.subscriptions.add(sub, channelID, teamID) {
+		// Record the branches the PR is already in, so only later ones are announced
+		key := subscriptionKey(owner, repo, prNumber)
+		s.goTracked(func() { s.checkSubscription(key, sub) })
+	}
+
+	return fmt.Sprintf("🔔 <#%s> will be notified when PR #%d (%s/%s) lands in a new release branch. Checked every %s.",
+		channelID, prNumber, owner, repo, s.currentConfig().SubscriptionPollInterval)
+}
+
+// handleUnsubscribeCommand handles `/unsubscribe pr <URL>`, which removes the
+// subscriptions of all channels to the PR.
+func (s *SlackServer) handleUnsubscribeCommand(text string) string {
+	args := strings.Fields(text)
+	if len(args) != 2 || args[0] != "pr" {
+		return unsubscribeUsage
+	}
+
+	prNumber, owner, repo, err := github.ParsePRInput(args[1], github.EnterpriseHost(s.currentConfig().GitHubBaseURL))
+	if err != nil || owner == "" || repo == "" {
+		return unsubscribeUsage
+	}
+	if !s.subscriptions.remove(owner, repo, prNumber) {
+		return fmt.Sprintf("ℹ️ No channel is subscribed to PR #%d (%s/%s)", prNumber, owner, repo)
+	}
+	return fmt.Sprintf("🔕 Unsubscribed all channels from PR #%d (%s/%s)", prNumber, owner, repo)
+}
+
+// handleSubscriptionsCommand handles `/subscriptions list`.
+func (s *SlackServer) handleSubscriptionsCommand(text string) string {
+	if strings.TrimSpace(text) != "list" {
+		return subscriptionsUsage
+	}
+
+	subs := s.subscriptions.list()
+	if len(subs) == 0 {
+		return "ℹ️ No PR subscriptions. Add one with `/subscribe pr <PR_URL>`."
+	}
+
+	var response strings.Builder
+	response.WriteString(fmt.Sprintf("🔔 *PR subscriptions (%d):*\n", len(subs)))
+	for _, sub := range subs {
+		var channels []string
+		for channelID := range sub.Channels {
+			channels = append(channels, fmt.Sprintf("<#%s>", channelID))
+		}
+		sort.Strings(channels)
+		response.WriteString(fmt.Sprintf("  • <%s|PR #%d> (%s/%s) → %s", sub.URL, sub.Number, sub.Owner, sub.Repo, strings.Join(channels, ", ")))
+		if sub.Known != nil {
+			response.WriteString(fmt.Sprintf(" · in %d release branch(es)", len(sub.Known)))
+		}
+		response.WriteString("\n")
+	}
+	response.WriteString("\nSubscriptions are kept in memory and are lost when the server restarts.")
+	return response.String()
+}
+
+// pollSubscriptions checks every subscribed PR each PR_BOT_SUBSCRIPTION_POLL_INTERVAL
+// until ctx is done.
+func (s *SlackServer) pollSubscriptions(ctx context.Context) {
+	interval := s.currentConfig().SubscriptionPollInterval
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		for _, sub := range s.subscriptions.list() {
+			if ctx.Err() != nil {
+				return
+			}
+			s.checkSubscription(subscriptionKey(sub.Owner, sub.Repo, sub.Number), sub)
+		}
+
+		if current := s.currentConfig().SubscriptionPollInterval; current != interval {
+			interval = current
+			ticker.Reset(interval)
+		}
+	}
+}
+
+// checkSubscription analyzes a subscribed PR again and posts the release branches
+// it newly landed in to the subscribed channels.
+func (s *SlackServer) checkSubscription(key string, sub prSubscription) {
+	cfg := *s.currentConfig()
+	cfg.Owner = sub.Owner
+	cfg.Repository = sub.Repo
+	a, err := analyzer.New(context.Background(), &cfg, s.repoManager)
+	if err != nil {
+		logger.Info("⚠️  Failed to create analyzer for subscribed PR %s: %v", key, err)
+		return
+	}
+	defer a.Close()
+
+	// JIRA analysis is skipped, it does not change the PR's own branches
+	result, err := a.AnalyzePRWithOptions(sub.Number, true)
+	if err != nil {
+		logger.Info("⚠️  Failed to analyze subscribed PR %s: %v", key, err)
+		return
+	}
+
+	branches := make(map[string]bool)
+	var versions []string
+	for _, branch := range result.AllFoundBranches(false) {
+		branches[branch.BranchName] = true
+		versions = append(versions, fmt.Sprintf("`%s` (v%s)%s", branch.BranchName, branch.Version, branch.CherryPickNote()))
+	}
+
+	added, channels := s.subscriptions.update(key, branches)
+	if len(added) == 0 {
+		return
+	}
+	logger.Debug("Subscribed PR %s landed in %s", key, strings.Join(added, ", "))
+
+	message := fmt.Sprintf("🎉 <%s|PR #%d> (%s/%s) landed in %d new release branch(es): %s",
+		sub.URL, sub.Number, sub.Owner, sub.Repo, len(added), "`"+strings.Join(added, "`, `")+"`")
+	message += fmt.Sprintf("\nNow in: %s", strings.Join(versions, ", "))
+
+	for channelID, teamID := range channels {
+		botClient := s.botClientFor(teamID)
+		if botClient == nil {
+			continue
+		}
+		if err := botClient.PostSimpleMessage(context.Background(), channelID, message); err != nil {
+			logger.Info("⚠️  Failed to post subscription update for %s to %s: %v", key, channelID, err)
+		}
+	}
+}
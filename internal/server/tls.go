@@ -0,0 +1,38 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/models"
+)
+
+// ValidateTLSConfig checks that the TLS certificate and key are either both set or both unset.
+func ValidateTLSConfig(cfg *models.Config) error {
+	if (cfg.TLSCertFile == "") != (cfg.TLSKeyFile == "") {
+		return fmt.Errorf("TLS needs both a certificate and a key (PR_BOT_TLS_CERT_FILE/-tls-cert and PR_BOT_TLS_KEY_FILE/-tls-key), got only one")
+	}
+	return nil
+}
+
+// listenAndServe serves srv over HTTPS when a TLS certificate and key are configured,
+// and over plain HTTP otherwise. It returns nil once the server has been shut down.
+func listenAndServe(srv *http.Server, cfg *models.Config) error {
+	if err := ValidateTLSConfig(cfg); err != nil {
+		return err
+	}
+
+	var err error
+	if cfg.TLSCertFile != "" {
+		logger.Info("🔒 Serving HTTPS with certificate %s", cfg.TLSCertFile)
+		err = srv.ListenAndServeTLS(cfg.TLSCertFile, cfg.TLSKeyFile)
+	} else {
+		err = srv.ListenAndServe()
+	}
+	if err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,231 @@
+package server
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/logger"
+)
+
+// maxWebhookRequestBytes caps the GitHub webhook payload read for signature verification.
+const maxWebhookRequestBytes = 10 << 20
+
+// pullRequestEvent is the part of a GitHub pull_request webhook payload the bot uses.
+type pullRequestEvent struct {
+	Action      string `json:"action"`
+	PullRequest struct {
+		Number  int    `json:"number"`
+		HTMLURL string `json:"html_url"`
+		Merged  bool   `json:"merged"`
+	} `json:"pull_request"`
+}
+
+// pushEvent is the part of a GitHub push webhook payload the bot uses.
+type pushEvent struct {
+	Ref     string `json:"ref"`
+	Commits []struct {
+		ID string `json:"id"`
+	} `json:"commits"`
+	Repository struct {
+		FullName string `json:"full_name"` // owner/repo
+	} `json:"repository"`
+}
+
+// maxPushCommits bounds the commits of a push event looked up for merged PRs.
+const maxPushCommits = 20
+
+// webhookDedupWindow is how long a PR analyzed for a webhook event is not analyzed
+// again. A merge is delivered both as a pull_request and as a push event.
+const webhookDedupWindow = 10 * time.Minute
+
+// webhookDedup remembers the PRs recently analyzed for webhook events.
+type webhookDedup struct {
+	mu   sync.Mutex
+	seen map[string]time.Time // PR URL -> when it was analyzed
+}
+
+// firstSeen reports whether prURL was not analyzed within webhookDedupWindow, and
+// records it as analyzed now.
+func (d *webhookDedup) firstSeen(prURL string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.seen == nil {
+		d.seen = make(map[string]time.Time)
+	}
+	for url, at := range d.seen {
+		if time.Since(at) > webhookDedupWindow {
+			delete(d.seen, url)
+		}
+	}
+	if _, ok := d.seen[prURL]; ok {
+		return false
+	}
+	d.seen[prURL] = time.Now()
+	return true
+}
+
+// handleGitHubWebhook receives GitHub webhook deliveries and analyzes pull requests
+// as they are merged, posting the result to PR_BOT_WEBHOOK_CHANNEL. Merges are
+// recognized from pull_request events, and from push events by looking up the PRs
+// whose merge commit was pushed.
+func (s *SlackServer) handleGitHubWebhook(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	cfg := s.currentConfig()
+	if cfg.GitHubWebhookSecret == "" {
+		http.Error(w, "GitHub webhook is not configured", http.StatusServiceUnavailable)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookRequestBytes))
+	if err != nil {
+		http.Error(w, "Failed to read body", http.StatusBadRequest)
+		return
+	}
+
+	if !validGitHubSignature(cfg.GitHubWebhookSecret, body, r.Header.Get("X-Hub-Signature-256")) {
+		http.Error(w, "Invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	var analyze func(channel string)
+	switch event := r.Header.Get("X-GitHub-Event"); event {
+	case "pull_request":
+		var payload pullRequestEvent
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "Failed to parse pull_request event", http.StatusBadRequest)
+			return
+		}
+		if payload.Action != "closed" || !payload.PullRequest.Merged {
+			logger.Debug("Ignoring pull_request %q event for PR #%d", payload.Action, payload.PullRequest.Number)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		prURL := payload.PullRequest.HTMLURL
+		analyze = func(channel string) {
+			logger.Info("PR %s merged, analyzing for %s", prURL, channel)
+			s.analyzeMergedPR(prURL, channel)
+		}
+
+	case "push":
+		var payload pushEvent
+		if err := json.Unmarshal(body, &payload); err != nil {
+			http.Error(w, "Failed to parse push event", http.StatusBadRequest)
+			return
+		}
+		owner, repo, ok := strings.Cut(payload.Repository.FullName, "/")
+		if !ok || !strings.HasPrefix(payload.Ref, "refs/heads/") || len(payload.Commits) == 0 {
+			logger.Debug("Ignoring push event for %s of %s", payload.Ref, payload.Repository.FullName)
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		var shas []string
+		for _, commit := range payload.Commits {
+			shas = append(shas, commit.ID)
+		}
+		if len(shas) > maxPushCommits {
+			logger.Debug("Push to %s of %s has %d commits, looking up the last %d", payload.Ref, payload.Repository.FullName, len(shas), maxPushCommits)
+			shas = shas[len(shas)-maxPushCommits:]
+		}
+		analyze = func(channel string) { s.analyzePushedPRs(owner, repo, shas, channel) }
+
+	default:
+		// Other events, such as the ping sent when the webhook is created, are acknowledged and ignored
+		logger.Debug("Ignoring GitHub %q webhook event", event)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	if s.shuttingDown.Load() {
+		// GitHub can redeliver the event once the server is back
+		http.Error(w, shuttingDownMessage, http.StatusServiceUnavailable)
+		return
+	}
+
+	if cfg.WebhookChannel == "" || s.currentBotClient() == nil {
+		logger.Info("GitHub webhook event received, but PR_BOT_WEBHOOK_CHANNEL or the Slack bot token is not configured")
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	channel := cfg.WebhookChannel
+	s.goTracked(func() { analyze(channel) })
+	w.WriteHeader(http.StatusAccepted)
+}
+
+// validGitHubSignature reports whether signature ("sha256=<hex>") is the HMAC-SHA256 of body with secret.
+func validGitHubSignature(secret string, body []byte, signature string) bool {
+	hexDigest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	got, err := hex.DecodeString(hexDigest)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), got)
+}
+
+// analyzePushedPRs analyzes the merged PRs of owner/repo whose merge commit is one
+// of shas, posting the results to channel.
+func (s *SlackServer) analyzePushedPRs(owner, repo string, shas []string, channel string) {
+	a := s.currentAnalyzer()
+	if a == nil {
+		return
+	}
+	githubClient := a.GetGitHubClient()
+
+	for _, sha := range shas {
+		prs, err := githubClient.ListPRsForCommit(owner, repo, sha)
+		if err != nil {
+			logger.Info("Failed to look up PRs for pushed commit %s in %s/%s: %v", sha, owner, repo, err)
+			continue
+		}
+		for _, pr := range prs {
+			// Commits pushed to a PR branch also list the PR; only its merge counts
+			if pr.MergedAt == nil || pr.GetMergeCommitSHA() != sha {
+				continue
+			}
+			logger.Info("PR %s merged by push of %s, analyzing for %s", pr.GetHTMLURL(), sha, channel)
+			s.analyzeMergedPR(pr.GetHTMLURL(), channel)
+		}
+	}
+}
+
+// analyzeMergedPR analyzes a merged PR and posts the result to channel, unless it
+// was already analyzed for another webhook event within webhookDedupWindow.
+func (s *SlackServer) analyzeMergedPR(prURL, channel string) {
+	if !s.webhookPRs.firstSeen(prURL) {
+		logger.Debug("PR %s was already analyzed for a recent webhook event", prURL)
+		return
+	}
+
+	message, err := s.analyzePR(prURL, "")
+	if err != nil {
+		message = fmt.Sprintf("❌ Error analyzing merged PR %s: %v", prURL, err)
+	}
+
+	botClient := s.currentBotClient()
+	if botClient == nil {
+		return
+	}
+	if err := botClient.PostSimpleMessage(context.Background(), channel, message); err != nil {
+		logger.Info("Failed to post analysis of %s to %s: %v", prURL, channel, err)
+	}
+}
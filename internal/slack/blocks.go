@@ -0,0 +1,57 @@
+package slack
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/models"
+)
+
+// GAStatusToSlackBlocks describes a release branch's GA status as Block Kit sections,
+// one per line: released and upcoming versions from upcomingGAs, followed by the
+// latest released ACM/MCE versions from status.
+func GAStatusToSlackBlocks(status models.GAStatus, upcomingGAs []models.UpcomingGA) []Block {
+	now := time.Now()
+	var blocks []Block
+
+	// Released versions first, one per product
+	productReleased := make(map[string]bool)
+	for _, upcomingGA := range upcomingGAs {
+		if upcomingGA.GADate != nil && upcomingGA.GADate.Before(now) && !productReleased[upcomingGA.Product] {
+			productReleased[upcomingGA.Product] = true
+			blocks = append(blocks, mrkdwnSection(fmt.Sprintf("🚀 %s %s: Released (GA: %s)",
+				upcomingGA.Product, upcomingGA.Version, models.FormatDate(upcomingGA.GADate))))
+		}
+	}
+
+	// Then the next upcoming release for products without a released version
+	productUpcoming := make(map[string]bool)
+	for _, upcomingGA := range upcomingGAs {
+		if !productReleased[upcomingGA.Product] && !productUpcoming[upcomingGA.Product] {
+			productUpcoming[upcomingGA.Product] = true
+			blocks = append(blocks, mrkdwnSection(fmt.Sprintf("⏳ %s %s: Upcoming (GA: %s)",
+				upcomingGA.Product, upcomingGA.Version, models.FormatDate(upcomingGA.GADate))))
+		}
+	}
+
+	// Latest GA status (already released versions)
+	for _, ga := range []struct {
+		product string
+		info    models.GAInfo
+	}{{"ACM", status.ACM}, {"MCE", status.MCE}} {
+		if ga.info.Version != "" && ga.info.Status == "GA" && ga.info.GADate != nil && ga.info.GADate.Before(now) {
+			blocks = append(blocks, mrkdwnSection(fmt.Sprintf("✅ %s %s: Released (GA: %s)",
+				ga.product, ga.info.Version, models.FormatDate(ga.info.GADate))))
+		}
+	}
+
+	return blocks
+}
+
+// mrkdwnSection returns a section block with mrkdwn text.
+func mrkdwnSection(text string) Block {
+	return Block{
+		Type: "section",
+		Text: &TextObject{Type: "mrkdwn", Text: text},
+	}
+}
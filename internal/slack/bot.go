@@ -7,10 +7,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 
 	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/models"
+	"github.com/shay23bra/pr-bot/internal/proxy"
 )
 
 // BotClient represents a Slack Bot API client using OAuth tokens.
@@ -42,6 +45,7 @@ type Event struct {
 	ChannelType    string `json:"channel_type"`
 	BotID          string `json:"bot_id,omitempty"`
 	AppID          string `json:"app_id,omitempty"`
+	Tab            string `json:"tab,omitempty"` // app_home_opened: "home" or "messages"
 }
 
 // SlackResponse represents a generic Slack API response.
@@ -61,8 +65,19 @@ type PostMessageRequest struct {
 
 // Block represents a Slack Block Kit block.
 type Block struct {
-	Type string      `json:"type"`
-	Text *TextObject `json:"text,omitempty"`
+	Type      string        `json:"type"`
+	Text      *TextObject   `json:"text,omitempty"`
+	Accessory *Button       `json:"accessory,omitempty"` // section blocks only
+	Elements  []*TextObject `json:"elements,omitempty"`  // context blocks only
+}
+
+// Button is a Block Kit button element. Clicks are sent to the interactivity
+// request URL as block_actions with the button's action ID and value.
+type Button struct {
+	Type     string      `json:"type"` // always "button"
+	Text     *TextObject `json:"text"`
+	ActionID string      `json:"action_id"`
+	Value    string      `json:"value,omitempty"`
 }
 
 // TextObject represents a text object in Slack Block Kit.
@@ -85,11 +100,33 @@ type AttachmentField struct {
 	Short bool   `json:"short"`
 }
 
+// ClientOptions configures the HTTP transport used by the Slack Bot API client.
+type ClientOptions struct {
+	Proxy proxy.Settings
+}
+
+// OptionsFromConfig builds ClientOptions from the application configuration.
+func OptionsFromConfig(cfg *models.Config) ClientOptions {
+	return ClientOptions{
+		Proxy: proxy.FromConfig(cfg),
+	}
+}
+
 // NewBotClient creates a new Slack Bot API client.
-func NewBotClient(botToken string) *BotClient {
+func NewBotClient(botToken string, opts ...ClientOptions) *BotClient {
+	var options ClientOptions
+	if len(opts) > 0 {
+		options = opts[0]
+	}
+
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	if transport := options.Proxy.NewTransport(); transport != nil {
+		httpClient.Transport = transport
+	}
+
 	return &BotClient{
 		botToken:   botToken,
-		httpClient: &http.Client{Timeout: 30 * time.Second},
+		httpClient: httpClient,
 	}
 }
 
@@ -134,12 +171,17 @@ func (c *BotClient) TestAuth(ctx context.Context) error {
 
 // PostMessage posts a message to a Slack channel.
 func (c *BotClient) PostMessage(ctx context.Context, req *PostMessageRequest) error {
-	jsonData, err := json.Marshal(req)
+	return c.callAPI(ctx, "chat.postMessage", req)
+}
+
+// callAPI posts body as JSON to the Web API method and checks the "ok" field of the response.
+func (c *BotClient) callAPI(ctx context.Context, method string, body interface{}) error {
+	jsonData, err := json.Marshal(body)
 	if err != nil {
 		return fmt.Errorf("failed to marshal request: %w", err)
 	}
 
-	httpReq, err := http.NewRequestWithContext(ctx, "POST", "https://slack.com/api/chat.postMessage", bytes.NewBuffer(jsonData))
+	httpReq, err := http.NewRequestWithContext(ctx, "POST", "https://slack.com/api/"+method, bytes.NewBuffer(jsonData))
 	if err != nil {
 		return fmt.Errorf("failed to create request: %w", err)
 	}
@@ -165,6 +207,43 @@ func (c *BotClient) PostMessage(ctx context.Context, req *PostMessageRequest) er
 	return nil
 }
 
+// CheckUserInGroup reports whether userID is a member of the Slack user group groupID.
+// The bot token needs the usergroups:read scope.
+func (c *BotClient) CheckUserInGroup(ctx context.Context, userID, groupID string) (bool, error) {
+	reqURL := "https://slack.com/api/usergroups.users.list?usergroup=" + url.QueryEscape(groupID)
+	req, err := http.NewRequestWithContext(ctx, "GET", reqURL, nil)
+	if err != nil {
+		return false, fmt.Errorf("failed to create usergroups request: %w", err)
+	}
+
+	req.Header.Set("Authorization", "Bearer "+c.botToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("failed to make usergroups request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		SlackResponse
+		Users []string `json:"users"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return false, fmt.Errorf("failed to decode usergroups response: %w", err)
+	}
+
+	if !result.OK {
+		return false, fmt.Errorf("slack API error: %s", result.Error)
+	}
+
+	for _, member := range result.Users {
+		if member == userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 // PostSimpleMessage posts a simple text message to a channel.
 func (c *BotClient) PostSimpleMessage(ctx context.Context, channel, text string) error {
 	return c.PostMessage(ctx, &PostMessageRequest{
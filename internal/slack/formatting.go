@@ -0,0 +1,45 @@
+package slack
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+// Slack Block Kit limits.
+const (
+	MaxBlockTextLength  = 4000 // Characters allowed in a section block's text
+	MaxBlocksPerMessage = 50   // Blocks allowed in a single message
+)
+
+// TruncateSlackMessage shortens text to at most maxLen characters, cutting at the
+// last line break when there is one so partial lines are not shown.
+func TruncateSlackMessage(text string, maxLen int) (truncated string, wasTruncated bool) {
+	if utf8.RuneCountInString(text) <= maxLen {
+		return text, false
+	}
+	if maxLen <= 0 {
+		return "", true
+	}
+
+	cut := string([]rune(text)[:maxLen])
+	if i := strings.LastIndex(cut, "\n"); i > 0 {
+		cut = cut[:i]
+	}
+	return cut, true
+}
+
+// SplitIntoBlocks splits text into mrkdwn section blocks that each stay under
+// MaxBlockTextLength, breaking between lines where possible. Text beyond
+// MaxBlocksPerMessage blocks is dropped.
+func SplitIntoBlocks(text string) []Block {
+	var blocks []Block
+	for text != "" && len(blocks) < MaxBlocksPerMessage {
+		chunk, _ := TruncateSlackMessage(text, MaxBlockTextLength)
+		text = strings.TrimPrefix(text[len(chunk):], "\n")
+		if strings.TrimSpace(chunk) == "" {
+			continue
+		}
+		blocks = append(blocks, mrkdwnSection(chunk))
+	}
+	return blocks
+}
@@ -0,0 +1,119 @@
+package slack
+
+import "context"
+
+// View is a Slack modal opened with views.open.
+type View struct {
+	Type            string       `json:"type"` // always "modal"
+	CallbackID      string       `json:"callback_id,omitempty"`
+	PrivateMetadata string       `json:"private_metadata,omitempty"`
+	Title           *TextObject  `json:"title"`
+	Submit          *TextObject  `json:"submit,omitempty"`
+	Close           *TextObject  `json:"close,omitempty"`
+	Blocks          []InputBlock `json:"blocks"`
+}
+
+// InputBlock is a Block Kit input block, the form fields of a modal.
+type InputBlock struct {
+	Type     string       `json:"type"` // always "input"
+	BlockID  string       `json:"block_id"`
+	Label    *TextObject  `json:"label"`
+	Element  InputElement `json:"element"`
+	Hint     *TextObject  `json:"hint,omitempty"`
+	Optional bool         `json:"optional,omitempty"`
+}
+
+// InputElement is the interactive element of an input block, such as a
+// plain_text_input, a multi_static_select or a conversations_select.
+type InputElement struct {
+	Type                         string      `json:"type"`
+	ActionID                     string      `json:"action_id"`
+	Placeholder                  *TextObject `json:"placeholder,omitempty"`
+	Options                      []Option    `json:"options,omitempty"`
+	InitialOptions               []Option    `json:"initial_options,omitempty"`
+	DefaultToCurrentConversation bool        `json:"default_to_current_conversation,omitempty"`
+	ResponseURLEnabled           bool        `json:"response_url_enabled,omitempty"`
+}
+
+// Option is a choice of a select element.
+type Option struct {
+	Text  *TextObject `json:"text"`
+	Value string      `json:"value"`
+}
+
+// PlainText returns a plain_text object, as required for modal titles, labels and options.
+func PlainText(text string) *TextObject {
+	return &TextObject{Type: "plain_text", Text: text}
+}
+
+// InteractionPayload is the JSON "payload" form field Slack posts to the
+// interactivity request URL, e.g. when a modal is submitted or a button is clicked.
+type InteractionPayload struct {
+	Type string `json:"type"` // e.g. "view_submission" or "block_actions"
+	Team struct {
+		ID string `json:"id"`
+	} `json:"team"`
+	User struct {
+		ID string `json:"id"`
+	} `json:"user"`
+	View struct {
+		CallbackID      string `json:"callback_id"`
+		PrivateMetadata string `json:"private_metadata"`
+		State           struct {
+			// Values maps block IDs to action IDs to the submitted values
+			Values map[string]map[string]ViewStateValue `json:"values"`
+		} `json:"state"`
+	} `json:"view"`
+	// ResponseURLs holds one URL per conversations_select with response_url_enabled
+	ResponseURLs []struct {
+		BlockID     string `json:"block_id"`
+		ActionID    string `json:"action_id"`
+		ChannelID   string `json:"channel_id"`
+		ResponseURL string `json:"response_url"`
+	} `json:"response_urls"`
+	// Actions holds the clicked button of a block_actions payload
+	Actions []BlockAction `json:"actions"`
+}
+
+// BlockAction is an interactive element the user acted on, such as a button.
+type BlockAction struct {
+	ActionID string `json:"action_id"`
+	BlockID  string `json:"block_id"`
+	Value    string `json:"value"`
+}
+
+// ViewStateValue is the submitted value of one modal input element.
+type ViewStateValue struct {
+	Type                 string   `json:"type"`
+	Value                string   `json:"value"`
+	SelectedOptions      []Option `json:"selected_options"`
+	SelectedConversation string   `json:"selected_conversation"`
+}
+
+// StateValue returns the submitted value of the element actionID in block blockID.
+func (p *InteractionPayload) StateValue(blockID, actionID string) ViewStateValue {
+	return p.View.State.Values[blockID][actionID]
+}
+
+// OpenView opens a modal for the user who triggered triggerID. Trigger IDs come
+// with slash commands and expire after three seconds.
+func (c *BotClient) OpenView(ctx context.Context, triggerID string, view *View) error {
+	return c.callAPI(ctx, "views.open", map[string]interface{}{
+		"trigger_id": triggerID,
+		"view":       view,
+	})
+}
+
+// HomeView is the content of the app's Home tab, published with views.publish.
+type HomeView struct {
+	Type   string  `json:"type"` // always "home"
+	Blocks []Block `json:"blocks"`
+}
+
+// PublishView replaces the Home tab of userID with view.
+func (c *BotClient) PublishView(ctx context.Context, userID string, view *HomeView) error {
+	return c.callAPI(ctx, "views.publish", map[string]interface{}{
+		"user_id": userID,
+		"view":    view,
+	})
+}
@@ -0,0 +1,70 @@
+// Package tracing exports OpenTelemetry traces of analyses to an OTLP collector.
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+)
+
+// ServiceName is reported as service.name on every exported span.
+const ServiceName = "pr-bot"
+
+// otlpTracesPath is where OTLP/HTTP collectors accept traces.
+const otlpTracesPath = "/v1/traces"
+
+// Setup installs a global tracer provider that batches spans to the OTLP/HTTP
+// collector at endpoint (e.g. http://localhost:4318). The returned function flushes
+// pending spans and must be called before the process exits.
+func Setup(endpoint, serviceVersion string) (func(context.Context) error, error) {
+	exporter, err := newOTLPExporter(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(
+			attribute.String("service.name", ServiceName),
+			attribute.String("service.version", serviceVersion),
+		)),
+	)
+	otel.SetTracerProvider(tp)
+
+	return func(ctx context.Context) error {
+		if err := tp.Shutdown(ctx); err != nil {
+			return fmt.Errorf("failed to flush traces: %w", err)
+		}
+		return nil
+	}, nil
+}
+
+// newOTLPExporter creates an exporter for the collector at endpoint. The traces
+// path is appended unless endpoint already ends with it.
+func newOTLPExporter(endpoint string) (*otlptrace.Exporter, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid OTLP endpoint %q: must be an absolute URL such as http://localhost:4318", endpoint)
+	}
+	if !strings.HasSuffix(u.Path, otlpTracesPath) {
+		u.Path = strings.TrimSuffix(u.Path, "/") + otlpTracesPath
+	}
+
+	exporter, err := otlptracehttp.New(context.Background(),
+		otlptracehttp.WithEndpointURL(u.String()),
+		otlptracehttp.WithTimeout(10*time.Second),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP exporter: %w", err)
+	}
+	return exporter, nil
+}
@@ -3,25 +3,33 @@ package main
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"log"
 	"os"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
 	"sync"
 	"time"
 
+	"gopkg.in/yaml.v2"
+
 	"github.com/shay23bra/pr-bot/internal/config"
 	"github.com/shay23bra/pr-bot/internal/ga"
 	"github.com/shay23bra/pr-bot/internal/github"
-	"github.com/shay23bra/pr-bot/internal/gitlocal"
 	"github.com/shay23bra/pr-bot/internal/gitlab"
+	"github.com/shay23bra/pr-bot/internal/gitlocal"
 	"github.com/shay23bra/pr-bot/internal/jira"
 	"github.com/shay23bra/pr-bot/internal/logger"
 	"github.com/shay23bra/pr-bot/internal/models"
+	"github.com/shay23bra/pr-bot/internal/resultcache"
 	"github.com/shay23bra/pr-bot/internal/server"
+	"github.com/shay23bra/pr-bot/internal/tracing"
 	"github.com/shay23bra/pr-bot/internal/version"
 	"github.com/shay23bra/pr-bot/pkg/analyzer"
 )
@@ -81,8 +89,41 @@ func main() {
 	jiraTicketFlag := flag.String("jt", "", "Analyze all PRs related to a JIRA ticket")
 	serverFlag := flag.Bool("server", false, "Run as Slack bot server")
 	portFlag := flag.Int("port", 8080, "Port for Slack bot server (default: 8080)")
+	apiPortFlag := flag.Int("api-port", 0, "Run as REST API server on the given port")
+	tlsCertFlag := flag.String("tls-cert", "", "TLS certificate file for -server and -api-port (overrides PR_BOT_TLS_CERT_FILE)")
+	tlsKeyFlag := flag.String("tls-key", "", "TLS private key file for -server and -api-port (overrides PR_BOT_TLS_KEY_FILE)")
 	versionOnlyFlag := flag.Bool("version", false, "Show version and exit")
 	dataSourceFlag := flag.Bool("data-source", false, "Show data source information and exit")
+	compareMCEFlag := flag.String("compare-mce", "", "Compare component SHAs between two MCE versions")
+	branchDiffFlag := flag.String("branch-diff", "", "List the commits of a branch that are not in another (base branch, then head branch)")
+	commitFlag := flag.String("commit", "", "List the PRs that contain a commit SHA")
+	matrixFlag := flag.String("matrix", "", "Print component SHAs of every snapshot in an MCE branch (e.g. mce-2.8)")
+	outputFlag := flag.String("output", outputText, "Output format for -pr, -pr-diff and -jt: text, json or yaml")
+	detectPatternsFlag := flag.String("detect-patterns", "", "Suggest release branch patterns for a repository (owner/repo)")
+	prDiffFlag := flag.String("pr-diff", "", "Analyze a PR again and show what changed since its cached result")
+	prsFileFlag := flag.String("prs-file", "", "Analyze every PR listed in a file (one PR URL or number per line)")
+	postJiraCommentFlag := flag.Bool("post-jira-comment", false, "Post the -jt analysis summary as a comment on the JIRA ticket")
+	jiraComponentFlag := flag.String("jira-component", "", "With -jt, only analyze PRs of tickets with this JIRA component")
+	jiraAssigneeFlag := flag.String("jira-assignee", "", "With -jt, only analyze PRs of tickets assigned to this user (name, email or username)")
+	postGitHubCommentFlag := flag.Bool("post-github-comment", false, "Post the -pr analysis as a comment on the PR, replacing any earlier one")
+	dryRunFlag := flag.Bool("dry-run", false, "Print the comments -post-jira-comment and -post-github-comment would post instead of posting them")
+	verboseFlag := flag.Bool("verbose", false, "Show per-branch details for every PR analyzed with -prs-file")
+	noCacheFlag := flag.Bool("no-cache", false, "Analyze -pr again instead of using a cached result (the cache is still updated)")
+	showFilesFlag := flag.Bool("show-files", false, "With -pr, list every file the PR changes")
+	labelFilterFlag := flag.String("label-filter", "", "With -pr, only report release branches named by a PR label matching this regular expression (e.g. backport-4.*)")
+	watchFlag := flag.Bool("watch", false, "After analyzing -pr, keep analyzing it and report release branches it newly lands in")
+	pollIntervalFlag := flag.Int("poll-interval", defaultWatchPollSeconds, "Seconds between analyses with -watch")
+	watchTimeoutFlag := flag.Duration("watch-timeout", defaultWatchTimeout, "How long -watch keeps watching")
+	timelineFlag := flag.Bool("timeline", false, "List upcoming ACM and MCE GA dates from the release schedule")
+	productFlag := flag.String("product", "", "With -timeline, only list ACM or MCE releases")
+	exportReleasesFlag := flag.Bool("export-releases", false, "Print every release of the release schedule as CSV (default) or JSON (-output json)")
+	sinceFlag := flag.String("since", "", "With -v or -branch-diff, only list commits committed on or after this date (YYYY-MM-DD)")
+	untilFlag := flag.String("until", "", "With -v or -branch-diff, only list commits committed on or before this date (YYYY-MM-DD)")
+	versionMapFlag := flag.Bool("version-map", false, "Print the ACM-to-MCE version mapping and exit")
+	configInitFlag := flag.Bool("config-init", false, "Prompt for the GitHub, GitLab, JIRA, Slack and Google Sheets settings and write them to .env")
+	profileFlag := flag.String("profile", "", "Use the owner, repository and branch prefix of a profile in ~/.pr-bot/profiles.yaml")
+	ownerFlag := flag.String("owner", "", "Repository owner for this run, overriding PR_BOT_GITHUB_OWNER and -profile")
+	repoFlag := flag.String("repo", "", "Repository name for this run, overriding PR_BOT_GITHUB_REPOSITORY and -profile")
 
 	slackSearchCmd := flag.NewFlagSet("slack-search", flag.ExitOnError)
 	slackSearchOwner := slackSearchCmd.String("owner", "stolostron", "Repository owner")
@@ -94,31 +135,139 @@ func main() {
 
 	slackTestCmd := flag.NewFlagSet("slack-test", flag.ExitOnError)
 
+	completionCmd := flag.NewFlagSet("completion", flag.ExitOnError)
+
+	profileCmd := flag.NewFlagSet("profile", flag.ExitOnError)
+
+	subcommands := []subcommand{
+		{flags: slackSearchCmd, description: "Search Slack for messages about a PR"},
+		{flags: versionSearchCmd, description: "Find the latest version message in a Slack channel"},
+		{flags: slackTestCmd, description: "Test Slack authentication"},
+		{flags: completionCmd, description: "Print a shell completion script", args: completionShells},
+		{flags: profileCmd, description: "Manage repository profiles", args: []string{"add"}},
+	}
+
 	// Set custom usage function
 	flag.Usage = func() {
 		fmt.Fprintf(os.Stderr, "Usage: ./pr-bot [options]\n")
 		fmt.Fprintf(os.Stderr, "\nOptions:\n")
 		fmt.Fprintf(os.Stderr, "  -pr <PR_URL>      Analyze a PR across all release branches\n")
 		fmt.Fprintf(os.Stderr, "  -jt <JIRA_URL>    Analyze all PRs related to a JIRA ticket\n")
+		fmt.Fprintf(os.Stderr, "  -post-jira-comment  With -jt, post the analysis summary as a comment on the ticket\n")
+		fmt.Fprintf(os.Stderr, "  -jira-component <NAME>  With -jt, only analyze PRs of tickets with this component\n")
+		fmt.Fprintf(os.Stderr, "  -jira-assignee <USER>   With -jt, only analyze PRs of tickets assigned to this user\n")
+		fmt.Fprintf(os.Stderr, "  -post-github-comment  With -pr, post the analysis as a comment on the PR (replaces the previous one)\n")
+		fmt.Fprintf(os.Stderr, "  -dry-run          With -post-jira-comment or -post-github-comment, print the comment instead of posting it\n")
+		fmt.Fprintf(os.Stderr, "  -pr-diff <PR_URL> Analyze a PR again and show branches and GAs that changed since its cached result\n")
+		fmt.Fprintf(os.Stderr, "  -prs-file <FILE>  Analyze every PR listed in a file (PR URLs or numbers, # comments)\n")
+		fmt.Fprintf(os.Stderr, "  -verbose          With -prs-file, show each PR's per-branch details\n")
+		fmt.Fprintf(os.Stderr, "  -no-cache         With -pr, ignore results cached in the last PR_BOT_RESULT_CACHE_TTL (default 1h)\n")
+		fmt.Fprintf(os.Stderr, "  -show-files       With -pr, list the changed files even when there are %d or more\n", maxListedChangedFiles)
+		fmt.Fprintf(os.Stderr, "  -label-filter <REGEX>  With -pr, only report release branches named by a matching PR label, e.g. backport-4.15 for release-4.15\n")
+		fmt.Fprintf(os.Stderr, "  -watch            With -pr, keep re-analyzing and print release branches the PR newly lands in\n")
+		fmt.Fprintf(os.Stderr, "  -poll-interval <SECONDS>  With -watch, time between analyses (default: 60)\n")
+		fmt.Fprintf(os.Stderr, "  -watch-timeout <DURATION>  With -watch, stop after this long (default: 24h)\n")
+		fmt.Fprintf(os.Stderr, "  -profile <NAME>   Use the owner/repo of a profile in ~/.pr-bot/profiles.yaml (environment variables still win)\n")
+		fmt.Fprintf(os.Stderr, "  -owner <OWNER> -repo <REPO>  Analyze this repository for this run, overriding the configured one and -profile\n")
 		fmt.Fprintf(os.Stderr, "  -v <component> <version>  Compare GitHub tag with previous version for specific component\n")
 		fmt.Fprintf(os.Stderr, "  -v mce <component> <version>  Compare MCE version with previous version for specific component\n")
+		fmt.Fprintf(os.Stderr, "  -since <YYYY-MM-DD> -until <YYYY-MM-DD>  With -v or -branch-diff, only list commits committed in this date range (put them first)\n")
+		fmt.Fprintf(os.Stderr, "  -compare-mce <v1> <v2>  Compare component SHAs between two MCE versions\n")
+		fmt.Fprintf(os.Stderr, "  -branch-diff <base> <head>  List the commits of head that are not in base (in PR_BOT_GITHUB_OWNER/PR_BOT_GITHUB_REPOSITORY)\n")
+		fmt.Fprintf(os.Stderr, "  -commit <SHA>     List the PRs that contain a commit (in PR_BOT_GITHUB_OWNER/PR_BOT_GITHUB_REPOSITORY)\n")
+		fmt.Fprintf(os.Stderr, "  -matrix <branch>  Print component SHAs of every snapshot in an MCE branch as a table\n")
+		fmt.Fprintf(os.Stderr, "  -detect-patterns <owner/repo>  Suggest release branch patterns from a repository's branches\n")
+		fmt.Fprintf(os.Stderr, "  -timeline         List upcoming GA dates (Product, Version, GA Date, Branch) sorted by date\n")
+		fmt.Fprintf(os.Stderr, "  -product <ACM|MCE>  With -timeline, only list one product\n")
+		fmt.Fprintf(os.Stderr, "  -export-releases  Print every release of the release schedule (ACMVersion, MCEVersion, GADate, IsGA) as CSV or JSON\n")
+		fmt.Fprintf(os.Stderr, "  -output <FORMAT>  Output format for -pr, -pr-diff and -jt: text (default), json or yaml; for -export-releases: csv (default) or json\n")
 		fmt.Fprintf(os.Stderr, "  -server           Run as Slack bot server\n")
 		fmt.Fprintf(os.Stderr, "  -port <PORT>      Port for Slack bot server (default: 8080)\n")
+		fmt.Fprintf(os.Stderr, "  -api-port <PORT>  Run as REST API server (requires PR_BOT_API_TOKEN)\n")
+		fmt.Fprintf(os.Stderr, "  -tls-cert <FILE> -tls-key <FILE>  Serve -server or -api-port over HTTPS (both required)\n")
+		fmt.Fprintf(os.Stderr, "  -config-init      Prompt for each token, check it and write them to .env in the current directory\n")
 		fmt.Fprintf(os.Stderr, "  -version          Show version and exit\n")
+		fmt.Fprintf(os.Stderr, "  -version-map      Print the ACM-to-MCE version mapping (PR_BOT_VERSION_MAPPINGS) and exit\n")
 		fmt.Fprintf(os.Stderr, "  -d                Enable debug logging\n")
+		fmt.Fprintf(os.Stderr, "\nCommands:\n")
+		fmt.Fprintf(os.Stderr, "  completion <bash|zsh|fish>  Print a shell completion script\n")
+		fmt.Fprintf(os.Stderr, "  profile add       Create a repository profile (prompts for name, owner, repo, branch prefix)\n")
 		fmt.Fprintf(os.Stderr, "\nExamples:\n")
 		fmt.Fprintf(os.Stderr, "  pr-bot -pr https://github.com/openshift/assisted-service/pull/7788\n")
 		fmt.Fprintf(os.Stderr, "  pr-bot -jt https://issues.redhat.com/browse/MGMT-20662\n")
 		fmt.Fprintf(os.Stderr, "  pr-bot -jt MGMT-20662\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -jt MGMT-20662 -post-jira-comment\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -jt MGMT-20662 -post-jira-comment -dry-run\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -jt MGMT-20662 -jira-component assisted-service\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -pr https://github.com/openshift/assisted-service/pull/7788 -post-github-comment\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -pr https://github.com/openshift/assisted-service/pull/7790 -watch -poll-interval 300 -watch-timeout 8h\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -pr-diff https://github.com/openshift/assisted-service/pull/7788\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -prs-file release-4.19-prs.txt -verbose\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -output json -pr https://github.com/openshift/assisted-service/pull/7788\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -output yaml -jt MGMT-20662\n")
 		fmt.Fprintf(os.Stderr, "  pr-bot -v assisted-service v2.40.1\n")
 		fmt.Fprintf(os.Stderr, "  pr-bot -v assisted-installer v2.44.0\n")
 		fmt.Fprintf(os.Stderr, "  pr-bot -v mce assisted-service 2.8.0\n")
 		fmt.Fprintf(os.Stderr, "  pr-bot -v mce assisted-installer 2.8.0\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -since 2025-06-01 -until 2025-06-30 -v assisted-service v2.40.1\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -branch-diff release-ocm-2.13 release-ocm-2.14\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -compare-mce 2.8.1 2.8.2\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -commit 3f2a9c1\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -matrix mce-2.8\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -detect-patterns openshift/assisted-installer-ui\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -timeline -product MCE\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -export-releases -output csv > releases.csv\n")
 		fmt.Fprintf(os.Stderr, "  pr-bot -server\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -api-port 8081\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -server -port 443 -tls-cert /etc/letsencrypt/live/example.com/fullchain.pem -tls-key /etc/letsencrypt/live/example.com/privkey.pem\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -config-init\n")
 		fmt.Fprintf(os.Stderr, "  pr-bot -version\n")
+		fmt.Fprintf(os.Stderr, "  source <(pr-bot completion bash)\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot profile add\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -profile agent -commit 3f2a9c1\n")
+		fmt.Fprintf(os.Stderr, "  pr-bot -owner openshift -repo assisted-installer-agent -pr 1234\n")
 	}
 
 	flag.Parse()
+	config.SetProfile(*profileFlag)
+	config.SetRepositoryOverride(*ownerFlag, *repoFlag)
+
+	// Handle shell completion before anything that needs configuration
+	if flag.Arg(0) == completionCmd.Name() {
+		completionCmd.Parse(flag.Args()[1:])
+		if completionCmd.NArg() != 1 {
+			fmt.Fprintf(os.Stderr, "Usage: pr-bot completion <%s>\n", strings.Join(completionShells, "|"))
+			os.Exit(1)
+		}
+		if err := writeCompletion(os.Stdout, completionCmd.Arg(0), flag.CommandLine, subcommands); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// Profiles are managed without loading the configuration
+	if flag.Arg(0) == profileCmd.Name() {
+		profileCmd.Parse(flag.Args()[1:])
+		if profileCmd.Arg(0) != "add" {
+			fmt.Fprintf(os.Stderr, "Usage: pr-bot profile add\n")
+			os.Exit(1)
+		}
+		if err := handleProfileAdd(os.Stdin, os.Stdout); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	// First-time setup writes the .env file the configuration is loaded from
+	if *configInitFlag {
+		if err := handleConfigInit(os.Stdin, os.Stdout, ".env"); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	// Handle version-only flag first
 	if *versionOnlyFlag {
@@ -140,6 +289,13 @@ func main() {
 		if cfg.GoogleServiceAccountJSON != "" && cfg.GoogleSheetID != "" {
 			fmt.Printf("Status: ✅ Google Sheets configured\n")
 			fmt.Printf("Sheet ID: %s\n", cfg.GoogleSheetID)
+			if gaParser, err := ga.NewParser(cfg.GoogleServiceAccountJSON, cfg.GoogleSheetID, ga.OptionsFromConfig(cfg)); err != nil {
+				fmt.Printf("Loaded from: %s (%v)\n", ga.DataSourceUnavailable, err)
+			} else if releases, err := gaParser.GetAllMCEReleases(); err != nil {
+				fmt.Printf("Loaded from: %s (%v)\n", gaParser.DataSource(), err)
+			} else {
+				fmt.Printf("Loaded from: %s (%d MCE releases)\n", gaParser.DataSource(), len(releases))
+			}
 		} else {
 			fmt.Printf("Status: ❌ Google Sheets not configured\n")
 			fmt.Printf("Required: PR_BOT_GOOGLE_SERVICE_ACCOUNT_JSON and PR_BOT_GOOGLE_SHEET_ID\n")
@@ -147,25 +303,60 @@ func main() {
 		return
 	}
 
+	// Handle version mapping flag
+	if *versionMapFlag {
+		handleVersionMap()
+		return
+	}
+
 	// Enable debug logging if requested
 	if *debugFlag {
 		logger.SetDebugMode(true)
 	}
 
+	// Export analysis traces before any analysis starts
+	defer setupTracing()()
+
+	// Handle release timeline mode
+	if *timelineFlag {
+		handleReleaseTimeline(*productFlag)
+		return
+	}
+
+	// Handle release schedule export mode
+	if *exportReleasesFlag {
+		format := *outputFlag
+		if format == outputText {
+			format = outputCSV
+		}
+		if format != outputCSV && format != outputJSON {
+			fmt.Fprintf(os.Stderr, "❌ Error: -export-releases supports -output csv or json, not %q\n", *outputFlag)
+			os.Exit(1)
+		}
+		handleExportReleases(format)
+		return
+	}
+
 	// Check for updates (non-blocking, continues execution)
 	ctx := context.Background()
 	version.CheckForUpdates(ctx)
 
+	// Handle REST API server mode
+	if *apiPortFlag > 0 {
+		startAPIServer(*apiPortFlag, *tlsCertFlag, *tlsKeyFlag)
+		return
+	}
+
 	// Handle server mode
 	if *serverFlag {
-		startSlackServer(*portFlag)
+		startSlackServer(*portFlag, *tlsCertFlag, *tlsKeyFlag)
 		return
 	}
 
 	args := flag.Args()
 
 	// Check if we have any flags/args that require token validation
-	needsValidation := *versionFlag != "" || *prFlag != "" || *jiraTicketFlag != "" || len(args) > 0
+	needsValidation := *versionFlag != "" || *prFlag != "" || *jiraTicketFlag != "" || *compareMCEFlag != "" || *branchDiffFlag != "" || *commitFlag != "" || *matrixFlag != "" || *detectPatternsFlag != "" || *prsFileFlag != "" || *prDiffFlag != "" || len(args) > 0
 	if needsValidation {
 		// Validate required environment variables for CLI mode
 		validateCLIEnvironment()
@@ -184,7 +375,7 @@ func main() {
 				// Format: "mce component version"
 				component := parts[0]
 				version := parts[1]
-				handleMCEVersionComparison(component, version)
+				handleMCEVersionComparison(component, version, *sinceFlag, *untilFlag)
 			} else {
 				// Format: "mce version" - component is required
 				fmt.Fprintf(os.Stderr, "❌ Error: Component is required for MCE version comparison\n")
@@ -199,7 +390,7 @@ func main() {
 				// Format: -v mce component version
 				component := args[0]
 				version := args[1]
-				handleMCEVersionComparison(component, version)
+				handleMCEVersionComparison(component, version, *sinceFlag, *untilFlag)
 			} else {
 				// Format: -v mce version - component is required
 				fmt.Fprintf(os.Stderr, "❌ Error: Component is required for MCE version comparison\n")
@@ -214,7 +405,7 @@ func main() {
 				// Format: -v component version
 				component := *versionFlag
 				version := args[0]
-				handleVersionComparison(component, version)
+				handleVersionComparison(component, version, *sinceFlag, *untilFlag)
 			} else {
 				// This shouldn't happen as we checked len(args) > 0
 				fmt.Fprintf(os.Stderr, "❌ Error: Component is required for version comparison\n")
@@ -230,7 +421,7 @@ func main() {
 				// Format: -v "component version"
 				component := parts[0]
 				version := parts[1]
-				handleVersionComparison(component, version)
+				handleVersionComparison(component, version, *sinceFlag, *untilFlag)
 			} else {
 				// Format: -v "version" - component is required
 				fmt.Fprintf(os.Stderr, "❌ Error: Component is required for version comparison\n")
@@ -243,15 +434,101 @@ func main() {
 		return
 	}
 
+	// Handle MCE snapshot comparison mode: -compare-mce v1 v2
+	if *compareMCEFlag != "" {
+		if len(args) < 1 {
+			fmt.Fprintf(os.Stderr, "❌ Error: Two MCE versions are required\n")
+			fmt.Fprintf(os.Stderr, "Usage: pr-bot -compare-mce <v1> <v2>\n")
+			fmt.Fprintf(os.Stderr, "Example: pr-bot -compare-mce 2.8.1 2.8.2\n")
+			os.Exit(1)
+		}
+		handleMCESnapshotComparison(*compareMCEFlag, args[0])
+		return
+	}
+
+	// Handle branch divergence mode
+	if *branchDiffFlag != "" {
+		if len(args) < 1 {
+			fmt.Fprintf(os.Stderr, "❌ Error: A base and a head branch are required\n")
+			fmt.Fprintf(os.Stderr, "Usage: pr-bot -branch-diff <base-branch> <head-branch>\n")
+			fmt.Fprintf(os.Stderr, "Example: pr-bot -branch-diff release-ocm-2.13 release-ocm-2.14\n")
+			os.Exit(1)
+		}
+		handleBranchDiff(*branchDiffFlag, args[0], *sinceFlag, *untilFlag)
+		return
+	}
+
+	// Handle commit to PR lookup mode
+	if *commitFlag != "" {
+		handleCommitLookup(*commitFlag)
+		return
+	}
+
+	// Handle MCE version matrix mode
+	if *matrixFlag != "" {
+		handleVersionMatrix(*matrixFlag)
+		return
+	}
+
+	// Handle branch pattern detection mode
+	if *detectPatternsFlag != "" {
+		handleDetectPatterns(*detectPatternsFlag)
+		return
+	}
+
+	if *outputFlag != outputText && *outputFlag != outputJSON && *outputFlag != outputYAML {
+		fmt.Fprintf(os.Stderr, "❌ Error: Unknown output format %q (use text, json or yaml)\n", *outputFlag)
+		os.Exit(1)
+	}
+	structuredOutput := *outputFlag != outputText
+
 	// Handle PR analysis mode
 	if *prFlag != "" {
-		handlePRAnalysis(*prFlag)
+		watch := watchOptions{Enabled: *watchFlag, Interval: time.Duration(*pollIntervalFlag) * time.Second, Timeout: *watchTimeoutFlag}
+		if watch.Enabled && (structuredOutput || watch.Interval <= 0 || watch.Timeout <= 0) {
+			fmt.Fprintf(os.Stderr, "❌ Error: -watch needs text output, a positive -poll-interval and a positive -watch-timeout\n")
+			os.Exit(1)
+		}
+		var labelFilter *regexp.Regexp
+		if *labelFilterFlag != "" {
+			if watch.Enabled {
+				fmt.Fprintf(os.Stderr, "❌ Error: -label-filter cannot be combined with -watch\n")
+				os.Exit(1)
+			}
+			var err error
+			if labelFilter, err = regexp.Compile(*labelFilterFlag); err != nil {
+				fmt.Fprintf(os.Stderr, "❌ Error: Invalid -label-filter: %v\n", err)
+				os.Exit(1)
+			}
+		}
+		if structuredOutput {
+			handlePRAnalysisJSON(*prFlag, *outputFlag, *noCacheFlag, labelFilter)
+			return
+		}
+		handlePRAnalysis(*prFlag, *postGitHubCommentFlag, *noCacheFlag, *dryRunFlag, *showFilesFlag, labelFilter, watch)
+		return
+	}
+
+	// Handle PR diff mode
+	if *prDiffFlag != "" {
+		handlePRDiff(*prDiffFlag, *outputFlag)
+		return
+	}
+
+	// Handle PR list file mode
+	if *prsFileFlag != "" {
+		handlePRsFileAnalysis(*prsFileFlag, *verboseFlag)
 		return
 	}
 
 	// Handle JIRA ticket analysis mode
 	if *jiraTicketFlag != "" {
-		handleJiraTicketAnalysis(*jiraTicketFlag)
+		filter := jira.IssueFilter{Component: *jiraComponentFlag, Assignee: *jiraAssigneeFlag}
+		if structuredOutput {
+			handleJiraTicketAnalysisJSON(*jiraTicketFlag, *outputFlag, filter)
+			return
+		}
+		handleJiraTicketAnalysis(*jiraTicketFlag, *postJiraCommentFlag, *dryRunFlag, filter)
 		return
 	}
 
@@ -300,7 +577,110 @@ func main() {
 	os.Exit(1)
 }
 
-// isValidComponent checks if a string is a valid component name
+// newReleaseScheduleParser creates a parser for the configured release schedule sheet.
+func newReleaseScheduleParser() *ga.Parser {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if cfg.GoogleServiceAccountJSON == "" || cfg.GoogleSheetID == "" {
+		log.Fatalf("The release schedule requires PR_BOT_GOOGLE_SERVICE_ACCOUNT_JSON and PR_BOT_GOOGLE_SHEET_ID")
+	}
+
+	gaParser, err := ga.NewParser(cfg.GoogleServiceAccountJSON, cfg.GoogleSheetID, ga.OptionsFromConfig(cfg))
+	if err != nil {
+		log.Fatalf("Failed to create release schedule parser: %v", err)
+	}
+	return gaParser
+}
+
+// handleReleaseTimeline prints the upcoming GA dates of the release schedule, optionally for one product.
+func handleReleaseTimeline(product string) {
+	entries, err := newReleaseScheduleParser().GetReleaseTimeline(product, time.Now())
+	if err != nil {
+		log.Fatalf("Failed to build release timeline: %v", err)
+	}
+
+	if len(entries) == 0 {
+		fmt.Println("No upcoming GA dates in the release schedule")
+		return
+	}
+	fmt.Printf("%-8s %-10s %-11s %s\n", "PRODUCT", "VERSION", "GA DATE", "BRANCH")
+	for _, entry := range entries {
+		branch := entry.Branch
+		if branch == "" {
+			branch = "-"
+		}
+		fmt.Printf("%-8s %-10s %-11s %s\n", entry.Product, entry.Version, models.FormatDate(&entry.GADate), branch)
+	}
+}
+
+// releaseExportDateLayout formats GA dates in -export-releases output, as spreadsheets parse it.
+const releaseExportDateLayout = "2006-01-02"
+
+// exportedRelease is a release schedule row in -export-releases -output json.
+type exportedRelease struct {
+	ACMVersion string `json:"acm_version"`
+	MCEVersion string `json:"mce_version"`
+	GADate     string `json:"ga_date"` // YYYY-MM-DD, empty when not scheduled
+	IsGA       bool   `json:"is_ga"`
+}
+
+// handleExportReleases writes every release of the release schedule, newest MCE
+// version first, to stdout as CSV with a header row or as JSON.
+func handleExportReleases(format string) {
+	// Keeps logs and messages out of stdout for CSV as well
+	stdout := beginJSONOutput()
+
+	releases, err := newReleaseScheduleParser().GetAllMCEReleases()
+	if err != nil {
+		log.Fatalf("Failed to read release schedule: %v", err)
+	}
+
+	exported := make([]exportedRelease, 0, len(releases))
+	for _, release := range releases {
+		row := exportedRelease{ACMVersion: release.ACMVersion, MCEVersion: release.MCEVersion, IsGA: release.IsGA}
+		if release.GADate != nil {
+			row.GADate = release.GADate.Format(releaseExportDateLayout)
+		}
+		exported = append(exported, row)
+	}
+
+	if format == outputJSON {
+		writeJSON(stdout, exported)
+		return
+	}
+
+	w := csv.NewWriter(stdout)
+	w.Write([]string{"ACMVersion", "MCEVersion", "GADate", "IsGA"})
+	for _, row := range exported {
+		w.Write([]string{row.ACMVersion, row.MCEVersion, row.GADate, strconv.FormatBool(row.IsGA)})
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		log.Fatalf("Failed to write CSV output: %v", err)
+	}
+}
+
+// handleVersionMap prints the ACM-to-MCE minor version mapping in use.
+func handleVersionMap() {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	source := "built-in defaults"
+	if len(cfg.VersionMappings) > 0 {
+		source = "configured"
+	}
+	fmt.Printf("ACM to MCE version mapping (%s):\n", source)
+	for _, m := range cfg.VersionMappingTable() {
+		fmt.Printf("  ACM 2.%d -> MCE 2.%d\n", m.ACMMinor, m.MCEMinor)
+	}
+	fmt.Printf("ACM versions not listed use the offset of the closest listed version.\n")
+}
+
+// isValidComponent checks if a string is a valid component name or profile name
 func isValidComponent(component string) bool {
 	validComponents := []string{
 		"assisted-service",
@@ -314,10 +694,10 @@ func isValidComponent(component string) bool {
 			return true
 		}
 	}
-	return false
+	return findProfile(component) != nil
 }
 
-// getRepositoryForComponent maps component names to owner/repository combinations
+// getRepositoryForComponent maps component names, or profile names, to owner/repository combinations
 func getRepositoryForComponent(component string) (owner, repo string) {
 	switch component {
 	case "assisted-service":
@@ -329,13 +709,49 @@ func getRepositoryForComponent(component string) (owner, repo string) {
 	case "assisted-installer-ui":
 		return "openshift-assisted", "assisted-installer-ui"
 	default:
+		if p := findProfile(component); p != nil {
+			return p.Owner, p.Repo
+		}
 		// Default to assisted-service for unknown components
 		return "openshift", "assisted-service"
 	}
 }
 
-// handleVersionComparison compares a version with its previous release
-func handleVersionComparison(component, version string) {
+// setupTracing exports traces to the OTLP collector in PR_BOT_OTEL_ENDPOINT, if set,
+// and returns a function that flushes them
+func setupTracing() func() {
+	endpoint := os.Getenv("PR_BOT_OTEL_ENDPOINT")
+	if endpoint == "" {
+		return func() {}
+	}
+
+	serviceVersion, err := version.GetCurrentVersion()
+	if err != nil {
+		serviceVersion = "unknown"
+	}
+	shutdown, err := tracing.Setup(endpoint, serviceVersion)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+	logger.Debug("Exporting traces to %s", endpoint)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := shutdown(ctx); err != nil {
+			logger.Debug("%v", err)
+		}
+	}
+}
+
+// handleVersionComparison compares a version with its previous release, listing the
+// commits committed between since and until (YYYY-MM-DD, either may be empty)
+func handleVersionComparison(component, version, since, until string) {
+	sinceTime, untilTime, err := models.ParseCommitDateRange(since, until)
+	if err != nil {
+		log.Fatalf("Invalid date filter: %v", err)
+	}
+
 	fmt.Printf("=== Version Comparison ===\n")
 	fmt.Printf("Target version: %s\n", version)
 	fmt.Printf("Component: %s\n", component)
@@ -366,7 +782,10 @@ func handleVersionComparison(component, version string) {
 	fmt.Printf("✅ Tag %s exists\n", version)
 
 	fmt.Printf("Finding nearest previous version...\n")
-	previousVersion, err := localRepo.FindPreviousVersion(version)
+	githubClient := github.NewClient(context.Background(), cfg.GitHubToken, github.OptionsFromConfig(cfg))
+	previousVersion, err := localRepo.FindPreviousVersion(version, func(tag string) bool {
+		return githubClient.IsReleasedTag(owner, repo, tag, cfg.IncludePreRelease)
+	})
 	if err != nil {
 		log.Fatalf("Failed to find previous version: %v", err)
 	}
@@ -377,20 +796,177 @@ func handleVersionComparison(component, version string) {
 	if err != nil {
 		log.Fatalf("Failed to get commits between versions: %v", err)
 	}
+	commits = models.FilterCommitsByDate(commits, sinceTime, untilTime)
 
 	fmt.Printf("=== Changes in %s ===\n", version)
-	fmt.Printf("Total commits: %d\n\n", len(commits))
+	printCommitTotal(len(commits), since, until)
 
 	for _, c := range commits {
 		fmt.Printf("  %s  %s  %s\n", c.ShortHash, c.Date, c.Title)
 	}
 
+	fmt.Printf("\n")
+	var gitlabClient *gitlab.Client
+	if cfg.GitLabToken != "" {
+		gitlabClient = gitlab.NewClient(context.Background(), cfg.GitLabToken, githubClient, gitlab.OptionsFromConfig(cfg))
+	}
+	printSaaSDeployment(gitlabClient, localRepo, component, previousVersion, version)
+
 	fmt.Printf("\nRepository: %s/%s\n", owner, repo)
 }
 
+// handleBranchDiff lists the commits of headBranch that are not in baseBranch, in the
+// configured repository, committed between since and until
+func handleBranchDiff(baseBranch, headBranch, since, until string) {
+	sinceTime, untilTime, err := models.ParseCommitDateRange(since, until)
+	if err != nil {
+		log.Fatalf("Invalid date filter: %v", err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	fmt.Printf("=== Branch Comparison ===\n")
+	fmt.Printf("Base branch: %s\n", baseBranch)
+	fmt.Printf("Head branch: %s\n", headBranch)
+	fmt.Printf("Repository: %s/%s\n", cfg.Owner, cfg.Repository)
+	fmt.Printf("Comparing %s...%s\n\n", baseBranch, headBranch)
+
+	githubClient := github.NewClient(context.Background(), cfg.GitHubToken, github.OptionsFromConfig(cfg))
+	repoCommits, err := githubClient.GetCommitsBetweenBranches(cfg.Owner, cfg.Repository, baseBranch, headBranch)
+	if err != nil {
+		log.Fatalf("Failed to get commits between branches: %v", err)
+	}
+
+	// Newest first, like git log in -v
+	commits := make([]models.CommitInfo, 0, len(repoCommits))
+	for i := len(repoCommits) - 1; i >= 0; i-- {
+		c := repoCommits[i]
+		title, _, _ := strings.Cut(c.GetCommit().GetMessage(), "\n")
+		commits = append(commits, models.CommitInfo{
+			ShortHash: shortSHA(c.GetSHA()),
+			Date:      c.GetCommit().GetCommitter().GetDate().Format(time.RFC3339),
+			Title:     title,
+		})
+	}
+	commits = models.FilterCommitsByDate(commits, sinceTime, untilTime)
+
+	fmt.Printf("=== Commits in %s not in %s ===\n", headBranch, baseBranch)
+	printCommitTotal(len(commits), since, until)
+
+	for _, c := range commits {
+		fmt.Printf("  %s  %s  %s\n", c.ShortHash, c.Date, c.Title)
+	}
+
+	fmt.Printf("\nRepository: %s/%s\n", cfg.Owner, cfg.Repository)
+}
+
+// saasBranchesToCheck limits the SaaS deployment check to the newest MCE branches
+const saasBranchesToCheck = 3
+
+// printSaaSDeployment reports whether the changes between previousVersion and version
+// are deployed in SaaS, i.e. in a snapshot of one of the newest MCE branches. Both tags
+// are resolved with FindSnapshotForVersion; when no snapshot announces version, the
+// latest snapshot of each branch is checked for containing it. gitlabClient is nil
+// when PR_BOT_GITLAB_TOKEN is not set.
+func printSaaSDeployment(gitlabClient *gitlab.Client, localRepo *gitlocal.Repo, component, previousVersion, version string) {
+	if gitlabClient == nil {
+		logger.Debug("Skipping SaaS deployment check: PR_BOT_GITLAB_TOKEN not set")
+		return
+	}
+
+	projectID, err := gitlabClient.ProjectForProduct("MCE")
+	if err != nil {
+		logger.Debug("Skipping SaaS deployment check: %v", err)
+		return
+	}
+
+	branches, err := gitlabClient.ListMCEBranches(projectID)
+	if err != nil {
+		logger.Debug("Skipping SaaS deployment check: %v", err)
+		return
+	}
+	if len(branches) > saasBranchesToCheck {
+		branches = branches[:saasBranchesToCheck]
+	}
+
+	var checkErrs []error
+	for _, branch := range branches {
+		tagSnapshots := make(map[string]string)
+		for _, tag := range []string{previousVersion, version} {
+			snapshot, err := gitlabClient.FindSnapshotForVersion(branch, strings.TrimPrefix(tag, "v"))
+			if err != nil {
+				logger.Debug("No %s snapshot for %s: %v", branch, tag, err)
+				continue
+			}
+			tagSnapshots[tag] = snapshot
+		}
+		if snapshot, ok := tagSnapshots[version]; ok {
+			fmt.Printf("🌐 SaaS: deployed in %s snapshot %s\n", branch, snapshotDay(snapshot))
+			return
+		}
+		if snapshot, ok := tagSnapshots[previousVersion]; ok {
+			logger.Debug("%s snapshot %s has %s but no snapshot announces %s", branch, snapshot, previousVersion, version)
+		}
+
+		snapshot, err := gitlabClient.FindLatestSnapshot(projectID, branch)
+		if err != nil {
+			logger.Debug("No snapshot found in %s: %v", branch, err)
+			continue
+		}
+
+		componentSHA, err := gitlabClient.ExtractComponentSHA(branch, snapshot, component)
+		if err != nil {
+			logger.Debug("No %s SHA in %s snapshot %s: %v", component, branch, snapshot, err)
+			continue
+		}
+
+		contains, err := localRepo.IsAncestor(version, componentSHA)
+		if err != nil {
+			checkErrs = append(checkErrs, fmt.Errorf("%s snapshot %s: %w", branch, snapshot, err))
+			continue
+		}
+		if contains {
+			fmt.Printf("🌐 SaaS: deployed in %s snapshot %s\n", branch, snapshotDay(snapshot))
+			return
+		}
+	}
+
+	if len(checkErrs) > 0 {
+		fmt.Printf("⚠️  SaaS: deployment unknown, failed to check %v\n", errors.Join(checkErrs...))
+		return
+	}
+	fmt.Printf("🚫 SaaS: not yet deployed\n")
+}
+
+// snapshotDay returns the YYYY-MM-DD date of a snapshot folder named after a
+// timestamp like 2025-03-14-18-55-26.
+func snapshotDay(snapshot string) string {
+	if len(snapshot) >= 10 {
+		return snapshot[:10]
+	}
+	return snapshot
+}
+
+// printCommitTotal prints the number of commits listed and the date filter applied to them
+func printCommitTotal(total int, since, until string) {
+	if label := models.CommitDateRangeLabel(since, until); label != "" {
+		fmt.Printf("Total commits (committed %s): %d\n\n", label, total)
+		return
+	}
+	fmt.Printf("Total commits: %d\n\n", total)
+}
+
+// handleMCEVersionComparison compares an MCE version with its previous release using
+// GitLab snapshots, listing the commits committed between since and until
+func handleMCEVersionComparison(component, version, since, until string) {
+	sinceTime, untilTime, err := models.ParseCommitDateRange(since, until)
+	if err != nil {
+		log.Fatalf("Invalid date filter: %v", err)
+	}
 
-// handleMCEVersionComparison compares an MCE version with its previous release using GitLab snapshots
-func handleMCEVersionComparison(component, version string) {
 	fmt.Printf("=== MCE Version Comparison ===\n")
 	fmt.Printf("Target MCE version: %s\n", version)
 	fmt.Printf("Component: %s\n", component)
@@ -402,22 +978,22 @@ func handleMCEVersionComparison(component, version string) {
 	}
 
 	ctx := context.Background()
-	githubClient := github.NewClient(ctx, cfg.GitHubToken)
+	githubClient := github.NewClient(ctx, cfg.GitHubToken, github.OptionsFromConfig(cfg))
 	rm := createRepoManager(cfg)
 
-	gitlabClient := gitlab.NewClient(ctx, cfg.GitLabToken, githubClient)
+	gitlabClient := gitlab.NewClient(ctx, cfg.GitLabToken, githubClient, gitlab.OptionsFromConfig(cfg))
 	if gitlabClient == nil {
 		log.Fatalf("Failed to create GitLab client. Please set PR_BOT_GITLAB_TOKEN environment variable.")
 	}
 
 	// Load GA parser
-	gaParser, err := ga.NewParser(cfg.GoogleServiceAccountJSON, cfg.GoogleSheetID)
+	gaParser, err := ga.NewParser(cfg.GoogleServiceAccountJSON, cfg.GoogleSheetID, ga.OptionsFromConfig(cfg))
 	if err != nil {
 		log.Fatalf("Failed to create GA parser: %v", err)
 	}
 
 	// Find previous MCE version
-	previousVersion, err := findPreviousMCEVersion(version, gaParser)
+	previousVersion, err := analyzer.FindPreviousMCEVersion(gitlabClient, gaParser, version)
 	if err != nil {
 		log.Fatalf("Failed to find previous MCE version: %v", err)
 	}
@@ -425,13 +1001,15 @@ func handleMCEVersionComparison(component, version string) {
 	fmt.Printf("Previous MCE version: %s\n", previousVersion)
 
 	// Get SHA for target version
-	targetSHA, err := getMCESHA(gitlabClient, component, version)
+	targetSHA, err := analyzer.GetMCESHA(gitlabClient, component, version, analyzer.MCEGADate(gaParser, version))
 	if err != nil {
 		log.Fatalf("Failed to get SHA for MCE %s: %v", version, err)
 	}
 
+	checkTagSnapshotSkew(githubClient, component, version, targetSHA, cfg.SHASkewThreshold)
+
 	// Get SHA for previous version
-	previousSHA, err := getMCESHA(gitlabClient, component, previousVersion)
+	previousSHA, err := analyzer.GetMCESHA(gitlabClient, component, previousVersion, analyzer.MCEGADate(gaParser, previousVersion))
 	if err != nil {
 		log.Fatalf("Failed to get SHA for MCE %s: %v", previousVersion, err)
 	}
@@ -460,9 +1038,10 @@ func handleMCEVersionComparison(component, version string) {
 	if err != nil {
 		log.Fatalf("Failed to get commits between SHAs: %v", err)
 	}
+	commits = models.FilterCommitsByDate(commits, sinceTime, untilTime)
 
 	fmt.Printf("=== Changes in MCE %s ===\n", version)
-	fmt.Printf("Total commits: %d\n\n", len(commits))
+	printCommitTotal(len(commits), since, until)
 
 	if len(commits) == 0 {
 		fmt.Printf("No commits found between %s and %s\n", previousSHA[:8], targetSHA[:8])
@@ -476,200 +1055,259 @@ func handleMCEVersionComparison(component, version string) {
 	fmt.Printf("\nRepository: %s/%s\n", owner, repo)
 }
 
-// findPreviousMCEVersion finds the previous MCE version using GitLab snapshot data
-func findPreviousMCEVersion(version string, gaParser *ga.Parser) (string, error) {
-	logger.Debug("Finding previous MCE version for %s using GitLab snapshot data", version)
-
-	// Parse the version
-	parts := strings.Split(version, ".")
-	if len(parts) != 3 {
-		return "", fmt.Errorf("invalid version format: %s", version)
-	}
+// checkTagSnapshotSkew warns when the component's GitHub tag for an MCE version points
+// more than threshold commits away from the SHA recorded in the MCE snapshot.
+func checkTagSnapshotSkew(githubClient *github.Client, component, version, snapshotSHA string, threshold int) {
+	owner, repo := getRepositoryForComponent(component)
+	versionTag := "v" + strings.TrimPrefix(version, "v")
 
-	major, err := strconv.Atoi(parts[0])
+	tagSHA, err := githubClient.GetTagCommitSHA(owner, repo, versionTag)
 	if err != nil {
-		return "", fmt.Errorf("invalid major version: %s", parts[0])
+		// Most components are not tagged with MCE versions, so a missing tag is expected
+		logger.Debug("Skipping tag/snapshot SHA check for %s: %v", versionTag, err)
+		return
 	}
-
-	minor, err := strconv.Atoi(parts[1])
-	if err != nil {
-		return "", fmt.Errorf("invalid minor version: %s", parts[1])
+	if tagSHA == snapshotSHA {
+		return
 	}
 
-	patch, err := strconv.Atoi(parts[2])
+	apart, err := githubClient.CommitsApart(owner, repo, tagSHA, snapshotSHA)
 	if err != nil {
-		return "", fmt.Errorf("invalid patch version: %s", parts[2])
+		logger.Debug("Failed to count commits between %s and %s: %v", tagSHA, snapshotSHA, err)
+		return
 	}
+	if apart > threshold {
+		fmt.Printf("⚠️ SHA mismatch: GitHub tag %s is at %s but MCE snapshot shows %s (%d commits apart)\n",
+			versionTag, shortSHA(tagSHA), shortSHA(snapshotSHA), apart)
+	}
+}
 
-	// Load configuration to get GitLab client
+// handleCommitLookup lists the PRs associated with a commit SHA
+func handleCommitLookup(sha string) {
 	cfg, err := config.Load()
 	if err != nil {
-		return "", fmt.Errorf("failed to load configuration: %w", err)
+		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	ctx := context.Background()
-	githubClient := github.NewClient(ctx, cfg.GitHubToken)
-	gitlabClient := gitlab.NewClient(ctx, cfg.GitLabToken, githubClient)
-	if gitlabClient == nil {
-		return "", fmt.Errorf("failed to create GitLab client")
+	githubClient := github.NewClient(context.Background(), cfg.GitHubToken, github.OptionsFromConfig(cfg))
+	prs, err := githubClient.ListPRsForCommit(cfg.Owner, cfg.Repository, sha)
+	if err != nil {
+		log.Fatalf("Failed to look up PRs for commit: %v", err)
 	}
 
-	if patch == 0 {
-		// For X.Y.0 versions, look in the previous minor branch (X.Y-1)
-		if minor == 0 {
-			return "", fmt.Errorf("cannot find previous version for %s (first minor version)", version)
-		}
-
-		previousMinorBranch := fmt.Sprintf("mce-%d.%d", major, minor-1)
-		logger.Debug("Looking for latest snapshot in previous minor branch: %s", previousMinorBranch)
-
-		// Try to verify the previous minor branch exists (optional verification)
-		_, err := gitlabClient.FindLatestSnapshot(previousMinorBranch)
-		if err != nil {
-			logger.Debug("Warning: Could not verify GitLab branch %s exists: %v. Proceeding with Excel data lookup.", previousMinorBranch, err)
-		}
+	fmt.Printf("=== PRs containing commit %s (%s/%s) ===\n", sha, cfg.Owner, cfg.Repository)
+	if len(prs) == 0 {
+		fmt.Printf("No PRs found for this commit\n")
+		return
+	}
 
-		// Find what versions exist in that branch by looking at Excel data
-		mceReleases, err := gaParser.GetAllMCEReleases()
-		if err != nil {
-			logger.Debug("Warning: failed to get MCE releases from Excel: %v", err)
-			// Fallback: assume latest patch in previous minor is high number
-			return fmt.Sprintf("%d.%d.10", major, minor-1), nil
+	for _, pr := range prs {
+		state := pr.GetState()
+		if pr.MergedAt != nil {
+			state = "merged"
 		}
+		fmt.Printf("  #%d [%s] %s\n", pr.GetNumber(), state, pr.GetTitle())
+		fmt.Printf("      %s\n", pr.GetHTMLURL())
+	}
+}
 
-		// Find the latest released version in the previous minor series
-		var latestInPrevious string
-		expectedMinor := fmt.Sprintf("%d.%d", major, minor-1)
-
-		for _, release := range mceReleases {
-			if release.MCEVersion == "" || release.GADate == nil {
-				continue
-			}
+// jiraDescriptionMaxLines caps how much of a ticket description the -jt analysis prints.
+const jiraDescriptionMaxLines = 10
 
-			releaseParts := strings.Split(release.MCEVersion, ".")
-			if len(releaseParts) >= 2 {
-				releaseMinor := releaseParts[0] + "." + releaseParts[1]
-				if releaseMinor == expectedMinor {
-					// Check if this version was actually released (GA date is in the past)
-					if release.GADate.Before(time.Now()) {
-						if latestInPrevious == "" || compareMCEVersions(release.MCEVersion, latestInPrevious) > 0 {
-							latestInPrevious = release.MCEVersion
-						}
-					}
-				}
-			}
-		}
+// printJiraDescription prints the first lines of a rendered ticket description
+func printJiraDescription(description string) {
+	if description == "" {
+		return
+	}
 
-		if latestInPrevious != "" {
-			logger.Debug("Found latest released version in previous minor series: %s", latestInPrevious)
-			return latestInPrevious, nil
+	lines := strings.Split(description, "\n")
+	fmt.Printf("Description:\n")
+	for i, line := range lines {
+		if i == jiraDescriptionMaxLines {
+			fmt.Printf("    ... (%d more lines)\n", len(lines)-i)
+			break
 		}
+		fmt.Printf("    %s\n", line)
+	}
+}
 
-		return "", fmt.Errorf("no released previous version found for %s in minor series %s", version, expectedMinor)
+// handleDetectPatterns prints the release branch patterns found in a repository's branch names
+func handleDetectPatterns(ownerRepo string) {
+	owner, repo, ok := strings.Cut(ownerRepo, "/")
+	if !ok || owner == "" || repo == "" {
+		fmt.Fprintf(os.Stderr, "❌ Error: Repository must be given as owner/repo\n")
+		fmt.Fprintf(os.Stderr, "Example: pr-bot -detect-patterns openshift/assisted-installer-ui\n")
+		os.Exit(1)
+	}
 
-	} else {
-		// For X.Y.Z versions where Z > 0, look for X.Y.(Z-1) in the same branch
-		previousPatch := patch - 1
-		if previousPatch < 0 {
-			return "", fmt.Errorf("cannot find previous patch version for %s", version)
-		}
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
 
-		previousVersion := fmt.Sprintf("%d.%d.%d", major, minor, previousPatch)
-		logger.Debug("Calculated previous patch version: %s", previousVersion)
+	githubClient := github.NewClient(context.Background(), cfg.GitHubToken, github.OptionsFromConfig(cfg))
+	patterns, err := githubClient.DetectBranchPatterns(owner, repo)
+	if err != nil {
+		log.Fatalf("Failed to detect branch patterns: %v", err)
+	}
 
-		// For patch versions, we assume the previous patch exists if we can find snapshots
-		// Let's verify the snapshot exists by trying to access the branch
-		currentBranch := fmt.Sprintf("mce-%d.%d", major, minor)
-		_, err := gitlabClient.FindLatestSnapshot(currentBranch)
-		if err != nil {
-			return "", fmt.Errorf("failed to find snapshots in branch %s: %w", currentBranch, err)
-		}
+	fmt.Printf("=== Branch patterns detected in %s/%s ===\n", owner, repo)
+	if len(patterns) == 0 {
+		fmt.Printf("No version branch patterns found\n")
+		return
+	}
 
-		logger.Debug("Found snapshots in branch %s, previous version is: %s", currentBranch, previousVersion)
-		return previousVersion, nil
+	for _, pattern := range patterns {
+		fmt.Printf("  %q\n", pattern)
 	}
 }
 
-// compareMCEVersions compares two MCE version strings (e.g., "2.8.1" vs "2.9.0")
-func compareMCEVersions(v1, v2 string) int {
-	// Parse version parts
-	parts1 := strings.Split(v1, ".")
-	parts2 := strings.Split(v2, ".")
+// handleMCESnapshotComparison shows which component SHAs changed between two MCE versions
+func handleMCESnapshotComparison(version1, version2 string) {
+	fmt.Printf("=== MCE Snapshot Comparison ===\n")
+	fmt.Printf("Comparing MCE %s -> %s\n\n", version1, version2)
 
-	// Compare each part
-	maxParts := len(parts1)
-	if len(parts2) > maxParts {
-		maxParts = len(parts2)
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if cfg.GitLabToken == "" {
+		log.Fatalf("GitLab token not configured. Please set PR_BOT_GITLAB_TOKEN environment variable.")
 	}
 
-	for i := 0; i < maxParts; i++ {
-		var num1, num2 int
+	ctx := context.Background()
+	githubClient := github.NewClient(ctx, cfg.GitHubToken, github.OptionsFromConfig(cfg))
+	gitlabClient := gitlab.NewClient(ctx, cfg.GitLabToken, githubClient, gitlab.OptionsFromConfig(cfg))
+	rm := createRepoManager(cfg)
 
-		if i < len(parts1) {
-			num1, _ = strconv.Atoi(parts1[i])
-		}
-		if i < len(parts2) {
-			num2, _ = strconv.Atoi(parts2[i])
+	branch1, err := mceBranchForVersion(version1)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+	branch2, err := mceBranchForVersion(version2)
+	if err != nil {
+		log.Fatalf("%v", err)
+	}
+
+	diffs, err := gitlabClient.CompareVersionSnapshots(branch1, version1, branch2, version2)
+	if err != nil {
+		log.Fatalf("Failed to compare MCE snapshots: %v", err)
+	}
+
+	var currentStatus gitlab.ComponentDiffStatus
+	for _, diff := range diffs {
+		if diff.Status != currentStatus {
+			currentStatus = diff.Status
+			fmt.Printf("\n%s:\n", currentStatus)
 		}
 
-		if num1 < num2 {
-			return -1
-		} else if num1 > num2 {
-			return 1
+		switch diff.Status {
+		case gitlab.DiffChanged:
+			fmt.Printf("  %s  %s -> %s\n", diff.Repository, shortSHA(diff.OldSHA), shortSHA(diff.NewSHA))
+			printComponentCommits(rm, cfg.GitHubToken, diff)
+		case gitlab.DiffAddedInNew:
+			fmt.Printf("  %s  %s\n", diff.Repository, shortSHA(diff.NewSHA))
+		default:
+			fmt.Printf("  %s  %s\n", diff.Repository, shortSHA(diff.OldSHA))
 		}
 	}
-
-	return 0 // versions are equal
 }
 
-// getMCESHA extracts the component SHA from MCE snapshot for given version
-func getMCESHA(gitlabClient *gitlab.Client, component, version string) (string, error) {
-	// Calculate MCE branch (e.g., 2.8.1 -> mce-2.8)
-	parts := strings.Split(version, ".")
-	if len(parts) < 2 {
-		return "", fmt.Errorf("invalid version format: %s", version)
+// handleVersionMatrix prints a tab-separated table of the component SHAs in each snapshot of an MCE branch
+func handleVersionMatrix(branch string) {
+	// Accept a bare version such as 2.8 as well as the branch name
+	if !strings.HasPrefix(branch, "mce-") {
+		mceBranch, err := mceBranchForVersion(branch)
+		if err != nil {
+			log.Fatalf("%v", err)
+		}
+		branch = mceBranch
 	}
-	mceBranch := fmt.Sprintf("mce-%s.%s", parts[0], parts[1])
 
-	// Find the appropriate snapshot for this version
-	// For version comparison, we want the latest snapshot in the branch
-	// This is a simplified approach - ideally we'd find the exact snapshot for the version
-	snapshot, err := findLatestMCESnapshot(gitlabClient, mceBranch)
+	cfg, err := config.Load()
 	if err != nil {
-		return "", fmt.Errorf("failed to find snapshot for MCE %s: %v", version, err)
+		log.Fatalf("Failed to load configuration: %v", err)
 	}
 
-	// Extract SHA from the snapshot using existing GitLab client method
-	sha, err := gitlabClient.ExtractComponentSHA(mceBranch, snapshot, component)
+	ctx := context.Background()
+	githubClient := github.NewClient(ctx, cfg.GitHubToken, github.OptionsFromConfig(cfg))
+	gitlabClient := gitlab.NewClient(ctx, cfg.GitLabToken, githubClient, gitlab.OptionsFromConfig(cfg))
+
+	rows, err := gitlabClient.BuildVersionMatrix(branch)
 	if err != nil {
-		// Check if this is a version mismatch issue (simplified detection)
-		if strings.Contains(err.Error(), "no valid snapshots found with version") {
-			// Always try to get the actual version from this snapshot to provide a better error
-			actualVersion, versionErr := gitlabClient.GetVersionFromSnapshot(mceBranch, snapshot)
-			if versionErr == nil {
-				if actualVersion != version {
-					return "", fmt.Errorf("❌ MCE version mismatch: You requested %s, but the latest snapshot in %s branch contains %s.\n💡 Try: pr-bot -v mce %s %s", version, mceBranch, actualVersion, component, actualVersion)
-				} else {
-					// Same version but still failing - show the original error with context
-					return "", fmt.Errorf("❌ MCE %s error for component %s: %v\n💡 This might be a temporary GitLab issue or the component might not be available in this MCE version", version, component, err)
-				}
-			} else {
-				// Couldn't get version from snapshot, show original error with helpful context
-				return "", fmt.Errorf("❌ MCE %s error for component %s: %v\n💡 Unable to determine actual MCE version from snapshot. This might be a GitLab connectivity issue", version, component, err)
+		log.Fatalf("Failed to build version matrix: %v", err)
+	}
+
+	// One column per repository seen in any snapshot
+	repoSet := make(map[string]bool)
+	for _, row := range rows {
+		for repository := range row.Components {
+			repoSet[repository] = true
+		}
+	}
+	repositories := make([]string, 0, len(repoSet))
+	for repository := range repoSet {
+		repositories = append(repositories, repository)
+	}
+	sort.Strings(repositories)
+
+	fmt.Printf("SNAPSHOT\tMCE_VERSION\t%s\n", strings.Join(repositories, "\t"))
+	for _, row := range rows {
+		cells := make([]string, len(repositories))
+		for i, repository := range repositories {
+			cells[i] = shortSHA(row.Components[repository])
+			if cells[i] == "" {
+				cells[i] = "-"
 			}
 		}
+		version := row.MCEVersion
+		if version == "" {
+			version = "-"
+		}
+		fmt.Printf("%s\t%s\t%s\n", row.SnapshotFolder, version, strings.Join(cells, "\t"))
+	}
+}
 
-		// For other types of errors, show the original error
-		return "", fmt.Errorf("failed to extract %s SHA from snapshot %s: %v", component, snapshot, err)
+// printComponentCommits prints the commit log for a changed component we keep a local clone of
+func printComponentCommits(rm *gitlocal.RepoManager, token string, diff gitlab.ComponentDiff) {
+	for _, component := range []string{"assisted-service", "assisted-installer", "assisted-installer-agent"} {
+		owner, repo := getRepositoryForComponent(component)
+		if diff.Repository != owner+"/"+repo {
+			continue
+		}
+
+		localRepo, err := rm.EnsureRepo(owner, repo, token)
+		if err != nil {
+			logger.Debug("Failed to ensure local repo %s/%s: %v", owner, repo, err)
+			return
+		}
+		commits, err := localRepo.LogBetween(diff.OldSHA, diff.NewSHA)
+		if err != nil {
+			logger.Debug("Failed to get commits for %s: %v", diff.Repository, err)
+			return
+		}
+		for _, c := range commits {
+			fmt.Printf("      %s  %s  %s\n", c.ShortHash, c.Date, c.Title)
+		}
+		return
 	}
+}
 
-	return sha, nil
+// mceBranchForVersion returns the MCE GitLab branch for a version (e.g., 2.8.1 -> mce-2.8)
+func mceBranchForVersion(version string) (string, error) {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return "", fmt.Errorf("invalid version format: %s", version)
+	}
+	return fmt.Sprintf("mce-%s.%s", parts[0], parts[1]), nil
 }
 
-// findLatestMCESnapshot finds the latest snapshot folder for MCE branch in GitLab
-func findLatestMCESnapshot(gitlabClient *gitlab.Client, mceBranch string) (string, error) {
-	// Use the new GitLab client method to find the latest snapshot
-	return gitlabClient.FindLatestSnapshot(mceBranch)
+// shortSHA abbreviates a commit SHA for display
+func shortSHA(sha string) string {
+	if len(sha) > 8 {
+		return sha[:8]
+	}
+	return sha
 }
 
 // handleSlackSearch searches for PR-related messages in Slack
@@ -706,11 +1344,16 @@ func createRepoManager(cfg *models.Config) *gitlocal.RepoManager {
 	if err != nil {
 		log.Fatalf("Failed to create repo manager: %v", err)
 	}
+	if host := github.EnterpriseHost(cfg.GitHubBaseURL); host != "" {
+		rm.SetHost(host)
+	}
 	return rm
 }
 
-// handlePRAnalysis analyzes a PR (existing functionality)
-func handlePRAnalysis(prURL string) {
+// handlePRAnalysis analyzes a PR (existing functionality), optionally posting the result
+// on the PR and then watching it for new release branches. With a labelFilter, only
+// release branches named by a matching PR label are reported.
+func handlePRAnalysis(prURL string, postComment, noCache, dryRun, showFiles bool, labelFilter *regexp.Regexp, watch watchOptions) {
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -718,7 +1361,7 @@ func handlePRAnalysis(prURL string) {
 	}
 
 	// Parse PR number or URL
-	prNumber, owner, repo, err := github.ParsePRInput(prURL)
+	prNumber, owner, repo, err := github.ParsePRInput(prURL, github.EnterpriseHost(cfg.GitHubBaseURL))
 	if err != nil {
 		log.Fatalf("Failed to parse PR input '%s': %v", prURL, err)
 	}
@@ -731,23 +1374,386 @@ func handlePRAnalysis(prURL string) {
 
 	ctx := context.Background()
 	rm := createRepoManager(cfg)
-	a, err := analyzer.New(ctx, cfg, rm)
+	if watch.Enabled {
+		rm.SetFetchTTL(watch.fetchTTL())
+	}
+	progress := newProgressPrinter()
+	a, err := analyzer.New(ctx, cfg, rm, analyzer.WithProgressFunc(progress.ProgressFunc(prURL)), resultCacheOption(cfg, noCache))
 	if err != nil {
 		log.Fatalf("Failed to create analyzer: %v", err)
 	}
 
 	result, err := a.AnalyzePR(prNumber)
+	progress.Clear()
 	if err != nil {
 		log.Fatalf("Failed to analyze PR #%d: %v", prNumber, err)
 	}
+	if labelFilter != nil {
+		result.FilterBranchesByLabels(labelFilter)
+	}
 
 	// Print results
 	a.PrintSummary(result)
+	printChangedFiles(a.GetGitHubClient(), cfg.Owner, cfg.Repository, prNumber, showFiles)
+
+	if postComment {
+		botVersion, err := version.GetCurrentVersion()
+		if err != nil {
+			logger.Debug("Could not determine pr-bot version for the PR comment: %v", err)
+		}
+		comment := github.FormatAnalysisComment(result, botVersion)
+		if err := a.GetGitHubClient().PostAnalysisComment(ctx, dryRun, cfg.Owner, cfg.Repository, prNumber, comment); err != nil {
+			fmt.Printf("\n❌ Failed to post analysis comment to PR #%d: %v\n", prNumber, err)
+		} else if !dryRun {
+			fmt.Printf("\n💬 Posted analysis as a comment on PR #%d\n", prNumber)
+		}
+	}
+
+	if watch.Enabled {
+		watchPR(a, prNumber, result, watch.Interval, watch.Timeout, progress)
+	}
+}
+
+// maxListedChangedFiles is the number of changed files from which -pr only prints
+// their count unless -show-files is given.
+const maxListedChangedFiles = 20
+
+// printChangedFiles prints the files a PR changes: all of them when there are fewer
+// than maxListedChangedFiles or showAll is set, otherwise only their count.
+func printChangedFiles(githubClient *github.Client, owner, repo string, prNumber int, showAll bool) {
+	files, err := githubClient.GetPRDiff(owner, repo, prNumber)
+	if err != nil {
+		logger.Debug("Failed to list changed files of PR #%d: %v", prNumber, err)
+		return
+	}
+
+	if len(files) >= maxListedChangedFiles && !showAll {
+		fmt.Printf("\n📁 Files changed: %d (expand with -show-files)\n", len(files))
+		return
+	}
+	fmt.Printf("\n📁 Files changed: %d\n", len(files))
+	for _, file := range files {
+		fmt.Printf("  %s\n", file)
+	}
+}
+
+// resultCacheOption caches -pr results in the user's cache directory. With noCache,
+// cached results are not used but are still refreshed.
+func resultCacheOption(cfg *models.Config, noCache bool) analyzer.Option {
+	dir, err := resultcache.DefaultDir()
+	if err != nil {
+		logger.Debug("Result cache disabled: %v", err)
+		return func(*analyzer.Analyzer) {}
+	}
+	return analyzer.WithResultCache(resultcache.New(dir, cfg.ResultCacheTTL), !noCache)
+}
+
+// prFileEntry is a PR listed in a -prs-file file, with its analysis outcome.
+type prFileEntry struct {
+	Number   int
+	Owner    string
+	Repo     string
+	Analyzer *analyzer.Analyzer
+	Result   *models.PRAnalysisResult
+	Err      error
+}
+
+// readPRsFile reads PRs from path, one GitHub PR URL or PR number per line. Blank
+// lines and lines starting with # are skipped, bare numbers refer to the configured
+// repository, and PRs listed more than once are kept only the first time.
+func readPRsFile(path string, cfg *models.Config) ([]*prFileEntry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read PRs file: %w", err)
+	}
+
+	var entries []*prFileEntry
+	seen := make(map[string]bool)
+	for i, line := range strings.Split(string(data), "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		prNumber, owner, repo, err := github.ParsePRInput(line, github.EnterpriseHost(cfg.GitHubBaseURL))
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %q: %w", i+1, line, err)
+		}
+		if owner == "" || repo == "" {
+			owner, repo = cfg.Owner, cfg.Repository
+		}
+
+		key := fmt.Sprintf("%s/%s#%d", owner, repo, prNumber)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		entries = append(entries, &prFileEntry{Number: prNumber, Owner: owner, Repo: repo})
+	}
+	return entries, nil
+}
+
+// handlePRsFileAnalysis analyzes every PR listed in a file and prints how many
+// release branches each was found in. verbose also prints each PR's full analysis.
+func handlePRsFileAnalysis(path string, verbose bool) {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	entries, err := readPRsFile(path, cfg)
+	if err != nil {
+		log.Fatalf("Failed to load PRs from %s: %v", path, err)
+	}
+	if len(entries) == 0 {
+		log.Fatalf("No PRs found in %s", path)
+	}
+	fmt.Printf("Analyzing %d PRs from %s...\n", len(entries), path)
+
+	ctx := context.Background()
+	rm := createRepoManager(cfg)
+
+	// Use a channel to control concurrency
+	concurrencyLimit := cfg.Concurrency.WithDefaults().PRAnalysisWorkers // Limit concurrent PR analyses to avoid overwhelming APIs
+	semaphore := make(chan struct{}, concurrencyLimit)
+
+	// WaitGroup to wait for all goroutines
+	var wg sync.WaitGroup
+
+	progress := newProgressPrinter()
+	for _, entry := range entries {
+		wg.Add(1)
+		go func(entry *prFileEntry) {
+			defer wg.Done()
+
+			// Acquire semaphore
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
+			// Each PR is analyzed against its own repository
+			prCfg := *cfg
+			prCfg.Owner = entry.Owner
+			prCfg.Repository = entry.Repo
+
+			key := fmt.Sprintf("%s/%s#%d", entry.Owner, entry.Repo, entry.Number)
+			entry.Analyzer, entry.Err = analyzer.New(ctx, &prCfg, rm, analyzer.WithProgressFunc(progress.ProgressFunc(key)))
+			if entry.Err != nil {
+				return
+			}
+			entry.Result, entry.Err = entry.Analyzer.AnalyzePRWithOptions(entry.Number, true)
+		}(entry)
+	}
+
+	// Wait for all PR analyses to complete
+	wg.Wait()
+	progress.Clear()
+
+	if verbose {
+		for _, entry := range entries {
+			if entry.Result != nil {
+				fmt.Print("\n" + strings.Repeat("=", 80) + "\n")
+				entry.Analyzer.PrintSummary(entry.Result)
+			}
+		}
+		fmt.Print("\n" + strings.Repeat("=", 80) + "\n")
+	}
+
+	// Summary table, in file order
+	repoWidth := len("REPOSITORY")
+	for _, entry := range entries {
+		repoWidth = max(repoWidth, len(entry.Owner)+1+len(entry.Repo))
+	}
+
+	fmt.Printf("\n%-8s  %-*s  %s\n", "PR", repoWidth, "REPOSITORY", "RELEASE BRANCHES")
+	failed := 0
+	for _, entry := range entries {
+		status := ""
+		switch {
+		case entry.Err != nil:
+			failed++
+			status = fmt.Sprintf("error: %v", entry.Err)
+		case entry.Result.PR.MergedAt == nil:
+			status = "not merged"
+		default:
+			found := 0
+			for _, branch := range entry.Result.ReleaseBranches {
+				if branch.Found {
+					found++
+				}
+			}
+			status = fmt.Sprintf("%d of %d", found, len(entry.Result.ReleaseBranches))
+		}
+		fmt.Printf("%-8s  %-*s  %s\n", fmt.Sprintf("#%d", entry.Number), repoWidth, entry.Owner+"/"+entry.Repo, status)
+	}
+
+	if failed > 0 {
+		fmt.Printf("\n%d of %d PRs could not be analyzed\n", failed, len(entries))
+	}
+	if !verbose {
+		fmt.Printf("\nRun with -verbose for per-branch details.\n")
+	}
+}
+
+// Output formats accepted by -output.
+const (
+	outputText = "text"
+	outputJSON = "json"
+	outputYAML = "yaml"
+	outputCSV  = "csv" // -export-releases only
+)
+
+// progressPrinter shows analysis progress on a single stderr line that is
+// overwritten in place. Progress of concurrent analyses is summed.
+type progressPrinter struct {
+	mu       sync.Mutex
+	analyses map[string][2]int // analysis key -> done, total
+	width    int               // length of the last line printed
+}
+
+func newProgressPrinter() *progressPrinter {
+	return &progressPrinter{analyses: make(map[string][2]int)}
 }
 
+// ProgressFunc returns the progress callback for the analysis identified by key.
+func (p *progressPrinter) ProgressFunc(key string) analyzer.ProgressFunc {
+	return func(stage string, done, total int) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+
+		p.analyses[key] = [2]int{done, total}
+		sumDone, sumTotal := 0, 0
+		for _, progress := range p.analyses {
+			sumDone += progress[0]
+			sumTotal += progress[1]
+		}
+
+		line := fmt.Sprintf("%s: %d/%d", stage, sumDone, sumTotal)
+		fmt.Fprintf(os.Stderr, "\r%-*s", p.width, line)
+		p.width = len(line)
+	}
+}
+
+// Clear erases the progress line so results print on a clean line.
+func (p *progressPrinter) Clear() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.width > 0 {
+		fmt.Fprintf(os.Stderr, "\r%s\r", strings.Repeat(" ", p.width))
+		p.width = 0
+	}
+}
+
+// beginJSONOutput routes everything printed while an analysis runs to stderr, so
+// stdout carries only the JSON or YAML result. It returns the original stdout.
+func beginJSONOutput() *os.File {
+	stdout := os.Stdout
+	os.Stdout = os.Stderr
+	logger.SetOutput(os.Stderr)
+	return stdout
+}
+
+// writeJSON writes v to w as indented JSON
+func writeJSON(w *os.File, v interface{}) {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		log.Fatalf("Failed to encode JSON output: %v", err)
+	}
+}
+
+// writeYAML writes v to w as YAML
+func writeYAML(w *os.File, v interface{}) {
+	encoder := yaml.NewEncoder(w)
+	if err := encoder.Encode(v); err != nil {
+		log.Fatalf("Failed to encode YAML output: %v", err)
+	}
+	if err := encoder.Close(); err != nil {
+		log.Fatalf("Failed to encode YAML output: %v", err)
+	}
+}
+
+// writeStructured writes v to w in format, which is outputJSON or outputYAML
+func writeStructured(w *os.File, format string, v interface{}) {
+	if format == outputYAML {
+		writeYAML(w, v)
+		return
+	}
+	writeJSON(w, v)
+}
+
+// prAnalysisOutput is the -pr result written with -output json or yaml: the analysis
+// result with its summary added.
+type prAnalysisOutput struct {
+	models.PRAnalysisResult `yaml:",inline"`
+	Summary                 models.AnalysisSummary `json:"summary" yaml:"summary"`
+}
+
+// handlePRAnalysisJSON analyzes a PR and writes the result to stdout as JSON, or
+// YAML when format is outputYAML. With a labelFilter, only release branches named
+// by a matching PR label are included.
+func handlePRAnalysisJSON(prURL, format string, noCache bool, labelFilter *regexp.Regexp) {
+	stdout := beginJSONOutput()
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
 
-// handleJiraTicketAnalysis analyzes all PRs related to a JIRA ticket
-func handleJiraTicketAnalysis(jiraInput string) {
+	prNumber, owner, repo, err := github.ParsePRInput(prURL, github.EnterpriseHost(cfg.GitHubBaseURL))
+	if err != nil {
+		log.Fatalf("Failed to parse PR input '%s': %v", prURL, err)
+	}
+	if owner != "" && repo != "" {
+		cfg.Owner = owner
+		cfg.Repository = repo
+	}
+
+	a, err := analyzer.New(context.Background(), cfg, createRepoManager(cfg), resultCacheOption(cfg, noCache))
+	if err != nil {
+		log.Fatalf("Failed to create analyzer: %v", err)
+	}
+
+	result, err := a.AnalyzePR(prNumber)
+	if err != nil {
+		log.Fatalf("Failed to analyze PR #%d: %v", prNumber, err)
+	}
+	if labelFilter != nil {
+		result.FilterBranchesByLabels(labelFilter)
+	}
+
+	writeStructured(stdout, format, prAnalysisOutput{PRAnalysisResult: *result, Summary: result.Summary()})
+}
+
+// handleJiraTicketAnalysisJSON analyzes the PRs of a JIRA ticket and writes the result to stdout
+// as JSON or YAML (format), in the same shape as the REST API's /api/v1/jira response
+func handleJiraTicketAnalysisJSON(jiraInput, format string, filter jira.IssueFilter) {
+	stdout := beginJSONOutput()
+
+	ticketID := extractJiraTicketID(jiraInput)
+	if ticketID == "" {
+		log.Fatalf("Invalid JIRA ticket format: %s", jiraInput)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	if cfg.JiraToken == "" {
+		log.Fatalf("JIRA token not configured. Please set PR_BOT_JIRA_TOKEN in your .env file")
+	}
+
+	result, err := server.AnalyzeJiraTicket(*cfg, createRepoManager(cfg), ticketID, filter)
+	if err != nil {
+		log.Fatalf("Failed to analyze JIRA ticket %s: %v", ticketID, err)
+	}
+
+	writeStructured(stdout, format, result)
+}
+
+// handleJiraTicketAnalysis analyzes all PRs related to a JIRA ticket. Only PRs of
+// tickets matching filter are analyzed.
+func handleJiraTicketAnalysis(jiraInput string, postComment, dryRun bool, filter jira.IssueFilter) {
 	fmt.Printf("=== JIRA Ticket Analysis ===\n")
 
 	// Extract ticket ID from input (could be full URL or just ticket ID)
@@ -772,7 +1778,7 @@ func handleJiraTicketAnalysis(jiraInput string) {
 	rm := createRepoManager(cfg)
 
 	// Create JIRA client for ticket discovery
-	jiraClient := jira.NewClient(ctx, cfg.JiraEmail, cfg.JiraToken)
+	jiraClient := jira.NewClient(ctx, cfg.JiraEmail, cfg.JiraToken, jira.OptionsFromConfig(cfg))
 
 	// Get all related JIRA tickets (main ticket + cloned tickets)
 	fmt.Printf("Finding all related JIRA tickets...\n")
@@ -787,14 +1793,63 @@ func handleJiraTicketAnalysis(jiraInput string) {
 		allTicketKeys[i] = ticket.Key
 	}
 
+	if len(allTicketIssues) > 0 && allTicketIssues[0].Fields.Epic != "" {
+		mainFields := allTicketIssues[0].Fields
+		if mainFields.EpicSummary != "" {
+			fmt.Printf("Epic: %s (%s)\n", mainFields.Epic, mainFields.EpicSummary)
+		} else {
+			fmt.Printf("Epic: %s\n", mainFields.Epic)
+		}
+	}
+	if len(allTicketIssues) > 0 && len(allTicketIssues[0].Fields.FixVersions) > 0 {
+		fmt.Printf("Fix versions: %s\n", strings.Join(allTicketIssues[0].Fields.FixVersions, ", "))
+	}
 	fmt.Printf("Found %d related tickets: %s\n", len(allTicketIssues), strings.Join(allTicketKeys, ", "))
 
-	// Extract all PR URLs from all tickets
+	// The main ticket's priority decides how urgently backports are expected
+	var priority jira.JiraPriority
+	if len(allTicketIssues) > 0 {
+		priority = allTicketIssues[0].Fields.Priority
+	}
+	if badge := jira.PriorityBadge(priority.Name); badge != "" {
+		fmt.Printf("Priority: %s (%s) - backports expected within %s\n", badge, priority.Name, jira.FormatSLA(jira.BackportSLA(priority.Level())))
+	}
+
+	if len(allTicketIssues) > 0 {
+		printJiraDescription(allTicketIssues[0].Fields.Description.PlainText())
+	}
+
+	// Extract all PR and MR URLs from all tickets
 	var allPRURLs []string
+	var mergeRequests []models.MRInfo
+	prURLsByTicket := make(map[string][]string)
+	seenMRs := make(map[string]bool)
+	matchingTickets := 0
 	for _, ticket := range allTicketIssues {
+		if !filter.Matches(ticket) {
+			continue
+		}
+		matchingTickets++
 		// ticket is already a JiraIssue, so we can pass it directly
 		prURLs := jiraClient.ExtractGitHubPRsFromIssue(ticket)
+		prURLsByTicket[ticket.Key] = prURLs
 		allPRURLs = append(allPRURLs, prURLs...)
+
+		for _, mrURL := range jiraClient.ExtractGitLabMRsFromIssue(ticket) {
+			if seenMRs[mrURL] {
+				continue
+			}
+			seenMRs[mrURL] = true
+			mr, err := models.ParseMRURL(mrURL)
+			if err != nil {
+				logger.Debug("Skipping GitLab MR link in %s: %v", ticket.Key, err)
+				continue
+			}
+			mergeRequests = append(mergeRequests, *mr)
+		}
+	}
+	if !filter.IsZero() {
+		fmt.Printf("Tickets matching %s: %d of %d\n", filter, matchingTickets, len(allTicketIssues))
 	}
 
 	// Remove duplicates and filter for supported repositories
@@ -824,9 +1879,23 @@ func handleJiraTicketAnalysis(jiraInput string) {
 
 	if len(uniquePRURLs) == 0 {
 		fmt.Printf("No GitHub PRs found for supported repositories (assisted-service, assisted-installer, assisted-installer-agent, assisted-installer-ui) in the related JIRA tickets\n")
+		if len(mergeRequests) > 0 {
+			printMergeRequests(mergeRequests)
+			if postComment {
+				postJiraAnalysisComment(ctx, jiraClient, dryRun, ticketID, nil, mergeRequests)
+			}
+		}
 		return
 	}
 
+	// Blocking links between the tickets decide the order their PRs should merge in
+	if mergeOrder := suggestedMergeOrder(allTicketIssues, prURLsByTicket, prURLsMap); len(mergeOrder) > 0 {
+		fmt.Printf("Suggested merge order:\n")
+		for i, prURL := range mergeOrder {
+			fmt.Printf("  %d. %s\n", i+1, prURL)
+		}
+	}
+
 	fmt.Printf("Found %d unique PRs to analyze:\n", len(uniquePRURLs))
 	for _, prURL := range uniquePRURLs {
 		fmt.Printf("  • %s\n", prURL)
@@ -834,15 +1903,17 @@ func handleJiraTicketAnalysis(jiraInput string) {
 
 	// Analyze each PR and collect results using goroutines for parallel processing
 	var allResults []*models.PRAnalysisResult
+	var unconfirmedPRs []string // PRs not merged or not found in any release branch
 	var resultsMutex sync.Mutex
 
 	// Use a channel to control concurrency
-	concurrencyLimit := 5 // Limit concurrent PR analyses to avoid overwhelming APIs
+	concurrencyLimit := cfg.Concurrency.WithDefaults().PRAnalysisWorkers // Limit concurrent PR analyses to avoid overwhelming APIs
 	semaphore := make(chan struct{}, concurrencyLimit)
 
 	// WaitGroup to wait for all goroutines
 	var wg sync.WaitGroup
 
+	progress := newProgressPrinter()
 	for _, prURL := range uniquePRURLs {
 		wg.Add(1)
 		go func(prURL string) {
@@ -866,7 +1937,7 @@ func handleJiraTicketAnalysis(jiraInput string) {
 				prCfg.Repository = repo
 			}
 
-			prAnalyzer, err := analyzer.New(ctx, &prCfg, rm)
+			prAnalyzer, err := analyzer.New(ctx, &prCfg, rm, analyzer.WithProgressFunc(progress.ProgressFunc(prURL)))
 			if err != nil {
 				fmt.Printf("Error creating analyzer for PR #%d: %v\n", prNumber, err)
 				return
@@ -876,18 +1947,33 @@ func handleJiraTicketAnalysis(jiraInput string) {
 			result, err := prAnalyzer.AnalyzePRWithOptions(prNumber, true)
 			if err != nil {
 				fmt.Printf("Error analyzing PR #%d: %v\n", prNumber, err)
+				resultsMutex.Lock()
+				unconfirmedPRs = append(unconfirmedPRs, prURL)
+				resultsMutex.Unlock()
 				return
 			}
 
+			foundInRelease := false
+			for _, branch := range result.ReleaseBranches {
+				if branch.Found {
+					foundInRelease = true
+					break
+				}
+			}
+
 			// Thread-safe append to results
 			resultsMutex.Lock()
 			allResults = append(allResults, result)
+			if !foundInRelease {
+				unconfirmedPRs = append(unconfirmedPRs, prURL)
+			}
 			resultsMutex.Unlock()
 		}(prURL)
 	}
 
 	// Wait for all PR analyses to complete
 	wg.Wait()
+	progress.Clear()
 
 	// Display combined results
 	fmt.Print("\n" + strings.Repeat("=", 80) + "\n")
@@ -928,6 +2014,9 @@ func handleJiraTicketAnalysis(jiraInput string) {
 	for _, summary := range prSummaries {
 		fmt.Printf("• %s\n", summary)
 	}
+	if len(mergeRequests) > 0 {
+		printMergeRequests(mergeRequests)
+	}
 
 	// Convert map back to slice for display
 	var allFoundBranches []models.BranchPresence
@@ -940,7 +2029,7 @@ func handleJiraTicketAnalysis(jiraInput string) {
 	if len(allFoundBranches) > 0 {
 		// Group branches by pattern for better organization
 		patternGroups := make(map[string][]models.BranchPresence)
-		patternOrder := []string{"release-ocm-", "release-", "release-v", "v"}
+		patternOrder := cfg.BranchPatternOrder()
 
 		for _, branch := range allFoundBranches {
 			patternGroups[branch.Pattern] = append(patternGroups[branch.Pattern], branch)
@@ -950,10 +2039,8 @@ func handleJiraTicketAnalysis(jiraInput string) {
 		for pattern := range patternGroups {
 			branches := patternGroups[pattern]
 			sort.Slice(branches, func(i, j int) bool {
-				// Parse version numbers for proper sorting (e.g., "2.13" < "2.14" < "2.15")
-				versionI := models.ParseVersionNumber(branches[i].Version)
-				versionJ := models.ParseVersionNumber(branches[j].Version)
-				return versionI < versionJ
+				// Compare version components numerically (e.g., "2.9" < "2.10" < "2.11")
+				return models.CompareBranchVersions(branches[i].Version, branches[j].Version) < 0
 			})
 			patternGroups[pattern] = branches
 		}
@@ -964,7 +2051,7 @@ func handleJiraTicketAnalysis(jiraInput string) {
 		for _, pattern := range patternOrder {
 			branches := patternGroups[pattern]
 			if len(branches) > 0 {
-				fmt.Printf("\n  %s branches (%d):\n", models.PatternDescription(pattern), len(branches))
+				fmt.Printf("\n  %s branches (%d):\n", cfg.PatternDescription(pattern), len(branches))
 				for _, branch := range branches {
 					isNextVersion := strings.Contains(branch.Version, "Next Version") ||
 						(branch.GAStatus.ACM.Status == "Next Version" || branch.GAStatus.MCE.Status == "Next Version")
@@ -974,7 +2061,7 @@ func handleJiraTicketAnalysis(jiraInput string) {
 						nextVersionText = " (Next Version)"
 					}
 
-					fmt.Printf("    - %s (v%s)%s", branch.BranchName, branch.Version, nextVersionText)
+					fmt.Printf("    - %s (v%s)%s%s", branch.BranchName, branch.Version, nextVersionText, branch.CherryPickNote())
 					if branch.MergedAt != nil {
 						fmt.Printf(" - merged at %s", branch.MergedAt.Format("01-02-2006"))
 					}
@@ -999,9 +2086,12 @@ func handleJiraTicketAnalysis(jiraInput string) {
 								// Add badge for SaaS versions
 								if branch.Pattern == "v" && cfg.GitLabToken != "" {
 									ctx := context.Background()
-									githubClient := github.NewClient(ctx, cfg.GitHubToken)
-									gitlabClient := gitlab.NewClient(ctx, cfg.GitLabToken, githubClient)
-									badge := gitlabClient.GetSaaSVersionBadge(branch.ReleasedVersions[0])
+									githubClient := github.NewClient(ctx, cfg.GitHubToken, github.OptionsFromConfig(cfg))
+									gitlabClient := gitlab.NewClient(ctx, cfg.GitLabToken, githubClient, gitlab.OptionsFromConfig(cfg))
+									badge, err := gitlabClient.GetSaaSVersionBadge(branch.ReleasedVersions[0])
+									if err != nil {
+										logger.Debug("Failed to get SaaS badge for %s: %v", branch.ReleasedVersions[0], err)
+									}
 									releasedVersionsText += badge
 								}
 								fmt.Printf("\n        %s", releasedVersionsText)
@@ -1078,32 +2168,125 @@ func handleJiraTicketAnalysis(jiraInput string) {
 		fmt.Printf("No release branches found across all analyzed PRs\n")
 	}
 
+	if priority.Level() == jira.PriorityLevelP1 && len(unconfirmedPRs) > 0 {
+		sort.Strings(unconfirmedPRs)
+		fmt.Printf("\n%s\n", jira.HighUrgencyMessage)
+		fmt.Printf("PRs not yet in any release branch:\n")
+		for _, prURL := range unconfirmedPRs {
+			fmt.Printf("  • %s\n", prURL)
+		}
+	}
+
+	// A single comment per run, posted only once every PR has been analyzed
+	if postComment {
+		postJiraAnalysisComment(ctx, jiraClient, dryRun, ticketID, allResults, mergeRequests)
+	}
+
 	fmt.Printf("\nJIRA ticket analysis completed at: %s\n", time.Now().Format("01-02-2006 15:04:05"))
 }
 
+// printMergeRequests lists the GitLab MRs linked from the JIRA tickets. Branch
+// presence is only checked for GitHub PRs, so they are listed without analysis.
+func printMergeRequests(mergeRequests []models.MRInfo) {
+	fmt.Printf("\n=== GitLab Merge Requests (not analyzed) ===\n")
+	for _, mr := range mergeRequests {
+		fmt.Printf("• %s!%d: %s\n", mr.Project, mr.IID, mr.URL)
+	}
+}
+
+// postJiraAnalysisComment posts the analysis summary as a comment on ticketID.
+func postJiraAnalysisComment(ctx context.Context, jiraClient *jira.Client, dryRun bool, ticketID string, results []*models.PRAnalysisResult, mergeRequests []models.MRInfo) {
+	if err := jiraClient.PostComment(ctx, dryRun, ticketID, jira.FormatAnalysisComment(results, mergeRequests)); err != nil {
+		fmt.Printf("\n❌ Failed to post analysis comment to %s: %v\n", ticketID, err)
+	} else if !dryRun {
+		fmt.Printf("\n💬 Posted analysis summary as a comment on %s\n", ticketID)
+	}
+}
+
+// suggestedMergeOrder returns the supported PR URLs ordered by the tickets' "blocks" links,
+// or nil when no ticket blocks another.
+func suggestedMergeOrder(issues []jira.JiraIssue, prURLsByTicket map[string][]string, supported map[string]bool) []string {
+	filtered := make(map[string][]string, len(prURLsByTicket))
+	for key, prURLs := range prURLsByTicket {
+		for _, prURL := range prURLs {
+			if supported[prURL] {
+				filtered[key] = append(filtered[key], prURL)
+			}
+		}
+	}
+
+	mergeOrder, err := jira.SuggestedMergeOrder(issues, filtered)
+	if err != nil {
+		fmt.Printf("Warning: cannot suggest a merge order: %v\n", err)
+		return nil
+	}
+	return mergeOrder
+}
+
 func extractJiraTicketID(input string) string {
 	return jira.ExtractJiraTicketFromText(input)
 }
 
 // startSlackServer starts the Slack bot server
-func startSlackServer(port int) {
+func startSlackServer(port int, tlsCert, tlsKey string) {
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
 		log.Fatalf("Failed to load configuration: %v", err)
 	}
+	applyTLSFlags(cfg, tlsCert, tlsKey)
 
 	rm := createRepoManager(cfg)
-	fmt.Printf("📦 Pre-cloning supported repositories...\n")
-	rm.CloneAllSupported(cfg.GitHubToken)
 
 	slackServer, err := server.NewSlackServer(cfg, rm)
 	if err != nil {
 		log.Fatalf("Failed to create Slack server: %v", err)
 	}
 
+	fmt.Printf("📦 Pre-fetching supported repositories...\n")
+	if err := slackServer.WarmUp(); err != nil {
+		fmt.Printf("Warning: failed to pre-fetch some repositories: %v\n", err)
+	}
+
 	fmt.Printf("🤖 Starting Slack bot server on port %d...\n", port)
 	if err := slackServer.Start(port); err != nil {
 		log.Fatalf("Failed to start Slack server: %v", err)
 	}
 }
+
+// startAPIServer starts the REST API server
+func startAPIServer(port int, tlsCert, tlsKey string) {
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+	applyTLSFlags(cfg, tlsCert, tlsKey)
+
+	rm := createRepoManager(cfg)
+	fmt.Printf("📦 Pre-cloning supported repositories...\n")
+	rm.CloneAllSupported(cfg.GitHubToken)
+
+	apiServer, err := server.NewAPIServer(cfg, rm)
+	if err != nil {
+		log.Fatalf("Failed to create API server: %v", err)
+	}
+
+	if err := apiServer.Start(port); err != nil {
+		log.Fatalf("Failed to start API server: %v", err)
+	}
+}
+
+// applyTLSFlags overrides the configured TLS certificate and key with the -tls-cert
+// and -tls-key flags, and exits unless both or neither end up set, so a half-configured
+// server never falls back to plain HTTP.
+func applyTLSFlags(cfg *models.Config, tlsCert, tlsKey string) {
+	if tlsCert != "" {
+		cfg.TLSCertFile = tlsCert
+	}
+	if tlsKey != "" {
+		cfg.TLSKeyFile = tlsKey
+	}
+	if err := server.ValidateTLSConfig(cfg); err != nil {
+		log.Fatalf("Invalid TLS configuration: %v", err)
+	}
+}
@@ -3,6 +3,7 @@ package analyzer
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -14,11 +15,16 @@ import (
 
 	"github.com/shay23bra/pr-bot/internal/ga"
 	"github.com/shay23bra/pr-bot/internal/github"
-	"github.com/shay23bra/pr-bot/internal/gitlocal"
 	"github.com/shay23bra/pr-bot/internal/gitlab"
+	"github.com/shay23bra/pr-bot/internal/gitlocal"
 	"github.com/shay23bra/pr-bot/internal/jira"
 	"github.com/shay23bra/pr-bot/internal/logger"
 	"github.com/shay23bra/pr-bot/internal/models"
+	"github.com/shay23bra/pr-bot/internal/resultcache"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // Constants for the analyzer package.
@@ -36,55 +42,296 @@ type Analyzer struct {
 	gitlabClient *gitlab.Client
 	jiraClient   *jira.Client
 
-	branchCache    []github.BranchInfo
-	branchCacheMux sync.RWMutex
+	hooks hookRegistry
+
+	progress ProgressFunc // nil reports no progress
+
+	resultCache     *resultcache.Cache // nil disables result caching
+	readResultCache bool               // false only refreshes the cache
+
+	gaRefreshInterval time.Duration // Scheduled release schedule refreshes; 0 disables them
+
+	tracer trace.Tracer
 }
 
-// New creates a new analyzer instance. Google Sheets is optional — if unavailable,
-// branch analysis still works but GA status will be skipped.
-func New(ctx context.Context, config *models.Config, repoManager *gitlocal.RepoManager) (*Analyzer, error) {
-	githubClient := github.NewClient(ctx, config.GitHubToken)
+// tracerName is the instrumentation scope of the analyzer's spans.
+const tracerName = "github.com/shay23bra/pr-bot/pkg/analyzer"
 
-	var gaParser *ga.Parser
-	if config.GoogleServiceAccountJSON != "" && config.GoogleSheetID != "" {
-		var err error
-		gaParser, err = ga.NewParser(config.GoogleServiceAccountJSON, config.GoogleSheetID)
-		if err != nil {
-			logger.Debug("Google Sheets unavailable (GA status will be skipped): %v", err)
-		} else {
-			logger.Debug("Using Google Sheets for GA data (Sheet ID: %s)", config.GoogleSheetID)
+// ProgressFunc is called as an analysis advances through a stage, e.g. after each
+// release branch check with stage "Checking branches". Calls for one analysis are
+// serialized and done increases by one each time until it reaches total.
+type ProgressFunc func(stage string, done, total int)
+
+// Option customizes an Analyzer created by New.
+type Option func(*Analyzer)
+
+// WithProgressFunc reports analysis progress to fn.
+func WithProgressFunc(fn ProgressFunc) Option {
+	return func(a *Analyzer) {
+		a.progress = fn
+	}
+}
+
+// WithResultCache makes AnalyzePR store its results in cache. When read is true, a
+// cached result that is still fresh is returned instead of analyzing the PR again.
+func WithResultCache(cache *resultcache.Cache, read bool) Option {
+	return func(a *Analyzer) {
+		a.resultCache = cache
+		a.readResultCache = read
+	}
+}
+
+// WithTracerProvider records analysis spans with tp instead of the global tracer
+// provider, which is a no-op unless one was installed (see PR_BOT_OTEL_ENDPOINT).
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(a *Analyzer) {
+		a.tracer = tp.Tracer(tracerName)
+	}
+}
+
+// WithGARefreshInterval re-reads the release schedule from Google Sheets every
+// interval instead of only when it is read after going stale. Call Close to stop it.
+func WithGARefreshInterval(interval time.Duration) Option {
+	return func(a *Analyzer) {
+		a.gaRefreshInterval = interval
+	}
+}
+
+// maxNewBranchesSinceCached is how many release branches may have appeared since a
+// result was cached before it is analyzed again.
+const maxNewBranchesSinceCached = 2
+
+// DefaultBranchCacheTTL is how long listed release branches are reused when
+// PR_BOT_BRANCH_CACHE_TTL is not set.
+const DefaultBranchCacheTTL = 15 * time.Minute
+
+// ComponentConfig identifies the GitHub repository of a component.
+type ComponentConfig struct {
+	Name  string // Component name, e.g. "assisted-service"
+	Owner string
+	Repo  string
+}
+
+// DefaultComponents are the repositories analyzed by JIRA ticket analysis.
+var DefaultComponents = []ComponentConfig{
+	{Name: "assisted-service", Owner: "openshift", Repo: "assisted-service"},
+	{Name: "assisted-installer", Owner: "openshift", Repo: "assisted-installer"},
+	{Name: "assisted-installer-agent", Owner: "openshift", Repo: "assisted-installer-agent"},
+	{Name: "assisted-installer-ui", Owner: "openshift-assisted", Repo: "assisted-installer-ui"},
+}
+
+// preFetchWorkers bounds how many repositories PreFetchAll clones or fetches at once.
+const preFetchWorkers = 3
+
+// New creates a new analyzer instance. Google Sheets is optional — if unavailable,
+// branch analysis still works but GA status will be skipped. Without configured
+// branch patterns, the patterns detected in the configured repository are added to
+// the defaults when its branches follow none of them. Detection runs once per
+// repository and is cached in repoManager.
+func New(ctx context.Context, config *models.Config, repoManager *gitlocal.RepoManager, opts ...Option) (*Analyzer, error) {
+	githubClient := github.NewClient(ctx, config.GitHubToken, github.OptionsFromConfig(config))
+	if len(config.BranchPatterns) == 0 && config.Owner != "" && config.Repository != "" {
+		if detected := withDetectedBranchPatterns(githubClient, repoManager, config); detected != config {
+			config = detected
+			githubClient = github.NewClient(ctx, config.GitHubToken, github.OptionsFromConfig(config))
 		}
 	}
 
 	var gitlabClient *gitlab.Client
 	if config.GitLabToken != "" {
-		gitlabClient = gitlab.NewClient(ctx, config.GitLabToken, githubClient)
+		gitlabClient = gitlab.NewClient(ctx, config.GitLabToken, githubClient, gitlab.OptionsFromConfig(config))
 	}
 
 	var jiraClient *jira.Client
 	if config.JiraToken != "" && config.JiraEmail != "" {
-		jiraClient = jira.NewClient(ctx, config.JiraEmail, config.JiraToken)
+		jiraClient = jira.NewClient(ctx, config.JiraEmail, config.JiraToken, jira.OptionsFromConfig(config))
 	}
 
-	return &Analyzer{
+	a := &Analyzer{
 		githubClient: githubClient,
 		repoManager:  repoManager,
 		config:       config,
-		gaParser:     gaParser,
 		gitlabClient: gitlabClient,
 		jiraClient:   jiraClient,
-	}, nil
+		tracer:       otel.Tracer(tracerName),
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	// Created after the options, which may schedule refreshes
+	if config.GoogleServiceAccountJSON != "" && config.GoogleSheetID != "" {
+		parserOpts := ga.OptionsFromConfig(config)
+		parserOpts.RefreshInterval = a.gaRefreshInterval
+		gaParser, err := ga.NewParser(config.GoogleServiceAccountJSON, config.GoogleSheetID, parserOpts)
+		if err != nil {
+			logger.Debug("Google Sheets unavailable (GA status will be skipped): %v", err)
+		} else {
+			logger.Debug("Using Google Sheets for GA data (Sheet ID: %s)", config.GoogleSheetID)
+			a.gaParser = gaParser
+		}
+	}
+	return a, nil
+}
+
+// Close stops background work of the analyzer, such as the release schedule
+// refreshes scheduled by WithGARefreshInterval.
+func (a *Analyzer) Close() {
+	if a.gaParser != nil {
+		a.gaParser.Close()
+	}
+}
+
+// withDetectedBranchPatterns returns a copy of config whose branch patterns are
+// models.DefaultBranchPatterns plus the patterns detected in the configured
+// repository that the defaults lack. config itself is returned when detection fails
+// or finds nothing new.
+func withDetectedBranchPatterns(githubClient *github.Client, repoManager *gitlocal.RepoManager, config *models.Config) *models.Config {
+	detect := func() ([]string, error) {
+		return githubClient.DetectCustomBranchPatterns(config.Owner, config.Repository)
+	}
+
+	var prefixes []string
+	var err error
+	if repoManager != nil {
+		prefixes, err = repoManager.DetectedBranchPatterns(config.Owner, config.Repository, detect)
+	} else {
+		prefixes, err = detect()
+	}
+	if err != nil {
+		logger.Debug("Failed to detect branch patterns in %s/%s: %v", config.Owner, config.Repository, err)
+		return config
+	}
+	logger.Debug("Detected branch patterns in %s/%s: %q", config.Owner, config.Repository, prefixes)
+
+	patterns := append([]models.BranchPattern(nil), models.DefaultBranchPatterns...)
+	for _, prefix := range prefixes {
+		known := false
+		for _, pattern := range patterns {
+			if pattern.Prefix == prefix {
+				known = true
+				break
+			}
+		}
+		if !known {
+			patterns = append(patterns, github.DetectedBranchPattern(prefix))
+		}
+	}
+	if len(patterns) == len(models.DefaultBranchPatterns) {
+		return config
+	}
+	logger.Info("Using branch patterns detected in %s/%s: %q", config.Owner, config.Repository, prefixes)
+
+	detected := *config
+	detected.BranchPatterns = patterns
+	return &detected
 }
 
-// AnalyzePR performs complete analysis of a pull request.
+// AnalyzePR performs complete analysis of a pull request, using the result cache if one is set.
 func (a *Analyzer) AnalyzePR(prNumber int) (*models.PRAnalysisResult, error) {
-	return a.AnalyzePRWithOptions(prNumber, false)
+	ctx, span := a.startAnalysisSpan(prNumber)
+	defer span.End()
+
+	if cached := a.cachedResult(ctx, prNumber); cached != nil {
+		span.SetAttributes(attribute.Bool("pr.cached", true))
+		a.runHooks(func(h PluginHook) { h.BeforeAnalysis(ctx, prNumber) })
+		a.runHooks(func(h PluginHook) { h.AfterAnalysis(ctx, cached) })
+		return cached, nil
+	}
+
+	result, err := a.analyzePR(ctx, prNumber, false)
+	if err != nil {
+		recordSpanError(span, err)
+		return nil, err
+	}
+
+	if a.resultCache != nil {
+		if err := a.resultCache.Put(a.config.Owner, a.config.Repository, prNumber, result); err != nil {
+			logger.Debug("Failed to cache result of PR #%d: %v", prNumber, err)
+		}
+	}
+	return result, nil
+}
+
+// cachedResult returns the cached result of a PR, or nil if caching is off, there is
+// no fresh result, or more than maxNewBranchesSinceCached release branches were added
+// since it was cached. Only the local git repository is consulted, not the GitHub API.
+func (a *Analyzer) cachedResult(ctx context.Context, prNumber int) *models.PRAnalysisResult {
+	if a.resultCache == nil || !a.readResultCache {
+		return nil
+	}
+
+	cached, err := a.resultCache.Get(a.config.Owner, a.config.Repository, prNumber)
+	if err != nil {
+		logger.Debug("Ignoring result cache for PR #%d: %v", prNumber, err)
+		return nil
+	}
+	if cached == nil {
+		return nil
+	}
+
+	repo, err := a.repoManager.EnsureRepo(a.config.Owner, a.config.Repository, a.config.GitHubToken)
+	if err != nil {
+		logger.Debug("Ignoring result cache for PR #%d: %v", prNumber, err)
+		return nil
+	}
+	branchInfos, err := a.getBranches(ctx, repo)
+	if err != nil {
+		logger.Debug("Ignoring result cache for PR #%d: %v", prNumber, err)
+		return nil
+	}
+
+	known := make(map[string]bool, len(cached.ReleaseBranches))
+	for _, branch := range cached.ReleaseBranches {
+		known[branch.BranchName] = true
+	}
+	newBranches := 0
+	for _, branch := range a.filterRelevantBranches(branchInfos, cached.PR.MergedAt) {
+		if !known[branch.Name] {
+			newBranches++
+		}
+	}
+	if newBranches > maxNewBranchesSinceCached {
+		logger.Debug("%d release branches were added since PR #%d was cached, analyzing again", newBranches, prNumber)
+		return nil
+	}
+
+	logger.Debug("Using cached result of PR #%d from %s", prNumber, cached.AnalyzedAt.Format("01-02-2006 15:04:05"))
+	return cached
 }
 
 // AnalyzePRWithOptions performs complete analysis of a pull request with optional settings.
 func (a *Analyzer) AnalyzePRWithOptions(prNumber int, skipJiraAnalysis bool) (*models.PRAnalysisResult, error) {
+	ctx, span := a.startAnalysisSpan(prNumber)
+	defer span.End()
+
+	result, err := a.analyzePR(ctx, prNumber, skipJiraAnalysis)
+	if err != nil {
+		recordSpanError(span, err)
+	}
+	return result, err
+}
+
+// startAnalysisSpan starts the root span of a PR analysis.
+func (a *Analyzer) startAnalysisSpan(prNumber int) (context.Context, trace.Span) {
+	return a.tracer.Start(context.Background(), "AnalyzePR", trace.WithAttributes(
+		attribute.String("pr.repository", a.config.Owner+"/"+a.config.Repository),
+		attribute.Int("pr.number", prNumber),
+	))
+}
+
+// recordSpanError marks span as failed with err.
+func recordSpanError(span trace.Span, err error) {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// analyzePR analyzes a pull request within the span in ctx.
+func (a *Analyzer) analyzePR(ctx context.Context, prNumber int, skipJiraAnalysis bool) (*models.PRAnalysisResult, error) {
 	logger.Debug("Starting analysis of PR #%d (skipJiraAnalysis: %v)", prNumber, skipJiraAnalysis)
 
+	a.runHooks(func(h PluginHook) { h.BeforeAnalysis(ctx, prNumber) })
+
 	// Get PR information
 	prInfo, err := a.githubClient.GetPRInfo(a.config.Owner, a.config.Repository, prNumber)
 	if err != nil {
@@ -100,7 +347,7 @@ func (a *Analyzer) AnalyzePRWithOptions(prNumber int, skipJiraAnalysis bool) (*m
 		return nil, fmt.Errorf("failed to ensure local repo: %w", err)
 	}
 
-	branchInfos, err := a.getBranches(repo)
+	branchInfos, err := a.getBranches(ctx, repo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get release branches: %w", err)
 	}
@@ -121,17 +368,36 @@ func (a *Analyzer) AnalyzePRWithOptions(prNumber int, skipJiraAnalysis bool) (*m
 	filteredBranches := a.filterRelevantBranches(branchInfos, prInfo.MergedAt)
 	logger.Debug("After filtering: %d relevant branches (saved %d API calls)", len(filteredBranches), len(branchInfos)-len(filteredBranches))
 
+	// Branches without the merge commit are searched for a cherry-pick of it
+	commitMessage, err := repo.GetCommitMessage(prInfo.Hash)
+	if err != nil {
+		logger.Debug("Warning: failed to read message of %s, only cherry-pick trailers will be matched: %v", prInfo.Hash, err)
+	}
+
 	// Check PR presence in each relevant release branch using goroutines for parallel processing
 	branchPresences := make([]models.BranchPresence, len(filteredBranches))
 	var sheetsUnavailable atomic.Bool
 
 	// Use a channel to control concurrency (limit to avoid overwhelming GitHub API)
-	concurrencyLimit := 10
+	concurrencyLimit := a.config.Concurrency.WithDefaults().BranchCheckWorkers
 	semaphore := make(chan struct{}, concurrencyLimit)
 
 	// WaitGroup to wait for all goroutines
 	var wg sync.WaitGroup
 
+	// Report progress after each branch check, serialized so done only increases
+	var progressMu sync.Mutex
+	checked := 0
+	branchChecked := func() {
+		if a.progress == nil {
+			return
+		}
+		progressMu.Lock()
+		defer progressMu.Unlock()
+		checked++
+		a.progress("Checking branches", checked, len(filteredBranches))
+	}
+
 	for i, branchInfo := range filteredBranches {
 		wg.Add(1)
 		go func(index int, branch github.BranchInfo) {
@@ -140,6 +406,24 @@ func (a *Analyzer) AnalyzePRWithOptions(prNumber int, skipJiraAnalysis bool) (*m
 			// Acquire semaphore
 			semaphore <- struct{}{}
 			defer func() { <-semaphore }()
+			defer branchChecked()
+
+			branchCtx, branchSpan := a.tracer.Start(ctx, "CheckBranch", trace.WithAttributes(
+				attribute.String("branch.name", branch.Name),
+				attribute.String("branch.pattern", branch.Pattern),
+			))
+			defer branchSpan.End()
+
+			if prInfo.SkipsBranchVersion(branch.Version) {
+				logger.Debug("Skipping branch %s: hotfix PR skips version %s", branch.Name, branch.Version)
+				branchPresences[index] = models.BranchPresence{
+					BranchName: branch.Name,
+					Pattern:    branch.Pattern,
+					Version:    branch.Version,
+					Skipped:    true,
+				}
+				return
+			}
 
 			logger.Debug("Checking branch: %s (%s)", branch.Name, branch.Pattern)
 
@@ -148,9 +432,21 @@ func (a *Analyzer) AnalyzePRWithOptions(prNumber int, skipJiraAnalysis bool) (*m
 				logger.Debug("Warning: failed to check commit in branch %s: %v", branch.Name, err)
 			}
 
+			// commitSHA is the commit that brought the PR into the branch
+			commitSHA := prInfo.Hash
 			var mergedAt *time.Time
+			var foundViaCherryPick bool
 			if found {
 				mergedAt, _ = repo.GetCommitDate(prInfo.Hash)
+			} else {
+				cherryPickSHA, cherryPickDate, cpErr := repo.FindCherryPick(prInfo.Hash, commitMessage, branch.Name, prInfo.MergedAt)
+				if cpErr != nil {
+					logger.Debug("Warning: failed to look for a cherry-pick in branch %s: %v", branch.Name, cpErr)
+				} else if cherryPickSHA != "" {
+					logger.Debug("Found cherry-pick %s of %s in %s", cherryPickSHA, prInfo.Hash, branch.Name)
+					found, foundViaCherryPick = true, true
+					commitSHA, mergedAt = cherryPickSHA, cherryPickDate
+				}
 			}
 
 			gaStatus := models.GAStatus{}
@@ -182,7 +478,7 @@ func (a *Analyzer) AnalyzePRWithOptions(prNumber int, skipJiraAnalysis bool) (*m
 				// For Version-prefixed branches (v*) and UI release branches (releases/v*), find the exact release versions
 				if branch.Pattern == "v" || (branch.Pattern == "releases/v" && a.config.Repository != "assisted-installer-ui") {
 					logger.Debug("Finding exact release versions for %s (%s)", branch.Name, branch.Version)
-					foundTags, tagErr := repo.FindCommitInVersionTags(prInfo.Hash, branch.Name)
+					foundTags, tagErr := repo.FindCommitInVersionTags(commitSHA, branch.Name)
 					if tagErr != nil {
 						logger.Debug("Warning: failed to find release versions for %s: %v", branch.Name, tagErr)
 					} else {
@@ -210,23 +506,25 @@ func (a *Analyzer) AnalyzePRWithOptions(prNumber int, skipJiraAnalysis bool) (*m
 
 					// Only perform validation if not all GAs are in the future
 					if !allGAsInFuture {
-						upcomingGAs = a.performMCEValidation(upcomingGAs, prInfo.Hash)
+						upcomingGAs = a.performMCEValidation(branchCtx, upcomingGAs, commitSHA)
 					}
 				}
 			}
 
 			presence := models.BranchPresence{
-				BranchName:       branch.Name,
-				Pattern:          branch.Pattern,
-				Version:          branch.Version,
-				MergedAt:         mergedAt,
-				Found:            found,
-				ReleasedVersions: releasedVersions,
-				GAStatus:         gaStatus,
-				UpcomingGAs:      upcomingGAs,
+				BranchName:         branch.Name,
+				Pattern:            branch.Pattern,
+				Version:            branch.Version,
+				MergedAt:           mergedAt,
+				Found:              found,
+				FoundViaCherryPick: foundViaCherryPick,
+				ReleasedVersions:   releasedVersions,
+				GAStatus:           gaStatus,
+				UpcomingGAs:        upcomingGAs,
 			}
 
 			branchPresences[index] = presence
+			branchSpan.SetAttributes(attribute.Bool("branch.found", found), attribute.Bool("branch.cherry_pick", foundViaCherryPick))
 
 			if found {
 				logger.Debug("✓ Found in %s (%s, version %s)", branch.Name, branch.Pattern, branch.Version)
@@ -251,60 +549,120 @@ func (a *Analyzer) AnalyzePRWithOptions(prNumber int, skipJiraAnalysis bool) (*m
 
 	// Perform JIRA analysis if JIRA client is available and PR title contains any JIRA ticket
 	if a.jiraClient != nil && !skipJiraAnalysis {
-		// Look for any JIRA ticket (ACM, MGMT, OCPBUGS, etc.) in PR title
-		jiraTicket := jira.ExtractJiraTicketFromText(prInfo.Title)
+		// Look for a JIRA ticket of the configured projects (PR_BOT_JIRA_PROJECTS) in PR title
+		jiraTicket := a.jiraClient.ExtractTicketFromTitle(prInfo.Title)
 		if jiraTicket != "" {
 			logger.Debug("Found JIRA ticket in PR title: %s", jiraTicket)
-			jiraAnalysis, relatedPRs := a.performJiraAnalysis(jiraTicket, prInfo)
+			jiraAnalysis, relatedPRs := a.performJiraAnalysis(ctx, jiraTicket, prInfo)
 			result.JiraAnalysis = jiraAnalysis
 			result.RelatedPRs = relatedPRs
 		}
 	}
 
+	a.runHooks(func(h PluginHook) { h.AfterAnalysis(ctx, result) })
+
 	return result, nil
 }
 
-// getBranches returns branch information from local git repo
-func (a *Analyzer) getBranches(repo *gitlocal.Repo) ([]github.BranchInfo, error) {
-	a.branchCacheMux.RLock()
-	if len(a.branchCache) > 0 {
-		cached := make([]github.BranchInfo, len(a.branchCache))
-		copy(cached, a.branchCache)
-		a.branchCacheMux.RUnlock()
-		logger.Debug("Using cached branch information (%d branches)", len(cached))
-		return cached, nil
-	}
-	a.branchCacheMux.RUnlock()
+// getBranches returns branch information for the configured repository from its
+// local git repo. Listed branches are cached in the repo, which is shared by all
+// analyzers created with the same RepoManager.
+func (a *Analyzer) getBranches(ctx context.Context, repo *gitlocal.Repo) ([]github.BranchInfo, error) {
+	key := a.config.Owner + "/" + a.config.Repository
 
-	a.branchCacheMux.Lock()
-	defer a.branchCacheMux.Unlock()
+	_, span := a.tracer.Start(ctx, "getBranches", trace.WithAttributes(attribute.String("repository", key)))
+	defer span.End()
 
-	if len(a.branchCache) > 0 {
-		cached := make([]github.BranchInfo, len(a.branchCache))
-		copy(cached, a.branchCache)
-		return cached, nil
+	ttl := a.config.BranchCacheTTL
+	if ttl <= 0 {
+		ttl = DefaultBranchCacheTTL
 	}
 
-	logger.Debug("Listing branches from local git repo")
-	branchInfos, err := repo.ListBranches()
+	branchInfos, cached, err := repo.CachedBranches(a.config.ReleaseBranchPatterns(), ttl)
 	if err != nil {
+		recordSpanError(span, err)
 		return nil, fmt.Errorf("failed to list branches: %w", err)
 	}
+	span.SetAttributes(attribute.Bool("branches.cached", cached), attribute.Int("branches.count", len(branchInfos)))
 
-	a.branchCache = make([]github.BranchInfo, len(branchInfos))
-	copy(a.branchCache, branchInfos)
-
-	logger.Debug("Found %d release branches (local)", len(branchInfos))
+	if cached {
+		logger.Debug("Using cached branch information (%d branches)", len(branchInfos))
+	} else {
+		logger.Debug("Found %d release branches (local)", len(branchInfos))
+	}
 	return branchInfos, nil
 }
 
+// FlushBranchCache drops all cached release branches, so the next analysis of
+// each repository lists its branches again.
+func (a *Analyzer) FlushBranchCache() {
+	a.repoManager.FlushBranchCache()
+}
+
+// PreFetchBranches clones or fetches a repository and caches its release branches
+// in the RepoManager, where every analyzer created with it finds them.
+func (a *Analyzer) PreFetchBranches(owner, repo string) error {
+	start := time.Now()
+
+	localRepo, err := a.repoManager.EnsureRepo(owner, repo, a.config.GitHubToken)
+	if err != nil {
+		return fmt.Errorf("failed to get local repo %s/%s: %w", owner, repo, err)
+	}
+
+	branchInfos, err := localRepo.RefreshBranches(a.config.ReleaseBranchPatterns())
+	if err != nil {
+		return fmt.Errorf("failed to list branches for %s/%s: %w", owner, repo, err)
+	}
+
+	logger.Info("Pre-fetched %d release branches for %s/%s in %s", len(branchInfos), owner, repo, time.Since(start).Round(time.Millisecond))
+	return nil
+}
+
+// PreFetchAll runs PreFetchBranches for each component concurrently and returns
+// the joined errors of the repositories that failed.
+func (a *Analyzer) PreFetchAll(repos []ComponentConfig) error {
+	sem := make(chan struct{}, preFetchWorkers)
+	errs := make([]error, len(repos))
+	var wg sync.WaitGroup
+
+	for i, component := range repos {
+		wg.Add(1)
+		go func(i int, component ComponentConfig) {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			errs[i] = a.PreFetchBranches(component.Owner, component.Repo)
+		}(i, component)
+	}
+	wg.Wait()
+
+	return errors.Join(errs...)
+}
+
+// WarmUp pre-fetches the release branches of all DefaultComponents so the first
+// analyses do not wait for clones and branch listings.
+func (a *Analyzer) WarmUp() error {
+	return a.PreFetchAll(DefaultComponents)
+}
+
 // performJiraAnalysis analyzes JIRA tickets and finds related PRs.
-func (a *Analyzer) performJiraAnalysis(mainTicket string, originalPR *models.PRInfo) (*models.JiraAnalysis, []models.RelatedPR) {
+func (a *Analyzer) performJiraAnalysis(ctx context.Context, mainTicket string, originalPR *models.PRInfo) (*models.JiraAnalysis, []models.RelatedPR) {
 	logger.Debug("Starting JIRA analysis for ticket: %s", mainTicket)
 
+	ctx, span := a.tracer.Start(ctx, "performJiraAnalysis", trace.WithAttributes(attribute.String("jira.ticket", mainTicket)))
+	defer span.End()
+
 	// Get all cloned issues related to the main ticket
+	_, traverseSpan := a.tracer.Start(ctx, "GetAllClonedIssues", trace.WithAttributes(attribute.String("jira.ticket", mainTicket)))
 	allIssues, err := a.jiraClient.GetAllClonedIssues(mainTicket)
 	if err != nil {
+		recordSpanError(traverseSpan, err)
+	} else {
+		traverseSpan.SetAttributes(attribute.Int("jira.issues", len(allIssues)))
+	}
+	traverseSpan.End()
+	if err != nil {
+		recordSpanError(span, err)
 		logger.Debug("Failed to get cloned issues for %s: %v", mainTicket, err)
 		return &models.JiraAnalysis{
 			MainTicket:      mainTicket,
@@ -361,7 +719,7 @@ func (a *Analyzer) performJiraAnalysis(mainTicket string, originalPR *models.PRI
 				continue
 			}
 
-			branchInfos, err := a.getBranches(relatedRepo)
+			branchInfos, err := a.getBranches(ctx, relatedRepo)
 			if err != nil {
 				logger.Debug("Failed to get release branches for related PR #%d: %v", prNumber, err)
 				continue
@@ -466,6 +824,9 @@ func (a *Analyzer) performJiraAnalysis(mainTicket string, originalPR *models.PRI
 		RelatedPRURLs:   uniquePRURLs,
 		AnalysisSuccess: true,
 	}
+	if len(allIssues) > 0 {
+		jiraAnalysis.FixVersions = allIssues[0].Fields.FixVersions
+	}
 
 	return jiraAnalysis, uniqueRelatedPRs
 }
@@ -496,15 +857,25 @@ func (a *Analyzer) PrintSummary(result *models.PRAnalysisResult) {
 	fmt.Printf("Hash: %s\n", result.PR.Hash)
 	fmt.Printf("Merged to '%s' at: %s\n", result.PR.MergedInto, models.FormatDate(result.PR.MergedAt))
 	fmt.Printf("URL: %s\n", result.PR.URL)
+	if len(result.PR.CoAuthors) > 0 {
+		fmt.Printf("Co-authored by: %s\n", strings.Join(result.PR.CoAuthors, ", "))
+	}
+	if len(result.PR.Labels) > 0 {
+		fmt.Printf("Labels: %s\n", strings.Join(result.PR.Labels, ", "))
+	}
+
+	summary := result.Summary()
 
 	// Add JIRA analysis to the summary if available
 	if result.JiraAnalysis != nil && result.JiraAnalysis.AnalysisSuccess {
-		// Count backport PRs (exclude the original PR)
-		backportCount := 0
-		for _, relatedPR := range result.RelatedPRs {
-			if relatedPR.Number != result.PR.Number {
-				backportCount++
-			}
+		backportCount := summary.RelatedMergedPRs
+
+		if backportCount > 0 || len(result.JiraAnalysis.FixVersions) > 0 {
+			fmt.Printf("\n📋 JIRA Ticket: %s\n", result.JiraAnalysis.MainTicket)
+		}
+		// Where JIRA says the fix should land, to compare with the branches below
+		if len(result.JiraAnalysis.FixVersions) > 0 {
+			fmt.Printf("🎯 Fix versions: %s\n", strings.Join(result.JiraAnalysis.FixVersions, ", "))
 		}
 
 		if backportCount > 0 {
@@ -512,7 +883,6 @@ func (a *Analyzer) PrintSummary(result *models.PRAnalysisResult) {
 			if backportCount == 1 {
 				pluralS = ""
 			}
-			fmt.Printf("\n📋 JIRA Ticket: %s\n", result.JiraAnalysis.MainTicket)
 			fmt.Printf("🔗 Found %d related backport PR%s:\n", backportCount, pluralS)
 
 			for _, relatedPR := range result.RelatedPRs {
@@ -528,47 +898,11 @@ func (a *Analyzer) PrintSummary(result *models.PRAnalysisResult) {
 	fmt.Printf("\n=== Release Branch Analysis ===\n")
 
 	// Collect all branches from original PR and related PRs
-	allBranchesMap := make(map[string]models.BranchPresence)
-
-	// Add original PR branches
-	for _, branch := range result.ReleaseBranches {
-		if branch.Found {
-			allBranchesMap[branch.BranchName] = branch
-		}
-	}
-
-	// Add related PR branches
-	if result.JiraAnalysis != nil && result.JiraAnalysis.AnalysisSuccess {
-		for _, relatedPR := range result.RelatedPRs {
-			if relatedPR.Number != result.PR.Number {
-				for _, branch := range relatedPR.ReleaseBranches {
-					if branch.Found {
-						// If we already have this branch from original PR, prefer the related PR data
-						// if the original PR is not actually found in that branch
-						if existing, exists := allBranchesMap[branch.BranchName]; exists {
-							// If original PR is not found in this branch, use related PR data instead
-							if !existing.Found {
-								allBranchesMap[branch.BranchName] = branch
-							}
-						} else {
-							// Branch not in map yet, add it
-							allBranchesMap[branch.BranchName] = branch
-						}
-					}
-				}
-			}
-		}
-	}
-
-	// Convert map back to slice for consistent ordering
-	var allFoundBranches []models.BranchPresence
-	for _, branch := range allBranchesMap {
-		allFoundBranches = append(allFoundBranches, branch)
-	}
+	allFoundBranches := result.AllFoundBranches(true)
 
 	// Group branches by pattern for better organization
 	patternGroups := make(map[string][]models.BranchPresence)
-	patternOrder := []string{"release-ocm-", "releases/v", "release-", "release-v", "v"}
+	patternOrder := a.config.BranchPatternOrder()
 
 	for _, branch := range allFoundBranches {
 		patternGroups[branch.Pattern] = append(patternGroups[branch.Pattern], branch)
@@ -578,32 +912,29 @@ func (a *Analyzer) PrintSummary(result *models.PRAnalysisResult) {
 	for pattern := range patternGroups {
 		branches := patternGroups[pattern]
 		sort.Slice(branches, func(i, j int) bool {
-			// Parse version numbers for proper sorting (e.g., "2.13" < "2.14" < "2.15")
-			versionI := models.ParseVersionNumber(branches[i].Version)
-			versionJ := models.ParseVersionNumber(branches[j].Version)
-			return versionI < versionJ
+			// Compare version components numerically (e.g., "2.9" < "2.10" < "2.11")
+			return models.CompareBranchVersions(branches[i].Version, branches[j].Version) < 0
 		})
 		patternGroups[pattern] = branches
 	}
 
 	if len(allFoundBranches) > 0 {
-		fmt.Printf("\n✓ Found in %d release branches:\n", len(allFoundBranches))
+		fmt.Printf("\n✓ Found in %d release branches:\n", summary.FoundBranches)
 
 		// Display found branches grouped by pattern
 		for _, pattern := range patternOrder {
 			branches := patternGroups[pattern]
 			if len(branches) > 0 {
-				fmt.Printf("\n  %s branches (%d):\n", models.PatternDescription(pattern), len(branches))
+				fmt.Printf("\n  %s branches (%d):\n", a.config.PatternDescription(pattern), len(branches))
 				for _, branch := range branches {
-					isNextVersion := strings.Contains(branch.Version, "Next Version") ||
-						(branch.GAStatus.ACM.Status == "Next Version" || branch.GAStatus.MCE.Status == "Next Version")
+					isNextVersion := branch.IsNextVersion()
 
 					nextVersionText := ""
 					if isNextVersion {
 						nextVersionText = " (Next Version)"
 					}
 
-					fmt.Printf("    - %s (v%s)%s", branch.BranchName, branch.Version, nextVersionText)
+					fmt.Printf("    - %s (v%s)%s%s", branch.BranchName, branch.Version, nextVersionText, branch.CherryPickNote())
 					if branch.MergedAt != nil {
 						fmt.Printf(" - merged at %s", branch.MergedAt.Format("01-02-2006"))
 					}
@@ -627,7 +958,10 @@ func (a *Analyzer) PrintSummary(result *models.PRAnalysisResult) {
 								releasedVersionsText := strings.Join(branch.ReleasedVersions, ", ")
 								// Add badge for SaaS versions
 								if branch.Pattern == "v" && a.gitlabClient != nil {
-									badge := a.gitlabClient.GetSaaSVersionBadge(branch.ReleasedVersions[0])
+									badge, err := a.gitlabClient.GetSaaSVersionBadge(branch.ReleasedVersions[0])
+									if err != nil {
+										logger.Debug("Failed to get SaaS badge for %s: %v", branch.ReleasedVersions[0], err)
+									}
 									releasedVersionsText += badge
 								}
 								fmt.Printf("\n        %s", releasedVersionsText)
@@ -642,38 +976,23 @@ func (a *Analyzer) PrintSummary(result *models.PRAnalysisResult) {
 								// For each product (ACM/MCE), show either:
 								// 1. The first released version (if any), OR
 								// 2. "Not released yet" for the earliest unreleased version (if no released versions)
-								productStatus := make(map[string]bool) // track if we found released version for each product
-
-								// First pass: find released versions
-								for _, upcomingGA := range branch.UpcomingGAs {
-									if upcomingGA.GADate != nil && upcomingGA.GADate.Before(now) {
-										// This is a released version
-										if !productStatus[upcomingGA.Product] {
-											productStatus[upcomingGA.Product] = true
-											fmt.Printf("\n        %s %s: Released (GA: %s)", upcomingGA.Product, upcomingGA.Version,
-												models.FormatDate(upcomingGA.GADate))
-
-											// Show the SHA from MCE validation if available
-											if upcomingGA.MCEValidation != nil && upcomingGA.MCEValidation.AssistedServiceSHA != "" {
-												componentName := upcomingGA.MCEValidation.ComponentName
-												if componentName == "" {
-													componentName = "assisted-service" // fallback for backward compatibility
-												}
-												fmt.Printf(" (%s latest commit SHA: %s)", componentName, upcomingGA.MCEValidation.AssistedServiceSHA[:8])
-											}
+								released, pending := branch.ReleaseStatus(now)
+								for _, upcomingGA := range released {
+									fmt.Printf("\n        %s %s: Released (GA: %s)", upcomingGA.Product, upcomingGA.Version,
+										models.FormatDate(upcomingGA.GADate))
+
+									// Show the SHA from MCE validation if available
+									if upcomingGA.MCEValidation != nil && upcomingGA.MCEValidation.AssistedServiceSHA != "" {
+										componentName := upcomingGA.MCEValidation.ComponentName
+										if componentName == "" {
+											componentName = "assisted-service" // fallback for backward compatibility
 										}
+										fmt.Printf(" (%s latest commit SHA: %s)", componentName, upcomingGA.MCEValidation.AssistedServiceSHA[:8])
 									}
 								}
-
-								// Second pass: for products without released versions, show "Not released yet"
-								productNotReleased := make(map[string]bool)
-								for _, upcomingGA := range branch.UpcomingGAs {
-									if !productStatus[upcomingGA.Product] && !productNotReleased[upcomingGA.Product] {
-										// No released version found for this product, show first unreleased
-										productNotReleased[upcomingGA.Product] = true
-										fmt.Printf("\n        %s %s: Not released yet (GA: %s)", upcomingGA.Product, upcomingGA.Version,
-											models.FormatDate(upcomingGA.GADate))
-									}
+								for _, upcomingGA := range pending {
+									fmt.Printf("\n        %s %s: Not released yet (GA: %s)", upcomingGA.Product, upcomingGA.Version,
+										models.FormatDate(upcomingGA.GADate))
 								}
 							}
 							fmt.Printf("\n")
@@ -702,6 +1021,12 @@ func (a *Analyzer) PrintSummary(result *models.PRAnalysisResult) {
 		}
 	}
 
+	for _, branch := range result.ReleaseBranches {
+		if branch.Skipped {
+			fmt.Printf("  ⏭️  %s %s\n", branch.BranchName, branch.SkipNote())
+		}
+	}
+
 	// Temporarily commented out - not showing branches where PR is not found
 	/*
 		if len(notFoundBranches) > 0 {
@@ -711,7 +1036,7 @@ func (a *Analyzer) PrintSummary(result *models.PRAnalysisResult) {
 			for _, pattern := range patternOrder {
 				branches := patternNotFound[pattern]
 				if len(branches) > 0 {
-					fmt.Printf("\n  %s branches (%d):\n", models.PatternDescription(pattern), len(branches))
+					fmt.Printf("\n  %s branches (%d):\n", a.config.PatternDescription(pattern), len(branches))
 					for _, branch := range branches {
 						fmt.Printf("    - %s (v%s)\n", branch.BranchName, branch.Version)
 					}
@@ -727,13 +1052,15 @@ func (a *Analyzer) PrintSummary(result *models.PRAnalysisResult) {
 	fmt.Printf("\nAnalysis completed at: %s\n", result.AnalyzedAt.Format("01-02-2006 15:04:05"))
 }
 
-
 // performMCEValidation performs MCE snapshot validation for released GAs only.
-func (a *Analyzer) performMCEValidation(upcomingGAs []models.UpcomingGA, prCommitSHA string) []models.UpcomingGA {
+func (a *Analyzer) performMCEValidation(ctx context.Context, upcomingGAs []models.UpcomingGA, prCommitSHA string) []models.UpcomingGA {
 	if len(upcomingGAs) == 0 {
 		return upcomingGAs
 	}
 
+	ctx, span := a.tracer.Start(ctx, "performMCEValidation")
+	defer span.End()
+
 	now := time.Now()
 
 	// Count how many GAs are already released (can be validated)
@@ -750,14 +1077,18 @@ func (a *Analyzer) performMCEValidation(upcomingGAs []models.UpcomingGA, prCommi
 	validatedGAs := make([]models.UpcomingGA, len(upcomingGAs))
 	copy(validatedGAs, upcomingGAs)
 
-	// Use goroutines to parallelize MCE validation
+	// Use goroutines to parallelize MCE validation, limited to avoid overwhelming GitLab
 	var wg sync.WaitGroup
+	semaphore := make(chan struct{}, a.config.Concurrency.WithDefaults().MCEValidationWorkers)
 
 	for i := range validatedGAs {
 		wg.Add(1)
 		go func(index int) {
 			defer wg.Done()
 
+			semaphore <- struct{}{}
+			defer func() { <-semaphore }()
+
 			ga := &validatedGAs[index]
 
 			// Only validate versions that are already released
@@ -768,6 +1099,12 @@ func (a *Analyzer) performMCEValidation(upcomingGAs []models.UpcomingGA, prCommi
 
 			logger.Debug("Validating MCE snapshot for %s %s (released)", ga.Product, ga.Version)
 
+			_, gaSpan := a.tracer.Start(ctx, "ValidateMCESnapshot", trace.WithAttributes(
+				attribute.String("ga.product", ga.Product),
+				attribute.String("ga.version", ga.Version),
+			))
+			defer gaSpan.End()
+
 			// Determine component name based on repository
 			componentName := "assisted-service" // default
 			if a.config.Repository == "assisted-installer" {
@@ -778,8 +1115,13 @@ func (a *Analyzer) performMCEValidation(upcomingGAs []models.UpcomingGA, prCommi
 				componentName = "assisted-installer-ui"
 			}
 
-			validation, err := a.gitlabClient.ValidateMCESnapshotForComponent(ga.Product, ga.Version, ga.GADate, prCommitSHA, componentName)
+			var validation *models.MCESnapshotValidation
+			projectID, err := a.gitlabClient.ProjectForProduct(ga.Product)
+			if err == nil {
+				validation, err = a.gitlabClient.ValidateMCESnapshotForComponent(projectID, ga.Product, ga.Version, ga.GADate, prCommitSHA, componentName)
+			}
 			if err != nil {
+				recordSpanError(gaSpan, err)
 				logger.Debug("Failed to validate MCE snapshot for %s %s: %v", ga.Product, ga.Version, err)
 				ga.MCEValidation = &models.MCESnapshotValidation{
 					Product:           ga.Product,
@@ -814,7 +1156,6 @@ func (a *Analyzer) performMCEValidation(upcomingGAs []models.UpcomingGA, prCommi
 	return validatedGAs
 }
 
-
 // checkUIVersionInMCERelease checks if a specific UI version exists in an MCE release.
 func (a *Analyzer) checkUIVersionInMCERelease(product, version string, gaDate *time.Time, targetUIVersion string) bool {
 	if gaDate == nil {
@@ -831,7 +1172,12 @@ func (a *Analyzer) checkUIVersionInMCERelease(product, version string, gaDate *t
 	logger.Debug("Extracting UI version from %s %s", product, version)
 
 	// Use MCE validation logic to extract UI version from snapshot
-	validation, err := a.gitlabClient.ValidateMCESnapshotForComponent(product, version, gaDate, "", "assisted-installer-ui")
+	projectID, err := a.gitlabClient.ProjectForProduct(product)
+	if err != nil {
+		logger.Debug("Cannot extract UI version for %s %s: %v", product, version, err)
+		return false
+	}
+	validation, err := a.gitlabClient.ValidateMCESnapshotForComponent(projectID, product, version, gaDate, "", "assisted-installer-ui")
 	if err != nil {
 		logger.Debug("Failed to validate MCE snapshot for %s %s: %v", product, version, err)
 		return false
@@ -972,11 +1318,37 @@ func (a *Analyzer) IsSheetsUnavailable() bool {
 	return a.gaParser == nil || !a.gaParser.IsAvailable()
 }
 
-// GetGitLabClient returns the GitLab client instance
+// GetGitLabClient returns the GitLab client instance (nil if GitLab is not
+// configured). It is safe to call on a nil Analyzer.
 func (a *Analyzer) GetGitLabClient() *gitlab.Client {
+	if a == nil {
+		return nil
+	}
 	return a.gitlabClient
 }
 
+// GetGitHubClient returns the GitHub client instance. It is safe to call on a nil Analyzer.
+func (a *Analyzer) GetGitHubClient() *github.Client {
+	if a == nil {
+		return nil
+	}
+	return a.githubClient
+}
+
+// GetJiraClient returns the JIRA client instance (nil if JIRA is not configured).
+// It is safe to call on a nil Analyzer.
+func (a *Analyzer) GetJiraClient() *jira.Client {
+	if a == nil {
+		return nil
+	}
+	return a.jiraClient
+}
+
+// GetGAParser returns the GA parser instance (nil if Google Sheets is not configured)
+func (a *Analyzer) GetGAParser() *ga.Parser {
+	return a.gaParser
+}
+
 // CompareVersions compares a component version with its previous release and returns the commits between them.
 func (a *Analyzer) CompareVersions(component, version string) (*models.VersionComparisonResult, error) {
 	owner, repo := getRepositoryForComponent(component)
@@ -994,7 +1366,9 @@ func (a *Analyzer) CompareVersions(component, version string) (*models.VersionCo
 		return nil, fmt.Errorf("no release found with tag '%s' in %s/%s", version, owner, repo)
 	}
 
-	previousVersion, err := localRepo.FindPreviousVersion(version)
+	previousVersion, err := localRepo.FindPreviousVersion(version, func(tag string) bool {
+		return a.githubClient.IsReleasedTag(owner, repo, tag, a.config.IncludePreRelease)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("failed to find previous version: %w", err)
 	}
@@ -0,0 +1,111 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/models"
+)
+
+// PluginHook lets callers run their own logic around PR analyses, e.g. to post
+// results to an internal dashboard. Hooks may be called from several analyses at
+// once, so implementations must be safe for concurrent use. Results served from
+// the result cache run the hooks too, as if the PR had been analyzed again.
+type PluginHook interface {
+	// BeforeAnalysis is called when analysis of a PR starts.
+	BeforeAnalysis(ctx context.Context, prNumber int)
+	// AfterAnalysis is called with the result of a successful analysis.
+	AfterAnalysis(ctx context.Context, result *models.PRAnalysisResult)
+}
+
+// NoOpHook implements PluginHook with methods that do nothing. Embed it to
+// implement only the methods a hook needs.
+type NoOpHook struct{}
+
+// BeforeAnalysis does nothing.
+func (NoOpHook) BeforeAnalysis(ctx context.Context, prNumber int) {}
+
+// AfterAnalysis does nothing.
+func (NoOpHook) AfterAnalysis(ctx context.Context, result *models.PRAnalysisResult) {}
+
+// hookRegistry holds the hooks registered on an Analyzer.
+type hookRegistry struct {
+	mu    sync.RWMutex
+	hooks []PluginHook
+}
+
+// RegisterHook adds a hook that runs around every PR analysis.
+// Hooks run in registration order.
+func (a *Analyzer) RegisterHook(h PluginHook) {
+	a.hooks.mu.Lock()
+	defer a.hooks.mu.Unlock()
+	a.hooks.hooks = append(a.hooks.hooks, h)
+}
+
+// runHooks calls fn for each registered hook. A panicking hook is logged and
+// does not stop the analysis or the other hooks.
+func (a *Analyzer) runHooks(fn func(h PluginHook)) {
+	a.hooks.mu.RLock()
+	hooks := append([]PluginHook(nil), a.hooks.hooks...)
+	a.hooks.mu.RUnlock()
+
+	for _, h := range hooks {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					logger.Info("Plugin hook %T panicked: %v", h, r)
+				}
+			}()
+			fn(h)
+		}()
+	}
+}
+
+// MessagePoster posts a plain text Slack message to a channel or user ID.
+type MessagePoster interface {
+	PostSimpleMessage(ctx context.Context, channel, text string) error
+}
+
+// SlackNotificationHook sends the PR author a Slack DM when the analysis of their PR completes.
+type SlackNotificationHook struct {
+	NoOpHook
+
+	poster MessagePoster
+	users  map[string]string // GitHub login -> Slack user ID
+}
+
+// NewSlackNotificationHook creates a hook that DMs PR authors through poster.
+// users maps GitHub logins to Slack user IDs; authors without an entry are not notified.
+func NewSlackNotificationHook(poster MessagePoster, users map[string]string) *SlackNotificationHook {
+	return &SlackNotificationHook{
+		poster: poster,
+		users:  users,
+	}
+}
+
+// AfterAnalysis DMs the PR author how many release branches contain their PR.
+func (h *SlackNotificationHook) AfterAnalysis(ctx context.Context, result *models.PRAnalysisResult) {
+	if h.poster == nil || result == nil {
+		return
+	}
+	slackUserID, ok := h.users[result.PR.Author]
+	if !ok {
+		logger.Debug("No Slack user for GitHub author %q, skipping notification", result.PR.Author)
+		return
+	}
+
+	found := 0
+	for _, branch := range result.ReleaseBranches {
+		if branch.Found {
+			found++
+		}
+	}
+
+	text := fmt.Sprintf("🔍 Analysis of your PR #%d (%s) is complete: found in %d release branches.\n%s",
+		result.PR.Number, result.PR.Title, found, result.PR.URL)
+	if err := h.poster.PostSimpleMessage(ctx, slackUserID, text); err != nil {
+		logger.Debug("Failed to notify %s about PR #%d: %v", slackUserID, result.PR.Number, err)
+	}
+}
@@ -0,0 +1,244 @@
+package analyzer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/ga"
+	"github.com/shay23bra/pr-bot/internal/gitlab"
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/models"
+)
+
+// CompareMCEVersions compares the component SHAs recorded in the GitLab snapshots of
+// an MCE version and its previous release and returns the commits between them.
+// It needs GitLab and the release schedule to be configured.
+func (a *Analyzer) CompareMCEVersions(component, version string) (*models.VersionComparisonResult, error) {
+	if a.gitlabClient == nil {
+		return nil, fmt.Errorf("GitLab is not configured, set PR_BOT_GITLAB_TOKEN to compare MCE versions")
+	}
+	if a.gaParser == nil {
+		return nil, fmt.Errorf("the release schedule (Google Sheets) is not configured, it is needed to compare MCE versions")
+	}
+
+	previousVersion, err := FindPreviousMCEVersion(a.gitlabClient, a.gaParser, version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find previous MCE version: %w", err)
+	}
+
+	targetSHA, err := GetMCESHA(a.gitlabClient, component, version, MCEGADate(a.gaParser, version))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SHA for MCE %s: %w", version, err)
+	}
+	previousSHA, err := GetMCESHA(a.gitlabClient, component, previousVersion, MCEGADate(a.gaParser, previousVersion))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get SHA for MCE %s: %w", previousVersion, err)
+	}
+
+	owner, repo := getRepositoryForComponent(component)
+	result := &models.VersionComparisonResult{
+		Component:       component,
+		Owner:           owner,
+		Repository:      repo,
+		TargetVersion:   version,
+		PreviousVersion: previousVersion,
+	}
+	if targetSHA == previousSHA {
+		return result, nil
+	}
+
+	localRepo, err := a.repoManager.EnsureRepo(owner, repo, a.config.GitHubToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to ensure local repo: %w", err)
+	}
+	result.Commits, err = localRepo.LogBetween(previousSHA, targetSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commits between SHAs: %w", err)
+	}
+	return result, nil
+}
+
+// FindPreviousMCEVersion finds the previous MCE version using GitLab snapshot data:
+// X.Y.(Z-1) for a patch release, or the latest released version of the previous
+// minor series (from the release schedule) for X.Y.0.
+func FindPreviousMCEVersion(gitlabClient gitlab.SnapshotReader, gaParser *ga.Parser, version string) (string, error) {
+	logger.Debug("Finding previous MCE version for %s using GitLab snapshot data", version)
+
+	// Parse the version
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return "", fmt.Errorf("invalid version format: %s", version)
+	}
+
+	major, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return "", fmt.Errorf("invalid major version: %s", parts[0])
+	}
+
+	minor, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return "", fmt.Errorf("invalid minor version: %s", parts[1])
+	}
+
+	patch, err := strconv.Atoi(parts[2])
+	if err != nil {
+		return "", fmt.Errorf("invalid patch version: %s", parts[2])
+	}
+
+	if patch == 0 {
+		// For X.Y.0 versions, look in the previous minor branch (X.Y-1)
+		if minor == 0 {
+			return "", fmt.Errorf("cannot find previous version for %s (first minor version)", version)
+		}
+
+		previousMinorBranch := fmt.Sprintf("mce-%d.%d", major, minor-1)
+		logger.Debug("Looking for latest snapshot in previous minor branch: %s", previousMinorBranch)
+
+		// Try to verify the previous minor branch exists (optional verification)
+		projectID, err := gitlabClient.ProjectForProduct("MCE")
+		if err == nil {
+			_, err = gitlabClient.FindLatestSnapshot(projectID, previousMinorBranch)
+		}
+		if err != nil {
+			logger.Debug("Warning: Could not verify GitLab branch %s exists: %v. Proceeding with Excel data lookup.", previousMinorBranch, err)
+		}
+
+		// Find what versions exist in that branch by looking at Excel data
+		mceReleases, err := gaParser.GetAllMCEReleasesSorted()
+		if err != nil {
+			logger.Debug("Warning: failed to get MCE releases from Excel: %v", err)
+			// Fallback: assume latest patch in previous minor is high number
+			return fmt.Sprintf("%d.%d.10", major, minor-1), nil
+		}
+
+		// Releases are sorted newest first, so the first released match is the latest
+		var latestInPrevious string
+		expectedMinor := fmt.Sprintf("%d.%d", major, minor-1)
+
+		for _, release := range mceReleases {
+			if release.MCEVersion == "" || release.GADate == nil {
+				continue
+			}
+
+			releaseParts := strings.Split(release.MCEVersion, ".")
+			if len(releaseParts) < 2 || releaseParts[0]+"."+releaseParts[1] != expectedMinor {
+				continue
+			}
+
+			// Check if this version was actually released (GA date is in the past)
+			if release.GADate.Before(time.Now()) {
+				latestInPrevious = release.MCEVersion
+				break
+			}
+		}
+
+		if latestInPrevious != "" {
+			logger.Debug("Found latest released version in previous minor series: %s", latestInPrevious)
+			return latestInPrevious, nil
+		}
+
+		return "", fmt.Errorf("no released previous version found for %s in minor series %s", version, expectedMinor)
+
+	} else {
+		// For X.Y.Z versions where Z > 0, look for X.Y.(Z-1) in the same branch
+		previousPatch := patch - 1
+		if previousPatch < 0 {
+			return "", fmt.Errorf("cannot find previous patch version for %s", version)
+		}
+
+		previousVersion := fmt.Sprintf("%d.%d.%d", major, minor, previousPatch)
+		logger.Debug("Calculated previous patch version: %s", previousVersion)
+
+		// For patch versions, we assume the previous patch exists if we can find snapshots
+		// Let's verify the snapshot exists by trying to access the branch
+		currentBranch := fmt.Sprintf("mce-%d.%d", major, minor)
+		projectID, err := gitlabClient.ProjectForProduct("MCE")
+		if err != nil {
+			return "", err
+		}
+		if _, err := gitlabClient.FindLatestSnapshot(projectID, currentBranch); err != nil {
+			return "", fmt.Errorf("failed to find snapshots in branch %s: %w", currentBranch, err)
+		}
+
+		logger.Debug("Found snapshots in branch %s, previous version is: %s", currentBranch, previousVersion)
+		return previousVersion, nil
+	}
+}
+
+// GetMCESHA extracts the component SHA from MCE snapshot for given version. With a GA
+// date the snapshot closest to (but not after) it is used, since later snapshots in the
+// branch may already belong to the next patch; otherwise the latest snapshot is used.
+func GetMCESHA(gitlabClient gitlab.SnapshotReader, component, version string, gaDate *time.Time) (string, error) {
+	// Calculate MCE branch (e.g., 2.8.1 -> mce-2.8)
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 {
+		return "", fmt.Errorf("invalid version format: %s", version)
+	}
+	mceBranch := fmt.Sprintf("mce-%s.%s", parts[0], parts[1])
+
+	snapshot, err := findMCESnapshot(gitlabClient, mceBranch, gaDate)
+	if err != nil {
+		return "", fmt.Errorf("failed to find snapshot for MCE %s: %v", version, err)
+	}
+
+	// Extract SHA from the snapshot using existing GitLab client method
+	sha, err := gitlabClient.ExtractComponentSHA(mceBranch, snapshot, component)
+	if err != nil {
+		// Check if this is a version mismatch issue (simplified detection)
+		if strings.Contains(err.Error(), "no valid snapshots found with version") {
+			// Always try to get the actual version from this snapshot to provide a better error
+			actualVersion, versionErr := gitlabClient.GetVersionFromSnapshot(mceBranch, snapshot)
+			if versionErr == nil {
+				if actualVersion != version {
+					return "", fmt.Errorf("❌ MCE version mismatch: You requested %s, but the selected snapshot in %s branch contains %s.\n💡 Try: pr-bot -v mce %s %s", version, mceBranch, actualVersion, component, actualVersion)
+				} else {
+					// Same version but still failing - show the original error with context
+					return "", fmt.Errorf("❌ MCE %s error for component %s: %v\n💡 This might be a temporary GitLab issue or the component might not be available in this MCE version", version, component, err)
+				}
+			} else {
+				// Couldn't get version from snapshot, show original error with helpful context
+				return "", fmt.Errorf("❌ MCE %s error for component %s: %v\n💡 Unable to determine actual MCE version from snapshot. This might be a GitLab connectivity issue", version, component, err)
+			}
+		}
+
+		// For other types of errors, show the original error
+		return "", fmt.Errorf("failed to extract %s SHA from snapshot %s: %v", component, snapshot, err)
+	}
+
+	return sha, nil
+}
+
+// findMCESnapshot finds the snapshot folder for MCE branch in GitLab: the one closest
+// to gaDate when it is known and a snapshot exists by then, otherwise the latest one
+func findMCESnapshot(gitlabClient gitlab.SnapshotReader, mceBranch string, gaDate *time.Time) (string, error) {
+	projectID, err := gitlabClient.ProjectForProduct("MCE")
+	if err != nil {
+		return "", err
+	}
+	if gaDate != nil {
+		snapshot, err := gitlabClient.FindSnapshotClosestToDate(projectID, mceBranch, *gaDate)
+		if err == nil {
+			return snapshot, nil
+		}
+		logger.Debug("No snapshot by GA date %s in %s, using the latest: %v", models.FormatDate(gaDate), mceBranch, err)
+	}
+	return gitlabClient.FindLatestSnapshot(projectID, mceBranch)
+}
+
+// MCEGADate returns the GA date of an MCE version from the release schedule, or nil
+// when the version or its date is not listed.
+func MCEGADate(gaParser *ga.Parser, version string) *time.Time {
+	releases, err := gaParser.GetAllMCEReleases(ga.ReleaseListOptions{Sorted: false})
+	if err != nil {
+		logger.Debug("Failed to read release schedule for MCE %s GA date: %v", version, err)
+		return nil
+	}
+	for _, release := range releases {
+		if release.MCEVersion == version && release.GADate != nil {
+			return release.GADate
+		}
+	}
+	return nil
+}
@@ -0,0 +1,114 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/config"
+	"github.com/shay23bra/pr-bot/internal/github"
+	"github.com/shay23bra/pr-bot/internal/models"
+	"github.com/shay23bra/pr-bot/internal/resultcache"
+	"github.com/shay23bra/pr-bot/pkg/analyzer"
+)
+
+// prDiffOutput is the -pr-diff result written with -output json or yaml.
+type prDiffOutput struct {
+	PreviousAnalyzedAt *time.Time           `json:"previous_analyzed_at" yaml:"previous_analyzed_at"` // nil when no earlier result was cached
+	AnalyzedAt         time.Time            `json:"analyzed_at" yaml:"analyzed_at"`
+	Diff               *models.AnalysisDiff `json:"diff" yaml:"diff"`
+}
+
+// handlePRDiff analyzes a PR again and prints what changed since its cached result,
+// however old that result is. The fresh result replaces the cached one, so the next
+// -pr-diff compares against this run.
+func handlePRDiff(prURL, format string) {
+	var stdout *os.File
+	if format != outputText {
+		stdout = beginJSONOutput()
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load configuration: %v", err)
+	}
+
+	prNumber, owner, repo, err := github.ParsePRInput(prURL, github.EnterpriseHost(cfg.GitHubBaseURL))
+	if err != nil {
+		log.Fatalf("Failed to parse PR input '%s': %v", prURL, err)
+	}
+	if owner != "" && repo != "" {
+		cfg.Owner = owner
+		cfg.Repository = repo
+	}
+
+	dir, err := resultcache.DefaultDir()
+	if err != nil {
+		log.Fatalf("Failed to open result cache: %v", err)
+	}
+	cache := resultcache.New(dir, cfg.ResultCacheTTL)
+
+	previous, err := cache.GetLatest(cfg.Owner, cfg.Repository, prNumber)
+	if err != nil {
+		log.Fatalf("Failed to read cached result of PR #%d: %v", prNumber, err)
+	}
+
+	// The cache is only written, so AnalyzePR always analyzes again
+	progress := newProgressPrinter()
+	a, err := analyzer.New(context.Background(), cfg, createRepoManager(cfg),
+		analyzer.WithProgressFunc(progress.ProgressFunc(prURL)), analyzer.WithResultCache(cache, false))
+	if err != nil {
+		log.Fatalf("Failed to create analyzer: %v", err)
+	}
+
+	current, err := a.AnalyzePR(prNumber)
+	progress.Clear()
+	if err != nil {
+		log.Fatalf("Failed to analyze PR #%d: %v", prNumber, err)
+	}
+
+	diff := models.DiffAnalysisResults(previous, current)
+
+	if format != outputText {
+		output := prDiffOutput{AnalyzedAt: current.AnalyzedAt, Diff: diff}
+		if previous != nil {
+			output.PreviousAnalyzedAt = &previous.AnalyzedAt
+		}
+		writeStructured(stdout, format, output)
+		return
+	}
+
+	fmt.Printf("=== PR #%d Diff ===\n", prNumber)
+	if previous == nil {
+		fmt.Printf("No earlier analysis of PR #%d is cached. This analysis is saved for the next -pr-diff.\n", prNumber)
+		return
+	}
+	fmt.Printf("Comparing the analysis from %s with a fresh one\n", previous.AnalyzedAt.Local().Format("2006-01-02 15:04"))
+	printAnalysisDiff(diff)
+}
+
+// printAnalysisDiff prints the branch and GA changes of an analysis diff.
+func printAnalysisDiff(diff *models.AnalysisDiff) {
+	if !diff.HasChanges() {
+		fmt.Printf("\n✅ No changes\n")
+		return
+	}
+
+	fmt.Println()
+	for _, branch := range diff.NewBranches {
+		fmt.Printf("  ➕ Now in %s (v%s)%s\n", branch.BranchName, branch.Version, branch.CherryPickNote())
+	}
+	for _, branch := range diff.RemovedBranches {
+		fmt.Printf("  ➖ No longer found in %s\n", branch.BranchName)
+	}
+	for _, change := range diff.GADateChanges {
+		fmt.Printf("  📅 %s %s GA date (%s): %s → %s\n", change.Product, change.Version, change.BranchName,
+			models.FormatDateWithNil(change.OldDate), models.FormatDateWithNil(change.NewDate))
+	}
+	for _, change := range diff.UpcomingGAChanges {
+		fmt.Printf("  🗓️  Upcoming GAs for %s: %s → %s\n", change.BranchName,
+			models.FormatUpcomingGAs(change.Before), models.FormatUpcomingGAs(change.After))
+	}
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/shay23bra/pr-bot/internal/logger"
+	"github.com/shay23bra/pr-bot/internal/profile"
+)
+
+// findProfile returns the profile called name, or nil if there is none. Profile
+// file errors are logged and treated as no profile.
+func findProfile(name string) *profile.Profile {
+	path, err := profile.DefaultPath()
+	if err != nil {
+		logger.Debug("Failed to locate profiles: %v", err)
+		return nil
+	}
+	profiles, err := profile.Load(path)
+	if err != nil {
+		logger.Debug("Failed to load profiles: %v", err)
+		return nil
+	}
+	for i := range profiles {
+		if profiles[i].Name == name {
+			return &profiles[i]
+		}
+	}
+	return nil
+}
+
+// handleProfileAdd prompts for the fields of a new profile on in and appends it
+// to ~/.pr-bot/profiles.yaml.
+func handleProfileAdd(in io.Reader, out io.Writer) error {
+	path, err := profile.DefaultPath()
+	if err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(in)
+	prompt := func(label string) string {
+		fmt.Fprintf(out, "%s: ", label)
+		if !scanner.Scan() {
+			return ""
+		}
+		return strings.TrimSpace(scanner.Text())
+	}
+
+	p := profile.Profile{
+		Name:         prompt("Profile name"),
+		Owner:        prompt("Repository owner (e.g. openshift)"),
+		Repo:         prompt("Repository name (e.g. assisted-installer-agent)"),
+		BranchPrefix: prompt("Release branch prefix (optional, e.g. release-ocm-)"),
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("failed to read input: %w", err)
+	}
+
+	if err := profile.Add(path, p); err != nil {
+		return err
+	}
+	fmt.Fprintf(out, "✅ Added profile %s (%s/%s) to %s\n", p.Name, p.Owner, p.Repo, path)
+	return nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"sort"
+	"syscall"
+	"time"
+
+	"github.com/shay23bra/pr-bot/internal/models"
+	"github.com/shay23bra/pr-bot/pkg/analyzer"
+)
+
+// Defaults for -poll-interval and -watch-timeout.
+const (
+	defaultWatchPollSeconds = 60
+	defaultWatchTimeout     = 24 * time.Hour
+)
+
+// watchOptions configures -watch for -pr.
+type watchOptions struct {
+	Enabled  bool
+	Interval time.Duration // Time between analyses
+	Timeout  time.Duration // How long to keep watching
+}
+
+// fetchTTL returns how long the local clone may go without a fetch while
+// watching: under one poll interval, so every poll sees newly pushed commits.
+func (w watchOptions) fetchTTL() time.Duration {
+	return w.Interval / 2
+}
+
+// watchPR analyzes a PR again every interval until timeout elapses or the user
+// presses Ctrl-C, printing the release branches it newly appears in or drops out of. previous is the
+// result of the initial analysis. a is reused so release branch lists stay cached;
+// only the PR's presence in each branch is checked again.
+func watchPR(a *analyzer.Analyzer, prNumber int, previous *models.PRAnalysisResult, interval, timeout time.Duration, progress *progressPrinter) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	fmt.Printf("\n👀 Watching PR #%d every %s for up to %s (Ctrl-C to stop)...\n", prNumber, interval, timeout)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			if ctx.Err() == context.DeadlineExceeded {
+				fmt.Printf("\n⏱️  Stopped watching PR #%d after %s\n", prNumber, timeout)
+			} else {
+				fmt.Printf("\n🛑 Stopped watching PR #%d\n", prNumber)
+			}
+			return
+		case <-ticker.C:
+		}
+
+		// JIRA analysis is skipped, it does not change the PR's own branches
+		result, err := a.AnalyzePRWithOptions(prNumber, true)
+		progress.Clear()
+		if err != nil {
+			fmt.Printf("%s ⚠️  Failed to analyze PR #%d: %v\n", time.Now().Format("15:04:05"), prNumber, err)
+			continue
+		}
+
+		added, removed := foundBranchChanges(previous, result)
+		if len(added) > 0 {
+			fmt.Printf("\n%s 🎉 PR #%d landed in %d more release branch(es):\n", time.Now().Format("15:04:05"), prNumber, len(added))
+			for _, branch := range added {
+				fmt.Printf("  + %s (v%s)%s\n", branch.BranchName, branch.Version, branch.CherryPickNote())
+			}
+		}
+		if len(removed) > 0 {
+			fmt.Printf("\n%s ⚠️  PR #%d is no longer found in %d release branch(es):\n", time.Now().Format("15:04:05"), prNumber, len(removed))
+			for _, branch := range removed {
+				fmt.Printf("  - %s (v%s)\n", branch.BranchName, branch.Version)
+			}
+		}
+		previous = result
+	}
+}
+
+// foundBranchChanges returns the branches current found the PR in that previous
+// did not (added), and those previous found it in that current does not (removed),
+// e.g. after a revert or force-push. Both are sorted by name.
+func foundBranchChanges(previous, current *models.PRAnalysisResult) (added, removed []models.BranchPresence) {
+	before := foundBranchesByName(previous)
+	after := foundBranchesByName(current)
+
+	for name, branch := range after {
+		if _, ok := before[name]; !ok {
+			added = append(added, branch)
+		}
+	}
+	for name, branch := range before {
+		if _, ok := after[name]; !ok {
+			removed = append(removed, branch)
+		}
+	}
+	sort.Slice(added, func(i, j int) bool { return added[i].BranchName < added[j].BranchName })
+	sort.Slice(removed, func(i, j int) bool { return removed[i].BranchName < removed[j].BranchName })
+	return added, removed
+}
+
+// foundBranchesByName returns the release branches result found the PR in, by name.
+func foundBranchesByName(result *models.PRAnalysisResult) map[string]models.BranchPresence {
+	found := make(map[string]models.BranchPresence)
+	for _, branch := range result.ReleaseBranches {
+		if branch.Found {
+			found[branch.BranchName] = branch
+		}
+	}
+	return found
+}
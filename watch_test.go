@@ -0,0 +1,83 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/shay23bra/pr-bot/internal/models"
+)
+
+// analysisWithBranches returns a result whose release branches are the found names
+// followed by the missing ones.
+func analysisWithBranches(found, missing []string) *models.PRAnalysisResult {
+	result := &models.PRAnalysisResult{}
+	for _, name := range found {
+		result.ReleaseBranches = append(result.ReleaseBranches, models.BranchPresence{BranchName: name, Found: true})
+	}
+	for _, name := range missing {
+		result.ReleaseBranches = append(result.ReleaseBranches, models.BranchPresence{BranchName: name})
+	}
+	return result
+}
+
+// branchNames returns the names of branches, nil for none.
+func branchNames(branches []models.BranchPresence) []string {
+	var names []string
+	for _, branch := range branches {
+		names = append(names, branch.BranchName)
+	}
+	return names
+}
+
+func TestFoundBranchChanges(t *testing.T) {
+	tests := []struct {
+		name        string
+		previous    *models.PRAnalysisResult
+		current     *models.PRAnalysisResult
+		wantAdded   []string
+		wantRemoved []string
+	}{
+		{
+			name:     "unchanged",
+			previous: analysisWithBranches([]string{"release-4.15"}, []string{"release-4.14"}),
+			current:  analysisWithBranches([]string{"release-4.15"}, []string{"release-4.14"}),
+		},
+		{
+			name:      "newly found",
+			previous:  analysisWithBranches([]string{"release-4.15"}, []string{"release-4.14", "release-4.13"}),
+			current:   analysisWithBranches([]string{"release-4.15", "release-4.14", "release-4.13"}, nil),
+			wantAdded: []string{"release-4.13", "release-4.14"},
+		},
+		{
+			name:        "no longer found after a revert",
+			previous:    analysisWithBranches([]string{"release-4.15", "release-4.14"}, nil),
+			current:     analysisWithBranches([]string{"release-4.15"}, []string{"release-4.14"}),
+			wantRemoved: []string{"release-4.14"},
+		},
+		{
+			name:        "branch gone from the listing",
+			previous:    analysisWithBranches([]string{"release-4.15", "release-4.14"}, nil),
+			current:     analysisWithBranches([]string{"release-4.15"}, nil),
+			wantRemoved: []string{"release-4.14"},
+		},
+		{
+			name:        "added and removed",
+			previous:    analysisWithBranches([]string{"release-4.14"}, []string{"release-4.15"}),
+			current:     analysisWithBranches([]string{"release-4.15"}, []string{"release-4.14"}),
+			wantAdded:   []string{"release-4.15"},
+			wantRemoved: []string{"release-4.14"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			added, removed := foundBranchChanges(tt.previous, tt.current)
+			if got := branchNames(added); !reflect.DeepEqual(got, tt.wantAdded) {
+				t.Errorf("added = %q, want %q", got, tt.wantAdded)
+			}
+			if got := branchNames(removed); !reflect.DeepEqual(got, tt.wantRemoved) {
+				t.Errorf("removed = %q, want %q", got, tt.wantRemoved)
+			}
+		})
+	}
+}